@@ -0,0 +1,290 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+//// CHECKED ARITHMETIC
+///
+// evaluates addition, substraction, multiplication, quotient and power of
+// naturals and integers without wrapping. operands are promoted to a common
+// type by NumberPrec first. results exceeding the range of that type are
+// promoted to BigIntVal, quotients that are not integral and negative powers
+// to RatioVal. division by zero yields an ErrorVal.
+func Checked(op OpStr, a, b Native) Native { return checked(op, a, b, false) }
+
+// like checked, but yields an ErrorVal, whenever the result would have to be
+// promoted.
+func Strict(op OpStr, a, b Native) Native { return checked(op, a, b, true) }
+
+func checked(op OpStr, a, b Native, strict bool) Native {
+	var at, bt = a.Type(), b.Type()
+	if !at.Match(Naturals|Integers) || !bt.Match(Naturals|Integers) {
+		return ErrorVal{fmt.Errorf(
+			"checked arithmetic is not defined for types %s and %s",
+			at.TypeName(), bt.TypeName())}
+	}
+	switch op {
+	case Add, Substract, Multiply, Quotient, Power:
+	default:
+		return ErrorVal{errOperator(op, a)}
+	}
+	var typ = NumberPrec(at, bt)
+	if isZero(b) && op == Quotient {
+		return ErrorVal{fmt.Errorf("division by zero: %s %s %s", a, op, b)}
+	}
+	var res Native
+	var exact bool
+	switch {
+	case typ == BigInt:
+		res, exact = nil, false
+	case typ.Match(Naturals):
+		res, exact = checkedUint(op,
+			uint64(Promote(a, typ).(Natural).GoUint()),
+			uint64(Promote(b, typ).(Natural).GoUint()), typ)
+	default:
+		res, exact = checkedInt(op,
+			int64(Promote(a, typ).(Integer).GoInt()),
+			int64(Promote(b, typ).(Integer).GoInt()), typ)
+	}
+	if exact {
+		return res
+	}
+	if strict && typ != BigInt {
+		return ErrorVal{fmt.Errorf("%s %s %s exceeds type %s",
+			a, op, b, typ.TypeName())}
+	}
+	var x, y = Promote(a, BigInt), Promote(b, BigInt)
+	if op == Quotient {
+		var q = new(big.Rat).SetFrac(x.(BigIntVal).GoBigInt(), y.(BigIntVal).GoBigInt())
+		if q.IsInt() {
+			return BigIntVal(*new(big.Int).Set(q.Num()))
+		}
+		if strict {
+			return ErrorVal{fmt.Errorf("%s %s %s is not integral", a, op, b)}
+		}
+		return RatioVal(*q)
+	}
+	if op == Power && strict && y.(BigIntVal).GoBigInt().Sign() < 0 {
+		var abs = new(big.Int).Abs(x.(BigIntVal).GoBigInt())
+		if abs.Cmp(big.NewInt(1)) != 0 {
+			return ErrorVal{fmt.Errorf("%s %s %s is not integral", a, op, b)}
+		}
+	}
+	if op == Power {
+		if err := checkPowBits(x.(BigIntVal).GoBigInt(), y.(BigIntVal).GoBigInt()); err != nil {
+			return ErrorVal{err}
+		}
+	}
+	var nat, err = Eval(op, x, y)
+	if err != nil {
+		return ErrorVal{err}
+	}
+	return nat
+}
+
+// evaluates the operation on unsigned operands, returns false, if the result
+// can not be represented by the type passed.
+func checkedUint(op OpStr, x, y uint64, typ TyNat) (Native, bool) {
+	var res, carry uint64
+	switch op {
+	case Add:
+		res, carry = bits.Add64(x, y, 0)
+	case Substract:
+		res, carry = bits.Sub64(x, y, 0)
+	case Multiply:
+		carry, res = bits.Mul64(x, y)
+	case Quotient:
+		if x%y != 0 {
+			return nil, false
+		}
+		res = x / y
+	case Power:
+		var ok bool
+		if res, ok = powUintChecked(x, y); !ok {
+			return nil, false
+		}
+	}
+	if carry != 0 || res > maxUint(typ) {
+		return nil, false
+	}
+	switch typ {
+	case Uint8:
+		return Uint8Val(res), true
+	case Uint16:
+		return Uint16Val(res), true
+	case Uint32:
+		return Uint32Val(res), true
+	}
+	return UintVal(res), true
+}
+
+// evaluates the operation on signed operands, returns false, if the result
+// can not be represented by the type passed.
+func checkedInt(op OpStr, x, y int64, typ TyNat) (Native, bool) {
+	var res int64
+	switch op {
+	case Add:
+		res = x + y
+		if (x > 0 && y > 0 && res < 0) || (x < 0 && y < 0 && res >= 0) {
+			return nil, false
+		}
+	case Substract:
+		res = x - y
+		if (x >= 0 && y < 0 && res < 0) || (x < 0 && y > 0 && res >= 0) {
+			return nil, false
+		}
+	case Multiply:
+		var hi, lo = bits.Mul64(absInt(x), absInt(y))
+		var ok bool
+		if res, ok = signedOf(hi, lo, (x < 0) != (y < 0)); !ok {
+			return nil, false
+		}
+	case Quotient:
+		if x%y != 0 || (x == math.MinInt64 && y == -1) {
+			return nil, false
+		}
+		res = x / y
+	case Power:
+		if y < 0 {
+			if x != 1 && x != -1 {
+				return nil, false
+			}
+			y = -y
+		}
+		var mag, ok = powUintChecked(absInt(x), uint64(y))
+		if !ok {
+			return nil, false
+		}
+		if res, ok = signedOf(0, mag, x < 0 && y%2 == 1); !ok {
+			return nil, false
+		}
+	}
+	if res < minInt(typ) || res > maxInt(typ) {
+		return nil, false
+	}
+	switch typ {
+	case Int8:
+		return Int8Val(res), true
+	case Int16:
+		return Int16Val(res), true
+	case Int32:
+		return Int32Val(res), true
+	}
+	return IntVal(res), true
+}
+
+func absInt(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+	return uint64(i)
+}
+
+// yields the signed value of the 128 bit magnitude passed, if it fits
+func signedOf(hi, lo uint64, neg bool) (int64, bool) {
+	if hi != 0 {
+		return 0, false
+	}
+	if neg {
+		if lo > 1<<63 {
+			return 0, false
+		}
+		return int64(-lo), true
+	}
+	if lo > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(lo), true
+}
+
+func maxUint(typ TyNat) uint64 {
+	switch typ {
+	case Uint8:
+		return math.MaxUint8
+	case Uint16:
+		return math.MaxUint16
+	case Uint32:
+		return math.MaxUint32
+	}
+	return math.MaxUint64
+}
+
+func minInt(typ TyNat) int64 {
+	switch typ {
+	case Int8:
+		return math.MinInt8
+	case Int16:
+		return math.MinInt16
+	case Int32:
+		return math.MinInt32
+	}
+	return math.MinInt64
+}
+
+func maxInt(typ TyNat) int64 {
+	switch typ {
+	case Int8:
+		return math.MaxInt8
+	case Int16:
+		return math.MaxInt16
+	case Int32:
+		return math.MaxInt32
+	}
+	return math.MaxInt64
+}
+
+// exponentiation by squaring, returns false if the result overflows
+func powUintChecked(x, n uint64) (uint64, bool) {
+	var res = uint64(1)
+	for n > 0 {
+		if n&1 == 1 {
+			var hi, lo = bits.Mul64(res, x)
+			if hi != 0 {
+				return 0, false
+			}
+			res = lo
+		}
+		if n >>= 1; n > 0 {
+			var hi, lo = bits.Mul64(x, x)
+			if hi != 0 {
+				return 0, false
+			}
+			x = lo
+		}
+	}
+	return res, true
+}
+
+// exponentiation by squaring, wrapping on overflow like the other arithmetic
+// methods of fixed size numbers do.
+func powUint(x, n uint64) uint64 {
+	var res = uint64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			res *= x
+		}
+		x *= x
+	}
+	return res
+}
+
+// negative exponents yield the integral part of the inverse power
+func powInt(x, n int64) int64 {
+	if n < 0 {
+		switch x {
+		case 1:
+			return 1
+		case -1:
+			if n%2 == 0 {
+				return 1
+			}
+			return -1
+		}
+		return 0
+	}
+	return int64(powUint(uint64(x), uint64(n)))
+}
@@ -0,0 +1,65 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var checkedCases = []struct {
+	op      OpStr
+	a, b    Native
+	checked string
+	typ     TyNat
+	strict  bool // strict evaluation succeeds
+}{
+	{Add, Int8Val(100), Int8Val(27), "127", Int8, true},
+	{Add, Int8Val(100), Int8Val(28), "128", BigInt, false},
+	{Substract, Uint8Val(2), Uint8Val(3), "-1", BigInt, false},
+	{Multiply, UintVal(math.MaxUint64), UintVal(2), "36893488147419103230", BigInt, false},
+	{Multiply, IntVal(math.MinInt64), IntVal(1), "-9223372036854775808", Int, true},
+	{Multiply, IntVal(math.MinInt64), IntVal(-1), "9223372036854775808", BigInt, false},
+	{Quotient, IntVal(8), IntVal(2), "4", Int, true},
+	{Quotient, IntVal(7), IntVal(2), "7/2", Ratio, false},
+	{Power, IntVal(3), IntVal(4), "81", Int, true},
+	{Power, IntVal(-2), IntVal(63), "-9223372036854775808", Int, true},
+	{Power, IntVal(2), IntVal(64), "18446744073709551616", BigInt, false},
+	{Power, IntVal(2), IntVal(-2), "1/4", Ratio, false},
+	{Power, IntVal(-1), IntVal(-3), "-1", Int, true},
+	{Power, Uint16Val(2), Uint16Val(15), "32768", Uint16, true},
+}
+
+func TestChecked(t *testing.T) {
+	for _, c := range checkedCases {
+		var res = Checked(c.op, c.a, c.b)
+		fmt.Printf("%s %s %s = %s\n", c.a, c.op, c.b, res)
+		if res.String() != c.checked || res.Type() != c.typ {
+			t.Log("expected", c.checked, c.typ, "got", res, res.Type())
+			t.Fail()
+		}
+		var strict = Strict(c.op, c.a, c.b)
+		if _, isErr := strict.(ErrorVal); isErr == c.strict {
+			t.Log("unexpected strict result", strict)
+			t.Fail()
+		}
+	}
+	if _, ok := Checked(Quotient, IntVal(1), IntVal(0)).(ErrorVal); !ok {
+		t.Log("division by zero should yield an error")
+		t.Fail()
+	}
+	if _, ok := Checked(Power, IntVal(3), IntVal(1<<34)).(ErrorVal); !ok {
+		t.Log("power exceeding the limit of big integers should yield an error")
+		t.Fail()
+	}
+}
+
+func TestPowerMethods(t *testing.T) {
+	if r := IntVal(3).Power(IntVal(3)); r != 27 {
+		t.Log("3^3 should be 27, got", r)
+		t.Fail()
+	}
+	if r := Uint8Val(2).PowerU(UintVal(10)); r != 1024 {
+		t.Log("2^10 should be 1024, got", r)
+		t.Fail()
+	}
+}
@@ -0,0 +1,428 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+//// TAGGED BINARY CODEC
+///
+// the encoder writes the type flag of every value ahead of the value itself,
+// so that heterogeneous trees of natives can be decoded without knowing their
+// types in advance.
+//
+// scalar:  flag | length | MarshalBinary bytes
+// pair:    flag | left value | right value
+// slice:   flag | count | values…
+// unboxed: flag | element flag | count | (length | element bytes)…
+// map:     flag | key flag | count | (key value | value)…
+// table:   flag | count | (name value | column value)…
+//
+// persistent collections report the type of the collection they are a
+// persistent version of. they are tagged by that type combined with the
+// type flag, which no native reports along with other flags, to be decoded
+// as persistent collection again.
+//
+// hash map:    Map|Type | count | (key value | value)…
+// ordered map: Map|Pair|Type | count | (key value | value)…
+// sorted set:  Slice|Map|Type | count | values…
+// vector:      Slice|Type | count | values…
+type Encoder struct {
+	w io.Writer
+}
+
+const (
+	tagHashMap    = Map | Type
+	tagOrderedMap = Map | Pair | Type
+	tagSortedSet  = Slice | Map | Type
+	tagVector     = Slice | Type
+)
+
+func NewEncoder(w io.Writer) *Encoder { return &Encoder{w} }
+
+// encodes the native passed and writes it to the underlying writer.
+func (e *Encoder) Encode(nat Native) error {
+	var buf, err = encodeNative(nil, nat)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(buf)
+	return err
+}
+
+func encodeNative(buf []byte, nat Native) ([]byte, error) {
+	var err error
+	switch v := nat.(type) {
+	case PairVal:
+		buf = appendUvarint(buf, uint64(Pair))
+		if buf, err = encodeNative(buf, v.Left()); err != nil {
+			return nil, err
+		}
+		return encodeNative(buf, v.Right())
+	case DataSlice:
+		buf = appendUvarint(buf, uint64(Slice))
+		return encodeElems(buf, v)
+	case SortedSet:
+		buf = appendUvarint(buf, uint64(tagSortedSet))
+		return encodeElems(buf, v.Slice())
+	case Vector:
+		buf = appendUvarint(buf, uint64(tagVector))
+		return encodeElems(buf, v.Slice())
+	case Table:
+		buf = appendUvarint(buf, uint64(Tabular))
+		buf = appendUvarint(buf, uint64(v.Width()))
+		for i, name := range v.names {
+			if buf, err = encodeNative(buf, StrVal(name)); err != nil {
+				return nil, err
+			}
+			if buf, err = encodeNative(buf, v.cols[i]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case HashMap:
+		buf = appendUvarint(buf, uint64(tagHashMap))
+		return encodeFields(buf, v.Fields())
+	case OrderedMap:
+		buf = appendUvarint(buf, uint64(tagOrderedMap))
+		return encodeFields(buf, v.Fields())
+	case Mapped:
+		buf = appendUvarint(buf, uint64(Map))
+		buf = appendUvarint(buf, uint64(mapKeyType(v)))
+		return encodeFields(buf, v.Fields())
+	case Sliceable:
+		if !nat.Type().Match(Unboxed) {
+			break
+		}
+		buf = appendUvarint(buf, uint64(Unboxed))
+		buf = appendUvarint(buf, uint64(v.TypeElem().Flag()))
+		buf = appendUvarint(buf, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if buf, err = encodePayload(buf, v.GetInt(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	buf = appendUvarint(buf, uint64(nat.Type()))
+	return encodePayload(buf, nat)
+}
+
+// appends the number of elements, followed by each element
+func encodeElems(buf []byte, elems []Native) ([]byte, error) {
+	var err error
+	buf = appendUvarint(buf, uint64(len(elems)))
+	for _, elem := range elems {
+		if buf, err = encodeNative(buf, elem); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// appends the number of fields, followed by key and value of each field
+func encodeFields(buf []byte, fields []Paired) ([]byte, error) {
+	var err error
+	buf = appendUvarint(buf, uint64(len(fields)))
+	for _, field := range fields {
+		if buf, err = encodeNative(buf, field.Left()); err != nil {
+			return nil, err
+		}
+		if buf, err = encodeNative(buf, field.Right()); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// appends length and binary encoding of a scalar native
+func encodePayload(buf []byte, nat Native) ([]byte, error) {
+	var b, err = marshalNative(nat)
+	if err != nil {
+		return nil, err
+	}
+	buf = appendUvarint(buf, uint64(len(b)))
+	return append(buf, b...), nil
+}
+
+// yields the flag, the binary encoding of a map is prefixed with to indicate
+// its key type. generic maps are flagged as map.
+func mapKeyType(m Mapped) TyNat {
+	switch m.(type) {
+	case MapString:
+		return String
+	case MapInt:
+		return Int
+	case MapUint:
+		return Uint
+	case MapFloat:
+		return Float
+	case MapFlag:
+		return Flag
+	}
+	return Map
+}
+
+type Decoder struct {
+	r byteReader
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	if br, ok := r.(byteReader); ok {
+		return &Decoder{br}
+	}
+	return &Decoder{bufio.NewReader(r)}
+}
+
+// reads the next value from the underlying reader and returns it as native.
+// returns io.EOF, when there are no more values left to read.
+func (d *Decoder) Decode() (Native, error) {
+	var flag, err = binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	var nat Native
+	if nat, err = d.decode(TyNat(flag)); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return nat, err
+}
+
+func (d *Decoder) decode(flag TyNat) (Native, error) {
+	switch flag {
+	case Pair:
+		var left, right Native
+		var err error
+		if left, err = d.next(); err != nil {
+			return nil, err
+		}
+		if right, err = d.next(); err != nil {
+			return nil, err
+		}
+		return NewPair(left, right), nil
+	case Slice:
+		var elems, err = d.elems()
+		if err != nil {
+			return nil, err
+		}
+		return NewSlice(elems...), nil
+	case tagSortedSet, tagVector:
+		var elems, err = d.elems()
+		if err != nil {
+			return nil, err
+		}
+		if flag == tagVector {
+			return NewVector(elems...), nil
+		}
+		return NewSortedSet(elems...), nil
+	case Unboxed:
+		var elem, err = binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		var count int
+		if count, err = d.count(); err != nil {
+			return nil, err
+		}
+		var slice = make([]Native, 0, prealloc(count))
+		for i := 0; i < count; i++ {
+			var nat Native
+			if nat, err = d.payload(TyNat(elem)); err != nil {
+				return nil, err
+			}
+			slice = append(slice, nat)
+		}
+		var vec = NewUnboxed(TyNat(elem), slice...)
+		if vec == nil {
+			return nil, fmt.Errorf(
+				"no unboxed vector defined for type %s",
+				TyNat(elem).TypeName())
+		}
+		return vec, nil
+	case Map:
+		var key, err = binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		var count int
+		if count, err = d.count(); err != nil {
+			return nil, err
+		}
+		var m Mapped
+		switch TyNat(key) {
+		case String:
+			m = NewStringMap()
+		case Int:
+			m = NewIntMap()
+		case Uint:
+			m = NewUintMap()
+		case Float:
+			m = NewFloatMap()
+		case Flag:
+			m = NewFLagMap()
+		default:
+			return d.decodeValMap(count)
+		}
+		for i := 0; i < count; i++ {
+			var k, v Native
+			if k, err = d.next(); err != nil {
+				return nil, err
+			}
+			if v, err = d.next(); err != nil {
+				return nil, err
+			}
+			if !mapKeyType(m).Match(k.Type()) {
+				return nil, errKeyType(mapKeyType(m), k)
+			}
+			m = m.Set(k, v)
+		}
+		return m, nil
+	case Tabular:
+		var count, err = d.count()
+		if err != nil {
+			return nil, err
+		}
+		var cols = make([]Column, 0, prealloc(count))
+		for i := 0; i < count; i++ {
+			var name, col Native
+			if name, err = d.next(); err != nil {
+				return nil, err
+			}
+			if col, err = d.next(); err != nil {
+				return nil, err
+			}
+			var vec, ok = col.(Sliceable)
+			if name.Type() != String || !ok {
+				return nil, fmt.Errorf("malformed column %s of table", name)
+			}
+			cols = append(cols, Column{name.String(), vec})
+		}
+		return NewTable(cols...)
+	case tagHashMap, tagOrderedMap:
+		var count, err = d.count()
+		if err != nil {
+			return nil, err
+		}
+		var fields []Paired
+		if fields, err = d.fields(count); err != nil {
+			return nil, err
+		}
+		if flag == tagOrderedMap {
+			return NewOrderedMap(fields...), nil
+		}
+		var b = NewHashMapBuilder()
+		for _, field := range fields {
+			b.Set(field.Left(), field.Right())
+		}
+		return b.Persistent(), nil
+	}
+	var nat, err = d.payload(flag)
+	if err != nil {
+		return nil, err
+	}
+	return NewData(nat), nil
+}
+
+// decodes the fields of a generic map. maps with keys, that go can't hash,
+// like slices and byte slices, are decoded as hashed maps.
+func (d *Decoder) decodeValMap(count int) (Native, error) {
+	var fields, err = d.fields(count)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if !reflect.TypeOf(field.Left()).Comparable() {
+			return NewHashedMap(fields...), nil
+		}
+	}
+	return NewValMap(fields...), nil
+}
+
+// reads the number of elements, followed by the elements
+func (d *Decoder) elems() ([]Native, error) {
+	var count, err = d.count()
+	if err != nil {
+		return nil, err
+	}
+	var elems = make([]Native, 0, prealloc(count))
+	for i := 0; i < count; i++ {
+		var elem Native
+		if elem, err = d.next(); err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// reads the number of fields passed, each of key and value
+func (d *Decoder) fields(count int) ([]Paired, error) {
+	var fields = make([]Paired, 0, prealloc(count))
+	for i := 0; i < count; i++ {
+		var k, v Native
+		var err error
+		if k, err = d.next(); err != nil {
+			return nil, err
+		}
+		if v, err = d.next(); err != nil {
+			return nil, err
+		}
+		fields = append(fields, NewPair(k, v))
+	}
+	return fields, nil
+}
+
+// reads the type flag of the next nested value and decodes it.
+func (d *Decoder) next() (Native, error) {
+	var flag, err = binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	return d.decode(TyNat(flag))
+}
+
+// counts and lengths are read from the stream and can't be trusted. they
+// are limited to the range of an int32 and memory is allocated as elements
+// are read, instead of up front.
+const maxCodecCount = math.MaxInt32
+
+func (d *Decoder) count() (int, error) {
+	var u, err = binary.ReadUvarint(d.r)
+	if err != nil {
+		return 0, err
+	}
+	if u > maxCodecCount {
+		return 0, fmt.Errorf("count of %d exceeds the limit of %d", u, maxCodecCount)
+	}
+	return int(u), nil
+}
+
+func prealloc(count int) int {
+	if count > 1024 {
+		return 1024
+	}
+	return count
+}
+
+// reads a length prefixed scalar and decodes it as native of the type passed
+func (d *Decoder) payload(flag TyNat) (Native, error) {
+	var l, err = d.count()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err = io.CopyN(&buf, d.r, int64(l)); err != nil {
+		return nil, err
+	}
+	return UnmarshalNative(flag, buf.Bytes())
+}
@@ -0,0 +1,134 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	var buf = bytes.NewBuffer([]byte{})
+	var enc = NewEncoder(buf)
+	var tree = NewSlice(
+		IntVal(23),
+		NewPair(StrVal("vector"), IntVec{1, 2, 3}),
+		NewSlice(FltVal(4.2), StrVec{"a", "b"}, NewSlice()),
+		MapString{"ratios": RatioVec{big.NewRat(1, 3), big.NewRat(2, 3)}},
+		MapVal{IntVal(1): NewPair(BoolVal(true), NilVal{})},
+		FlagSet{BitFlag(Int), BitFlag(String)},
+	)
+	for _, nat := range marshalNatives {
+		if err := enc.Encode(nat); err != nil {
+			t.Log(err)
+			t.Fail()
+		}
+	}
+	if err := enc.Encode(tree); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	var dec = NewDecoder(buf)
+	for _, nat := range marshalNatives {
+		val, err := dec.Decode()
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if val.Type() != nat.Type() {
+			t.Log("type mismatch", nat.Type(), val.Type())
+			t.Fail()
+		}
+		if _, ok := nat.(Mapped); !ok && val.String() != nat.String() {
+			t.Log("value mismatch", nat, val)
+			t.Fail()
+		}
+	}
+	val, err := dec.Decode()
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	fmt.Println(val)
+	var slice = val.(DataSlice)
+	if vec, ok := slice[1].(PairVal).Right().(IntVec); !ok ||
+		vec.String() != "[1, 2, 3]" {
+		t.Log("unboxed vector should decode as IntVec", slice[1])
+		t.Fail()
+	}
+	if rats, _ := slice[3].(MapString).Get(StrVal("ratios")); rats.String() != "[1/3, 2/3]" {
+		t.Log("unboxed ratios should decode unchanged", rats)
+		t.Fail()
+	}
+	if _, ok := slice[5].(FlagSet); !ok {
+		t.Log("flag set should decode as FlagSet", slice[5])
+		t.Fail()
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Log("expected end of stream, got", err)
+		t.Fail()
+	}
+}
+
+func TestCodecTruncated(t *testing.T) {
+	var buf = bytes.NewBuffer([]byte{})
+	NewEncoder(buf).Encode(NewSlice(StrVal("truncated"), IntVal(1)))
+	var dec = NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-2]))
+	if _, err := dec.Decode(); err != io.ErrUnexpectedEOF {
+		t.Log("expected unexpected end of stream, got", err)
+		t.Fail()
+	}
+}
+
+func TestCodecMalformed(t *testing.T) {
+	var stream = func(us ...uint64) []byte {
+		var buf []byte
+		for _, u := range us {
+			buf = appendUvarint(buf, u)
+		}
+		return buf
+	}
+	var streams = [][]byte{
+		stream(uint64(Slice), math.MaxUint64),
+		stream(uint64(Slice), math.MaxInt32),
+		stream(uint64(Unboxed), uint64(Int), 1<<40),
+		stream(uint64(Unboxed), uint64(Int), 1<<30),
+		stream(uint64(Map), uint64(String), 1<<62),
+		stream(uint64(String), math.MaxInt32),
+		stream(uint64(String), 1<<63),
+	}
+	for _, s := range streams {
+		var _, err = NewDecoder(bytes.NewReader(s)).Decode()
+		if err == nil {
+			t.Log("expected malformed stream to fail", s)
+			t.Fail()
+		}
+	}
+}
+
+func TestCodecUnhashableKeys(t *testing.T) {
+	var buf = bytes.NewBuffer([]byte{})
+	var enc = NewEncoder(buf)
+	var hashed = NewHashedMap(
+		NewPair(BytesVal("key"), IntVal(1)),
+		NewPair(BytesVal("other"), IntVal(2)))
+	if err := enc.Encode(hashed); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var dec = NewDecoder(buf)
+	var val, err = dec.Decode()
+	fmt.Println(val, err)
+	if err != nil || val.(Mapped).Len() != 2 {
+		t.Log("expected map keyed by byte slices to decode", val, err)
+		t.FailNow()
+	}
+	if v, ok := val.(Mapped).Get(BytesVal("other")); !ok || v != IntVal(2) {
+		t.Log("expected field keyed by byte slice", val)
+		t.Fail()
+	}
+}
@@ -0,0 +1,256 @@
+package data
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+//// TOTAL ORDER
+///
+// compares two natives of arbitrary type, returns -1 if a is lesser, 1 if
+// it is greater than b and 0, if both are equal.
+//
+// numbers, booleans and bytes are compared by their value across all widths,
+// not a number is lesser than negative infinity, imaginary numbers are
+// compared by their real part first. strings, runes and bytes are compared
+// lexicographically, times chronologically. pairs, slices, unboxed vectors
+// and maps compare their elements lexicographically, fields of maps in
+// order of their keys, shorter collections are lesser than collections they
+// are a prefix of. natives of the same class, that compare equal, are
+// ordered by their type flag, natives of different classes by the least
+// flag of their class.
+func Compare(a, b Native) int {
+	a, b = indirect(a), indirect(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	var at, bt = a.Type(), b.Type()
+	var ac, bc = classOf(at), classOf(bt)
+	if ac != bc {
+		var x, y = ac.Flag().Least(), bc.Flag().Least()
+		return cmpOf(x < y, x > y)
+	}
+	var c int
+	switch ac {
+	case numerals:
+		c = compareNumbers(a, b)
+	case Letters:
+		c = strings.Compare(stringOf(a), stringOf(b))
+	case Time:
+		var x, y = time.Time(a.(TimeVal)), time.Time(b.(TimeVal))
+		c = cmpOf(x.Before(y), x.After(y))
+	case Duration:
+		c = cmpOf(a.(DuraVal) < b.(DuraVal), a.(DuraVal) > b.(DuraVal))
+	case Flag, Type:
+		var x, y = uint64(a.(Typed).Flag()), uint64(b.(Typed).Flag())
+		c = cmpOf(x < y, x > y)
+	case Error:
+		c = strings.Compare(stringOf(a), stringOf(b))
+	case Pair:
+		c = comparePairs(a, b)
+	case sequences:
+		c = compareSlices(elemsOf(a), elemsOf(b))
+	case Map:
+		c = compareMaps(a, b)
+	default:
+		if !DeepEqual(a, b) {
+			c = strings.Compare(a.String(), b.String())
+		}
+	}
+	if c != 0 {
+		return c
+	}
+	return cmpOf(at < bt, at > bt)
+}
+
+const (
+	numerals  = Numbers | Bool | Byte
+	sequences = Slice | Unboxed
+)
+
+// yields the class of types a type is compared with
+func classOf(t TyNat) TyNat {
+	switch {
+	case t.Match(numerals):
+		return numerals
+	case t.Match(Letters):
+		return Letters
+	case t.Match(sequences):
+		return sequences
+	}
+	return t
+}
+
+// numbers are ranked not a number, negative infinity, finite, positive
+// infinity. finite numbers are compared by their exact rational value.
+// fixed size integers and floats are compared directly, when both operands
+// are of the same kind.
+func compareNumbers(a, b Native) int {
+	var ak, ai, au, af = fixedOf(a)
+	var bk, bi, bu, bf = fixedOf(b)
+	switch {
+	case ak == fixedInt && bk == fixedInt:
+		return cmpOf(ai < bi, ai > bi)
+	case ak == fixedUint && bk == fixedUint:
+		return cmpOf(au < bu, au > bu)
+	case ak == fixedInt && bk == fixedUint:
+		return cmpOf(ai < 0 || uint64(ai) < bu, ai >= 0 && uint64(ai) > bu)
+	case ak == fixedUint && bk == fixedInt:
+		return cmpOf(bi >= 0 && au < uint64(bi), bi < 0 || au > uint64(bi))
+	case ak == fixedFloat && bk == fixedFloat:
+		return compareFloats(af, bf)
+	}
+	return compareRationals(a, b)
+}
+
+const (
+	fixedNone = iota
+	fixedInt
+	fixedUint
+	fixedFloat
+)
+
+func fixedOf(v Native) (kind int, i int64, u uint64, f float64) {
+	switch x := v.(type) {
+	case IntVal:
+		return fixedInt, int64(x), 0, 0
+	case Int8Val:
+		return fixedInt, int64(x), 0, 0
+	case Int16Val:
+		return fixedInt, int64(x), 0, 0
+	case Int32Val:
+		return fixedInt, int64(x), 0, 0
+	case UintVal:
+		return fixedUint, 0, uint64(x), 0
+	case Uint8Val:
+		return fixedUint, 0, uint64(x), 0
+	case Uint16Val:
+		return fixedUint, 0, uint64(x), 0
+	case Uint32Val:
+		return fixedUint, 0, uint64(x), 0
+	case ByteVal:
+		return fixedUint, 0, uint64(x), 0
+	case FltVal:
+		return fixedFloat, 0, 0, float64(x)
+	case Flt32Val:
+		return fixedFloat, 0, 0, float64(x)
+	}
+	return fixedNone, 0, 0, 0
+}
+
+func compareRationals(a, b Native) int {
+	var ar, ax = rankNumber(a)
+	var br, bx = rankNumber(b)
+	if ar != br {
+		return cmpOf(ar < br, ar > br)
+	}
+	if ax != nil && bx != nil {
+		if c := ax.Cmp(bx); c != 0 {
+			return c
+		}
+	}
+	return compareFloats(imagOf(a), imagOf(b))
+}
+
+func rankNumber(v Native) (int, *big.Rat) {
+	var f float64
+	switch x := v.(type) {
+	case FltVal:
+		f = float64(x)
+	case Flt32Val:
+		f = float64(x)
+	case ImagVal:
+		f = real(x)
+	case Imag64Val:
+		f = float64(real(x))
+	case BigFltVal:
+		if x.GoBigFlt().IsInf() {
+			return 2 + x.GoBigFlt().Sign(), nil
+		}
+		var r, _ = x.GoBigFlt().Rat(nil)
+		return 2, r
+	default:
+		if r, ok := fromNumber(v, Ratio).(RatioVal); ok {
+			return 2, r.GoRat()
+		}
+		return 0, nil
+	}
+	switch {
+	case math.IsNaN(f):
+		return 0, nil
+	case math.IsInf(f, -1):
+		return 1, nil
+	case math.IsInf(f, 1):
+		return 3, nil
+	}
+	return 2, new(big.Rat).SetFloat64(f)
+}
+
+// not a number is ordered before all other floats
+func compareFloats(x, y float64) int {
+	switch {
+	case math.IsNaN(x) && math.IsNaN(y):
+		return 0
+	case math.IsNaN(x):
+		return -1
+	case math.IsNaN(y):
+		return 1
+	}
+	return cmpOf(x < y, x > y)
+}
+
+func comparePairs(a, b Native) int {
+	var x, xok = a.(Paired)
+	var y, yok = b.(Paired)
+	if !xok || !yok {
+		return 0
+	}
+	if c := Compare(x.Left(), y.Left()); c != 0 {
+		return c
+	}
+	return Compare(x.Right(), y.Right())
+}
+
+func elemsOf(v Native) []Native {
+	if s, ok := v.(Sliced); ok {
+		return s.Slice()
+	}
+	return nil
+}
+
+func compareSlices(x, y []Native) int {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if c := Compare(x[i], y[i]); c != 0 {
+			return c
+		}
+	}
+	return cmpOf(len(x) < len(y), len(x) > len(y))
+}
+
+func compareMaps(a, b Native) int {
+	var x, xok = a.(Mapped)
+	var y, yok = b.(Mapped)
+	if !xok || !yok {
+		return 0
+	}
+	return compareSlices(sortedFields(x), sortedFields(y))
+}
+
+func sortedFields(m Mapped) []Native {
+	var fields = make([]Native, 0, m.Len())
+	for _, field := range m.Fields() {
+		fields = append(fields, PairVal{field.Left(), field.Right()})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return Compare(fields[i], fields[j]) < 0
+	})
+	return fields
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	var now = time.Now()
+	var cases = []struct {
+		a, b Native
+		c    int
+	}{
+		{IntVal(1), IntVal(2), -1},
+		{Int8Val(-1), UintVal(1), -1},
+		{UintVal(math.MaxUint64), IntVal(-1), 1},
+		{IntVal(2), FltVal(1.5), 1},
+		{RatioVal(*big.NewRat(1, 3)), FltVal(0.3333), 1},
+		{(*BigIntVal)(big.NewInt(7)), IntVal(7), 1},
+		{IntVal(7), FltVal(7), -1},
+		{FltVal(7), IntVal(7), 1},
+		{IntVal(7), IntVal(7), 0},
+		{FltVal(math.NaN()), FltVal(math.Inf(-1)), -1},
+		{FltVal(math.Inf(1)), BigIntVal(*new(big.Int).Lsh(big.NewInt(1), 2000)), 1},
+		{ImagVal(complex(1, 1)), ImagVal(complex(1, 2)), -1},
+		{BoolVal(true), IntVal(0), 1},
+		{StrVal("abc"), StrVal("abd"), -1},
+		{StrVal("b"), BytesVal("a"), 1},
+		{TimeVal(now), TimeVal(now.Add(time.Second)), -1},
+		{NewNil(), IntVal(0), -1},
+		{IntVal(1000), StrVal("a"), -1},
+		{NewPair(IntVal(1), IntVal(2)), NewPair(IntVal(1), IntVal(3)), -1},
+		{DataSlice{IntVal(1), IntVal(2)}, DataSlice{IntVal(1)}, 1},
+		{DataSlice{IntVal(1), StrVal("b")}, DataSlice{IntVal(1), StrVal("a")}, 1},
+		{IntVec{1, 2}, IntVec{1, 2}, 0},
+		{
+			NewValMap(NewPair(IntVal(2), IntVal(0)), NewPair(IntVal(1), IntVal(9))),
+			NewOrderedMap(NewPair(IntVal(1), IntVal(9)), NewPair(IntVal(2), IntVal(0))),
+			0,
+		},
+	}
+	for _, c := range cases {
+		if r := Compare(c.a, c.b); r != c.c {
+			t.Log("compare", c.a, c.b, "yields", r, "expected", c.c)
+			t.Fail()
+		}
+		if r := Compare(c.b, c.a); r != -c.c {
+			t.Log("compare", c.b, c.a, "is not antisymmetric", r)
+			t.Fail()
+		}
+	}
+}
+
+func TestSortMixed(t *testing.T) {
+	var sl = DataSlice{
+		StrVal("b"), FltVal(2.5), IntVal(3), NewNil(), Uint8Val(1),
+		StrVal("a"), BoolVal(false), IntVal(-4), FltVal(3),
+	}
+	var sorted = DataSlice{
+		NewNil(), IntVal(-4), BoolVal(false), Uint8Val(1), FltVal(2.5),
+		IntVal(3), FltVal(3), StrVal("a"), StrVal("b"),
+	}
+	sl.Sort(Int)
+	fmt.Println(sl)
+	if sl.String() != sorted.String() {
+		t.Log("unexpected order of mixed slice", sl)
+		t.Fail()
+	}
+	if dat := sl.Search(FltVal(2.5)); dat != FltVal(2.5) {
+		t.Log("expected to find 2.5", dat)
+		t.Fail()
+	}
+	if r := SliceSearchRange(sl, IntVal(3)); len(r) != 1 {
+		t.Log("expected search range to yield a single element", r)
+		t.Fail()
+	}
+}
+
+func TestCompareFixed(t *testing.T) {
+	var nats = []Native{
+		IntVal(-1 << 62), IntVal(-1), Int8Val(-128), Int16Val(300), Int32Val(7),
+		UintVal(math.MaxUint64), Uint8Val(255), Uint16Val(300), Uint32Val(7), ByteVal(0),
+		FltVal(math.NaN()), FltVal(math.Inf(-1)), FltVal(-0.5), Flt32Val(7), FltVal(math.Inf(1)),
+	}
+	for _, a := range nats {
+		for _, b := range nats {
+			if c, r := compareNumbers(a, b), compareRationals(a, b); c != r {
+				t.Log("comparing", a, b, "yields", c, "expected", r)
+				t.Fail()
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package data
+
+import (
+	"math/big"
+	"strings"
+)
+
+//// NUMERIC CONTEXT
+///
+// precision and rounding mode of big float arithmetic and of conversions to
+// big floats, and the largest denominator of ratios. the zero context keeps
+// ratios exact and big floats at the precision of their operands, or of the
+// value they are converted from, rounding to the nearest even value. Eval,
+// Convert and the methods of big floats and ratios use the zero context.
+// contexts are values and are passed to the code, that needs them. their
+// methods compute, convert and print natives according to the context and
+// are safe to be called concurrently.
+type NumContext struct {
+	Prec     uint             // mantissa bits of big floats, zero keeps the precision
+	Mode     big.RoundingMode // rounding mode of big floats
+	MaxDenom int64            // largest denominator of ratios, zero keeps ratios exact
+}
+
+func NewNumContext(prec uint, mode big.RoundingMode, maxDenom int64) NumContext {
+	return NumContext{prec, mode, maxDenom}
+}
+
+// returns a big float, that rounds results of operations and conversions
+// according to the context.
+func (c NumContext) newFloat() *big.Float {
+	var f = new(big.Float).SetMode(c.Mode)
+	if c.Prec > 0 {
+		f.SetPrec(c.Prec)
+	}
+	return f
+}
+
+// returns a copy of the float rounded to the precision of the context
+func (c NumContext) Float(f *big.Float) *big.Float {
+	return c.newFloat().Set(f)
+}
+
+// returns the ratio closest to r, with a denominator not exceeding the
+// largest denominator of the context. ratios are returned as they are, if
+// the context keeps them exact, or their denominator is small enough.
+func (c NumContext) Rat(r *big.Rat) *big.Rat {
+	var max = big.NewInt(c.MaxDenom)
+	if c.MaxDenom <= 0 || r.Denom().Cmp(max) <= 0 {
+		return r
+	}
+	// convergents of the continued fraction of r, until the denominator
+	// exceeds the maximum.
+	var p0, q0, p1, q1 = big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	var n, d = new(big.Int).Abs(r.Num()), new(big.Int).Set(r.Denom())
+	var a, m, q2 = new(big.Int), new(big.Int), new(big.Int)
+	for d.Sign() != 0 {
+		a.DivMod(n, d, m)
+		q2.Add(q0, new(big.Int).Mul(a, q1))
+		if q2.Cmp(max) > 0 {
+			break
+		}
+		p0, p1 = p1, new(big.Int).Add(p0, new(big.Int).Mul(a, p1))
+		q0, q1 = q1, new(big.Int).Set(q2)
+		n, d = d, new(big.Int).Set(m)
+	}
+	// the closer of the last convergent and the largest semiconvergent
+	// below the maximum.
+	var k = new(big.Int).Quo(new(big.Int).Sub(max, q0), q1)
+	var semi = new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)))
+	var conv = new(big.Rat).SetFrac(p1, q1)
+	var abs = new(big.Rat).Abs(r)
+	var res = conv
+	var ds, dc = new(big.Rat).Sub(semi, abs), new(big.Rat).Sub(conv, abs)
+	if ds.Abs(ds).Cmp(dc.Abs(dc)) < 0 {
+		res = semi
+	}
+	if r.Sign() < 0 {
+		res.Neg(res)
+	}
+	return res
+}
+
+// rounds big floats and limits the denominator of ratios according to the
+// context. natives of other types are returned as they are.
+func (c NumContext) Round(nat Native) Native {
+	switch v := nat.(type) {
+	case BigFltVal:
+		return BigFltVal(*c.Float(v.GoBigFlt()))
+	case *BigFltVal:
+		var f = BigFltVal(*c.Float(v.GoBigFlt()))
+		return &f
+	case RatioVal:
+		return RatioVal(*c.Rat(v.GoRat()))
+	case *RatioVal:
+		return (*RatioVal)(c.Rat(new(big.Rat).Set(v.GoRat())))
+	}
+	return nat
+}
+
+// converts like Convert does and rounds big floats and ratios according to
+// the context. ratios, decimals and strings are converted to big floats at
+// the precision of the context directly, instead of being rounded twice.
+func (c NumContext) Convert(v Native, to TyNat) (Native, error) {
+	var res Native
+	if to == BigFlt {
+		switch x := v.(type) {
+		case RatioVal, DecimalVal:
+			res = BigFltVal(*c.newFloat().SetRat(x.(Rational).GoRat()))
+		case StrVal:
+			var str = strings.TrimSpace(string(x))
+			if f, ok := c.newFloat().SetString(str); ok && !strings.Contains(str, "_") {
+				res = BigFltVal(*f)
+			}
+		}
+	}
+	if res == nil {
+		var err error
+		if res, err = convert(v, to); res == nil || (to != BigFlt && to != Ratio) {
+			return res, err
+		}
+		res = c.Round(res)
+	}
+	if !lossless(v, res) {
+		return res, ConversionError{v, to, true, nil}
+	}
+	return res, nil
+}
+
+// evaluates like Eval does, big float results are computed at the precision
+// of the context and ratio results are limited to its largest denominator.
+func (c NumContext) Eval(op OpStr, a, b Native) (Native, error) {
+	var res, err = c.eval(op, a, b)
+	if err != nil {
+		return nil, err
+	}
+	return c.Round(res), nil
+}
+
+// prints the native rounded according to the context. big floats print ten
+// significant digits, unless the context sets a precision. they print as
+// many digits then, as are needed to read the same value at that precision.
+func (c NumContext) String(nat Native) string {
+	if f, ok := indirect(nat).(BigFltVal); ok && c.Prec > 0 {
+		return c.Float(f.GoBigFlt()).Text('g', -1)
+	}
+	return c.Round(nat).String()
+}
@@ -0,0 +1,126 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNumContextRat(t *testing.T) {
+	var pi = new(big.Rat).SetFloat64(math.Pi)
+	var cases = []struct {
+		r      *big.Rat
+		max    int64
+		expect *big.Rat
+	}{
+		{pi, 10, big.NewRat(22, 7)},
+		{pi, 1000, big.NewRat(355, 113)},
+		{new(big.Rat).Neg(pi), 100, big.NewRat(-311, 99)},
+		{big.NewRat(1, 3), 2, big.NewRat(1, 2)},
+		{big.NewRat(3, 7), 0, big.NewRat(3, 7)},
+		{big.NewRat(3, 7), 7, big.NewRat(3, 7)},
+		{big.NewRat(10000001, 10000000), 100, big.NewRat(1, 1)},
+	}
+	for _, c := range cases {
+		var res = NumContext{MaxDenom: c.max}.Rat(c.r)
+		if res.Cmp(c.expect) != 0 {
+			t.Log("limiting", c.r, "to", c.max, "expected", c.expect, "got", res)
+			t.Fail()
+		}
+	}
+}
+
+func TestNumContextEval(t *testing.T) {
+	var one, three = BigFltVal(*big.NewFloat(1)), BigFltVal(*big.NewFloat(3))
+	var down = NewNumContext(8, big.ToZero, 0)
+	var up = NewNumContext(8, big.AwayFromZero, 0)
+	var lo, err = down.Eval(Quotient, one, three)
+	var hi, _ = up.Eval(Quotient, one, three)
+	fmt.Println(lo, hi)
+	if err != nil || lo.(BigFltVal).GoBigFlt().Prec() != 8 ||
+		lo.(BigFltVal).Cmp(hi.(BigFltVal)) >= 0 {
+		t.Log("expected quotient at eight bits rounded down and up", lo, hi, err)
+		t.Fail()
+	}
+	var pow, _ = down.Eval(Power, three, BigFltVal(*big.NewFloat(40)))
+	if pow.(BigFltVal).GoBigFlt().Prec() != 8 {
+		t.Log("expected power at the precision of the context", pow)
+		t.Fail()
+	}
+	var r, _ = NumContext{MaxDenom: 10}.Eval(QuoRatio, IntVal(100), IntVal(33))
+	if r.(RatioVal).GoRat().Cmp(big.NewRat(3, 1)) != 0 {
+		t.Log("expected ratio with limited denominator", r)
+		t.Fail()
+	}
+}
+
+func TestNumContextConvert(t *testing.T) {
+	var ctx = NewNumContext(16, big.ToNearestEven, 0)
+	var res, err = ctx.Convert(RatioVal(*big.NewRat(1, 3)), BigFlt)
+	if res.(BigFltVal).GoBigFlt().Prec() != 16 {
+		t.Log("expected conversion at the precision of the context", res)
+		t.Fail()
+	}
+	if conv, ok := err.(ConversionError); !ok || !conv.Lossy {
+		t.Log("expected rounding to be reported as lossy", err)
+		t.Fail()
+	}
+	res, err = ctx.Convert(StrVal("0.5"), BigFlt)
+	if err != nil || res.(BigFltVal).GoBigFlt().Prec() != 16 {
+		t.Log("expected exact conversion of string", res, err)
+		t.Fail()
+	}
+	res, err = NumContext{MaxDenom: 100}.Convert(FltVal(0.1), Ratio)
+	if _, ok := err.(ConversionError); !ok || res.(RatioVal).GoRat().Cmp(big.NewRat(1, 10)) != 0 {
+		t.Log("expected float to be approximated by ratio", res, err)
+		t.Fail()
+	}
+	if res, err = ctx.Convert(IntVal(3), Int8); err != nil || res != Int8Val(3) {
+		t.Log("conversions to other types should not change", res, err)
+		t.Fail()
+	}
+}
+
+func TestNumContextScoped(t *testing.T) {
+	var third = RatioVal(*big.NewRat(1, 3))
+	var ctx = NewNumContext(24, big.ToNearestEven, 5)
+	var f, _ = ctx.Convert(third, BigFlt)
+	var sum, _ = ctx.Eval(Add, f, f)
+	fmt.Println(f, ctx.String(f), sum)
+	if sum.(BigFltVal).GoBigFlt().Prec() != 24 || ctx.String(f) != "0.33333334" {
+		t.Log("expected big floats of the precision of the context", f, sum)
+		t.Fail()
+	}
+	var r, _ = ctx.Eval(Add, third, RatioVal(*big.NewRat(1, 7)))
+	if ctx.String(r) != "1/2" {
+		t.Log("expected sum limited to the denominator of the context", r)
+		t.Fail()
+	}
+	// package level functions and methods use the zero context
+	var q, _ = Eval(QuoRatio, IntVal(2), IntVal(7))
+	if q.(RatioVal).GoRat().Cmp(big.NewRat(2, 7)) != 0 ||
+		IntVal(7).GoBigFlt().Prec() != 53 {
+		t.Log("expected eval and conversions to use the zero context", q)
+		t.Fail()
+	}
+	// contexts are values, that don't affect each other, when used
+	// concurrently.
+	var results = make(chan bool)
+	for prec := uint(8); prec < 72; prec += 8 {
+		go func(c NumContext) {
+			var ok = true
+			for i := 0; i < 100; i++ {
+				var res, err = c.Eval(Quotient, BigFltVal(*big.NewFloat(1)), BigFltVal(*big.NewFloat(3)))
+				ok = ok && err == nil && res.(BigFltVal).GoBigFlt().Prec() == c.Prec
+			}
+			results <- ok
+		}(NewNumContext(prec, big.ToZero, 0))
+	}
+	for prec := uint(8); prec < 72; prec += 8 {
+		if !<-results {
+			t.Log("expected concurrent contexts to keep their precision")
+			t.Fail()
+		}
+	}
+}
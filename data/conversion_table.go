@@ -1,15 +1,25 @@
 package data
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type indexTable [][]func(arg Native) Native
 
 func newIndexTable() indexTable {
-	return make([][]func(arg Native) Native, 64, 64)
+	var table = make([][]func(arg Native) Native, 64, 64)
+	for i := range table {
+		table[i] = make([]func(arg Native) Native, 64, 64)
+	}
+	return table
 }
 func (i indexTable) idx(tx, ty TyNat) (x, y int) {
 	return tx.Flag().Least(), ty.Flag().Least()
@@ -20,1104 +30,628 @@ func (i indexTable) Get(tx, ty TyNat) func(Native) Native {
 }
 func (i indexTable) Set(x, y int, fnc func(Native) Native) { i[x][y] = fnc }
 
-var TypeConversionTable = [][]func(arg Native) Native{
+//// TYPE CONVERSION TABLE
+///
+// holds a conversion function for every pair of native types, the row is
+// indexed by the flag index of the source type, the column by the flag index
+// of the target type. conversion functions never panic, conversions that
+// can't be performed yield an error value, unless the target type is error,
+// in which case the error value is the result.
+var TypeConversionTable = newConversionTable()
 
-	[]func(arg Native) Native{ // 0  ← Nil
-		func(Native) Native { return NilVal{} },                  // 0  ← Nil
-		func(Native) Native { return BoolVal(false) },            // 1  ← Bool
-		func(Native) Native { return Int8Val(0) },                // 2  ← Int8
-		func(Native) Native { return Int16Val(0) },               // 3  ← Int16
-		func(Native) Native { return Int32Val(0) },               // 4  ← Int32
-		func(Native) Native { return IntVal(0) },                 // 4  ← Int
-		func(Native) Native { return IntVal(0).BigInt() },        // 5  ← BigInt
-		func(Native) Native { return Uint8Val(0) },               // 6  ← Uint8
-		func(Native) Native { return Uint16Val(0) },              // 7  ← Uint16
-		func(Native) Native { return Uint32Val(0) },              // 9  ← Uint32
-		func(Native) Native { return UintVal(0) },                // 10 ← Uint
-		func(Native) Native { return Flt32Val(0.0) },             // 12 ← Flt32
-		func(Native) Native { return FltVal(0.0) },               // 13 ← Float
-		func(Native) Native { return FltVal(0.0).BigFlt() },      // 14 ← BigFlt
-		func(Native) Native { return IntVal(0).Ratio() },         // 15 ← Ratio
-		func(Native) Native { return IntVal(0).Imag().Imag64() }, // 16 ← Imag64
-		func(Native) Native { return IntVal(0).Imag() },          // 17 ← Imag
-		func(Native) Native { return TimeVal{} },                 // 18 ← Time
-		func(arg Native) Native { return DuraVal(0) },            // 19 ← Duration
-		func(arg Native) Native { return ByteVal(0) },            // 20 ← Byte
-		func(arg Native) Native { return RuneVal(0) },            // 21 ← Rune
-		func(arg Native) Native { return BitFlag(0) },            // 22 ← Flag
-		func(arg Native) Native { return StrVal("") },            // 23 ← String
-		func(arg Native) Native { return BytesVal{} },            // 24 ← Bytes
-		func(arg Native) Native { return ErrorVal{} },            // 25 ← Error
-	},
+// all types convertable by the conversion table
+const Convertables = Natives | Flag
 
-	[]func(arg Native) Native{ // 1  ← Bool
-		func(arg Native) Native { return NilVal{} },                             // 0  ← Nil
-		func(arg Native) Native { return arg },                                  // 1  ← Bool
-		func(arg Native) Native { return arg.(BoolVal).Int().Int8() },           // 2  ← Int8
-		func(arg Native) Native { return arg.(BoolVal).Int().Int16() },          // 3  ← Int16
-		func(arg Native) Native { return arg.(BoolVal).Int().Int32() },          // 4  ← Int32
-		func(arg Native) Native { return arg.(BoolVal).Int() },                  // 4  ← Int
-		func(arg Native) Native { return arg.(BoolVal).Int().BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return arg.(BoolVal).Uint().Uint8() },         // 6  ← Uint8
-		func(arg Native) Native { return arg.(BoolVal).Uint().Uint16() },        // 7  ← Uint16
-		func(arg Native) Native { return arg.(BoolVal).Uint().Uint32() },        // 9  ← Uint32
-		func(arg Native) Native { return arg.(BoolVal).Uint() },                 // 10 ← Uint
-		func(arg Native) Native { return arg.(BoolVal).Int().Float().Flt32() },  // 12 ← Flt32
-		func(arg Native) Native { return arg.(BoolVal).Int().Float() },          // 13 ← Float
-		func(arg Native) Native { return arg.(BoolVal).Int().Float().BigFlt() }, // 14 ← BigFlt
-		func(arg Native) Native { return arg.(BoolVal).Int().Ratio() },          // 15 ← Ratio
-		func(arg Native) Native { return arg.(BoolVal).Int().Imag().Imag64() },  // 16 ← Imag64
-		func(arg Native) Native { return arg.(BoolVal).Int().Imag() },           // 17 ← Imag
-		func(arg Native) Native { // 18 ← Time
-			if arg.(BoolVal).Bool() {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		},
-		func(arg Native) Native { // 19 ← Duration
-			if arg.(BoolVal).Bool() {
-				return DuraVal(1)
-			}
-			return DuraVal(0)
-		}, // 18 ← Time
-		func(arg Native) Native { return arg.(BoolVal).Int().Byte() },             // 20 ← Byte
-		func(arg Native) Native { return arg.(BoolVal).Int().Rune() },             // 21 ← Rune
-		func(arg Native) Native { return arg.(BoolVal).Int().Flag() },             // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(BoolVal).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(BoolVal).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from bool"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+func newConversionTable() indexTable {
+	var table = newIndexTable()
+	for _, from := range predefinedTypes() {
+		if !from.Match(Convertables) {
+			continue
+		}
+		for _, to := range predefinedTypes() {
+			if !to.Match(Convertables) {
+				continue
+			}
+			table.Set(
+				from.Flag().Index(),
+				to.Flag().Index(),
+				conversion(from, to),
+			)
+		}
+	}
+	return table
+}
 
-	[]func(arg Native) Native{ // 2  ← Int8
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Int8Val) > Int8Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                   // 2  ← Int8
-		func(arg Native) Native { return Int16Val(arg.(Int8Val)) },               // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(Int8Val)) },               // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Int8Val)) },                 // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return UintVal(arg.(Int8Val)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Int8Val)) },              // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Int8Val)) },              // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Int8Val)) },                // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint8Val)) },              // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Int8Val)) },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Int8Val)).BigFlt() },        // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Int8Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Int8Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Byte() },           // 20 ← Byte
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Rune() },           // 21 ← Rune
-		func(arg Native) Native { return IntVal(arg.(Int8Val)).Flag() },           // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Int8Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Int8Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from int8"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// returns the function that converts values of the source type to the
+// target type
+func conversion(from, to TyNat) func(Native) Native {
+	var fnc func(Native) Native
+	switch {
+	case from == to:
+		fnc = func(arg Native) Native { return arg }
+	case to == Nil:
+		fnc = func(Native) Native { return NilVal{} }
+	case from == Nil:
+		fnc = func(Native) Native { return zeroOf(to) }
+	case to == String:
+		fnc = func(arg Native) Native { return StrVal(stringOf(arg)) }
+	case to == Bytes:
+		fnc = func(arg Native) Native { return BytesVal(stringOf(arg)) }
+	case to == Error:
+		fnc = func(arg Native) Native { return ErrorVal{errors.New(stringOf(arg))} }
+	case from == String:
+		fnc = func(arg Native) Native { return fromString(arg.(StrVal), to) }
+	case from == Bytes:
+		fnc = func(arg Native) Native { return fromString(StrVal(arg.(BytesVal)), to) }
+	case from == Error:
+		fnc = func(arg Native) Native {
+			if to == Bool {
+				return BoolVal(arg.(ErrorVal).E != nil)
+			}
+			return errConversion(arg, to)
+		}
+	case from == Time:
+		fnc = func(arg Native) Native { return fromTime(arg.(TimeVal), to) }
+	default:
+		fnc = func(arg Native) Native { return fromNumber(arg, to) }
+	}
+	return func(arg Native) Native {
+		if arg == nil || arg.Type() != from {
+			return errConversion(arg, to)
+		}
+		return fnc(arg)
+	}
+}
 
-	[]func(arg Native) Native{ // 3  ← Int16
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Int16Val) > Int16Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                    // 2  ← Int16
-		func(arg Native) Native { return Int16Val(arg.(Int16Val)) },               // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(Int16Val)) },               // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Int16Val)) },                 // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return UintVal(arg.(Int16Val)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Int16Val)) },              // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Int16Val)) },              // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Int16Val)) },                // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint16Val)) },              // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Int16Val)) },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Int16Val)).BigFlt() },        // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Int16Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 116 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Int16Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Byte() },           // 20 ← Byte
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Rune() },           // 21 ← Rune
-		func(arg Native) Native { return IntVal(arg.(Int16Val)).Flag() },           // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Int16Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Int16Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from int16"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+func errConversion(arg Native, to TyNat) ErrorVal {
+	if arg == nil {
+		return ErrorVal{fmt.Errorf("can not convert nil to %s", to.TypeName())}
+	}
+	return ErrorVal{fmt.Errorf("can not convert %s of type %s to %s",
+		stringOf(arg), arg.Type().TypeName(), to.TypeName())}
+}
 
-	[]func(arg Native) Native{ // 4  ← Int32
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Int32Val) > Int32Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                    // 2  ← Int8
-		func(arg Native) Native { return Int16Val(arg.(Int32Val)) },               // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(Int32Val)) },               // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Int32Val)) },                 // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(Int32Val)) },               // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Int32Val)) },              // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Int32Val)) },              // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Int32Val)) },                // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint32Val)) },              // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Int32Val)) },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Int32Val)).BigFlt() },        // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Int32Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 132 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Int32Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Byte() },           // 20 ← Byte
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Rune() },           // 21 ← Rune
-		func(arg Native) Native { return IntVal(arg.(Int32Val)).Flag() },           // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Int32Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Int32Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from int32"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// string representation used by conversions, errors are represented by their
+// message
+func stringOf(arg Native) string {
+	switch v := arg.(type) {
+	case ErrorVal:
+		if v.E == nil {
+			return ""
+		}
+		return v.E.Error()
+	case BytesVal:
+		return string(v)
+	}
+	return arg.String()
+}
 
-	[]func(arg Native) Native{ // 4  ← Int
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(IntVal) > IntVal(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                  // 2  ← Int8
-		func(arg Native) Native { return Int16Val(arg.(IntVal)) },               // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(IntVal)) },               // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(IntVal)) },                 // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(IntVal)).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(IntVal)) },               // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(IntVal)) },              // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(IntVal)) },              // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(IntVal)) },                // 10 ← Uint
-		func(arg Native) Native { return FltVal(arg.(IntVal)) },                 // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(IntVal)) },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(IntVal)).BigFlt() },        // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(IntVal) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 1 ← Time
-		func(arg Native) Native { return DuraVal(arg.(IntVal)) },                 // 19 ← Duration
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Byte() },           // 20 ← Byte
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Rune() },           // 21 ← Rune
-		func(arg Native) Native { return IntVal(arg.(IntVal)).Flag() },           // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(IntVal).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(IntVal).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from int"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// zero value of the native type passed
+func zeroOf(t TyNat) Native {
+	switch t {
+	case Bool:
+		return BoolVal(false)
+	case Int8:
+		return Int8Val(0)
+	case Int16:
+		return Int16Val(0)
+	case Int32:
+		return Int32Val(0)
+	case Int:
+		return IntVal(0)
+	case BigInt:
+		return BigIntVal{}
+	case Uint8:
+		return Uint8Val(0)
+	case Uint16:
+		return Uint16Val(0)
+	case Uint32:
+		return Uint32Val(0)
+	case Uint:
+		return UintVal(0)
+	case Flt32:
+		return Flt32Val(0)
+	case Float:
+		return FltVal(0)
+	case BigFlt:
+		return BigFltVal{}
+	case Ratio:
+		return RatioVal{}
+	case Decimal:
+		return DecimalVal{}
+	case Imag64:
+		return Imag64Val(0)
+	case Imag:
+		return ImagVal(0)
+	case Time:
+		return TimeVal{}
+	case Duration:
+		return DuraVal(0)
+	case Byte:
+		return ByteVal(0)
+	case Rune:
+		return RuneVal(0)
+	case Flag:
+		return BitFlag(0)
+	case String:
+		return StrVal("")
+	case Bytes:
+		return BytesVal{}
+	case Error:
+		return ErrorVal{}
+	}
+	if r, ok := RegisteredType(t); ok {
+		return r.Zero
+	}
+	return NilVal{}
+}
 
-	[]func(arg Native) Native{ // 5  ← BigInt
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(BigIntVal).Int() > IntVal(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                           // 2  ← Int8
-		func(arg Native) Native { return Int16Val(arg.(BigIntVal).Int()) },               // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(BigIntVal).Int()) },               // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()) },                 // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(BigIntVal).Int()) },               // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(BigIntVal).Int()) },              // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(BigIntVal).Int()) },              // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(BigIntVal).Int()) },                // 10 ← Uint
-		func(arg Native) Native { return FltVal(arg.(BigIntVal).Int()) },                 // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(BigIntVal).Int()) },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(BigIntVal).Int()).BigFlt() },        // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(BigIntVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 1 ← Time
-		func(arg Native) Native { return DuraVal(arg.(BigIntVal).Int()) },                 // 19 ← Duration
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Byte() },           // 20 ← Byte
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Rune() },           // 21 ← Rune
-		func(arg Native) Native { return IntVal(arg.(BigIntVal).Int()).Flag() },           // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(BigIntVal).Int().String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(BigIntVal).Int().String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from bigint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// numbers, durations, bytes, runes and flags are converted via the most
+// precise go representation of their value.
+func fromNumber(arg Native, to TyNat) Native {
+	switch v := arg.(type) {
+	case BoolVal:
+		if v {
+			return fromInt(big.NewInt(1), to, arg)
+		}
+		return fromInt(new(big.Int), to, arg)
+	case Int8Val:
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case Int16Val:
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case Int32Val:
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case IntVal:
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case BigIntVal:
+		return fromInt(new(big.Int).Set(v.GoBigInt()), to, arg)
+	case Uint8Val:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case Uint16Val:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case Uint32Val:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case UintVal:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case ByteVal:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case RuneVal:
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case BitFlag:
+		return fromInt(new(big.Int).SetUint64(uint64(v)), to, arg)
+	case DuraVal:
+		if to == Time {
+			return TimeVal(time.Unix(0, int64(v)).UTC())
+		}
+		return fromInt(big.NewInt(int64(v)), to, arg)
+	case RatioVal:
+		return fromRat(new(big.Rat).Set(v.GoRat()), to, arg)
+	case DecimalVal:
+		return fromRat(v.GoRat(), to, arg)
+	case Flt32Val:
+		return fromFloat(float64(v), to, arg)
+	case FltVal:
+		return fromFloat(float64(v), to, arg)
+	case BigFltVal:
+		return fromBigFlt(new(big.Float).Copy(v.GoBigFlt()), to, arg)
+	case Imag64Val:
+		return fromImag(complex128(v), to, arg)
+	case ImagVal:
+		return fromImag(complex128(v), to, arg)
+	}
+	return errConversion(arg, to)
+}
 
-	[]func(arg Native) Native{ // 6  ← Uint8
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Uint8Val) > Uint8Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return arg },                                     // 2  ← Int8
-		func(arg Native) Native { return Uint16Val(arg.(Uint8Val)) },               // 3  ← Int16
-		func(arg Native) Native { return Uint32Val(arg.(Uint8Val)) },               // 4  ← Int32
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)) },                 // 4  ← Int
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)).BigInt() },        // 5  ← BigInt
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)) },                 // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Uint8Val)) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Uint8Val)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint8Val)) },                // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Uint8Val)) },                  // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Uint8Val)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(Uint8Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Uint8Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Uint8Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(Uint8Val)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(Uint8Val))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(Uint8Val))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Uint8Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Uint8Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint8"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// integers exceeding the range of a fixed size target type wrap around, like
+// go's conversions do.
+func fromInt(i *big.Int, to TyNat, arg Native) Native {
+	var u = i.Uint64()
+	if i.Sign() < 0 {
+		u = uint64(i.Int64())
+	}
+	switch to {
+	case Bool:
+		return BoolVal(i.Sign() != 0)
+	case Int8:
+		return Int8Val(i.Int64())
+	case Int16:
+		return Int16Val(i.Int64())
+	case Int32:
+		return Int32Val(i.Int64())
+	case Int:
+		return IntVal(i.Int64())
+	case BigInt:
+		return BigIntVal(*i)
+	case Uint8:
+		return Uint8Val(u)
+	case Uint16:
+		return Uint16Val(u)
+	case Uint32:
+		return Uint32Val(u)
+	case Uint:
+		return UintVal(u)
+	case Ratio:
+		return RatioVal(*new(big.Rat).SetInt(i))
+	case Decimal:
+		return NewBigDecimal(i, 0)
+	case BigFlt:
+		return BigFltVal(*new(big.Float).SetInt(i))
+	case Time:
+		return TimeVal(time.Unix(i.Int64(), 0).UTC())
+	case Duration:
+		return DuraVal(i.Int64())
+	case Byte:
+		return ByteVal(u)
+	case Rune:
+		return RuneVal(i.Int64())
+	case Flag:
+		return BitFlag(u)
+	}
+	var f, _ = new(big.Float).SetInt(i).Float64()
+	return fromFloat(f, to, arg)
+}
 
-	[]func(arg Native) Native{ // 7  ← Uint16
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Uint16Val) > Uint16Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return Int8Val(arg.(Uint16Val)) },                 // 2  ← Int8
-		func(arg Native) Native { return Int16Val(arg.(Uint16Val)) },                // 3  ← Int16
-		func(arg Native) Native { return Int32Val(arg.(Uint16Val)) },                // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Uint16Val)) },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Uint16Val)).BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(Uint16Val)) },                // 6  ← Uint8
-		func(arg Native) Native { return arg.(Uint16Val) },                          // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Uint16Val)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Uint16Val)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint16Val)) },                // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Uint16Val)) },                  // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Uint16Val)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(Uint16Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(Uint16Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(Uint16Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Uint16Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Uint16Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(Uint16Val)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(Uint16Val))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(Uint16Val))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Uint16Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Uint16Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint16"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// ratios are truncated, when converted to integral types
+func fromRat(r *big.Rat, to TyNat, arg Native) Native {
+	switch to {
+	case Bool:
+		return BoolVal(r.Sign() != 0)
+	case Ratio:
+		return RatioVal(*r)
+	case Decimal:
+		return decimalOfRat(r)
+	case BigFlt:
+		return BigFltVal(*new(big.Float).SetRat(r))
+	case Flt32, Float, Imag64, Imag:
+		var f, _ = r.Float64()
+		return fromFloat(f, to, arg)
+	}
+	return fromInt(new(big.Int).Quo(r.Num(), r.Denom()), to, arg)
+}
 
-	[]func(arg Native) Native{ // 9  ← Uint32
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Uint32Val) > Uint32Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(Uint32Val)) },                  // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(Uint32Val)) },                  // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(Uint32Val)) },                  // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Uint32Val)) },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Uint32Val)).BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(Uint32Val)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Uint32Val)) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Uint32Val)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Uint32Val)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Uint32Val)) },                // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(Uint32Val)) },                  // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Uint32Val)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(Uint32Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(Uint32Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(Uint32Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Uint32Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Uint32Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(Uint32Val)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(Uint32Val))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(Uint32Val))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Uint32Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Uint32Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint32"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+func fromFloat(f float64, to TyNat, arg Native) Native {
+	switch to {
+	case Bool:
+		return BoolVal(f != 0)
+	case Flt32:
+		return Flt32Val(f)
+	case Float:
+		return FltVal(f)
+	case Imag64:
+		return Imag64Val(complex(float32(f), 0))
+	case Imag:
+		return ImagVal(complex(f, 0))
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return errConversion(arg, to)
+	}
+	if to == Decimal {
+		return decimalOfFloat(f, arg)
+	}
+	return fromBigFlt(new(big.Float).SetFloat64(f), to, arg)
+}
 
-	[]func(arg Native) Native{ // 10 ← Uint
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(UintVal) > UintVal(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(UintVal)) },                  // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(UintVal)) },                  // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(UintVal)) },                  // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(UintVal)) },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(UintVal)).BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(UintVal)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(UintVal)) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(UintVal)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(UintVal)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(UintVal)) },                // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(UintVal)) },                  // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(UintVal)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(UintVal)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(UintVal)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(UintVal)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(UintVal) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(UintVal)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(UintVal)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(UintVal))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(UintVal))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(UintVal).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(UintVal).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// floats are truncated, when converted to integral types
+func fromBigFlt(f *big.Float, to TyNat, arg Native) Native {
+	switch to {
+	case Bool:
+		return BoolVal(f.Sign() != 0)
+	case BigFlt:
+		return BigFltVal(*f)
+	case Flt32, Float, Imag64, Imag:
+		var flt, _ = f.Float64()
+		return fromFloat(flt, to, arg)
+	}
+	if f.IsInf() {
+		return errConversion(arg, to)
+	}
+	if to == Ratio || to == Decimal {
+		var r, _ = f.Rat(nil)
+		return fromRat(r, to, arg)
+	}
+	var i, _ = f.Int(nil)
+	return fromInt(i, to, arg)
+}
 
-	[]func(arg Native) Native{ // 12 ← Flt32
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Flt32Val) > Flt32Val(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(Flt32Val)) },                  // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(Flt32Val)) },                  // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(Flt32Val)) },                  // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(Flt32Val)) },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(Flt32Val)).BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(Flt32Val)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(Flt32Val)) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(Flt32Val)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(Flt32Val)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(Flt32Val)) },                // 12 ← Flt32
-		func(arg Native) Native { return arg.(Flt32Val) },                          // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(Flt32Val)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(Flt32Val)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(Flt32Val)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(Flt32Val)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(Flt32Val) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(Flt32Val)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(Flt32Val)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(Flt32Val))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(Flt32Val))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(Flt32Val).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(Flt32Val).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// floats convert to the decimal with the fewest digits, that converts back
+// to the same float.
+func decimalOfFloat(f float64, arg Native) Native {
+	var size = 64
+	switch arg.(type) {
+	case Flt32Val, Imag64Val:
+		size = 32
+	}
+	var d, _ = ParseDecimal(strconv.FormatFloat(f, 'e', -1, size))
+	return d
+}
 
-	[]func(arg Native) Native{ // 13 ← Float
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(FltVal) > FltVal(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(FltVal)) },                  // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(FltVal)) },                  // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(FltVal)) },                  // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(FltVal)) },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(FltVal)).BigInt() },         // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(FltVal)) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(FltVal)) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(FltVal)) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(FltVal)) },                 // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(arg.(FltVal)) },                // 12 ← Flt32
-		func(arg Native) Native { return arg.(FltVal) },                          // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(FltVal)).BigFlt() },         // 14 ← BigFlt
-		func(arg Native) Native { return UintVal(arg.(FltVal)).Ratio() },         // 15 ← Ratio
-		func(arg Native) Native { return UintVal(arg.(FltVal)).Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return UintVal(arg.(FltVal)).Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(FltVal) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(FltVal)) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(FltVal)) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(FltVal))) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(FltVal))) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(FltVal).String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(FltVal).String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// imaginary numbers loose their imaginary part, when converted to any other
+// type of number
+func fromImag(c complex128, to TyNat, arg Native) Native {
+	switch to {
+	case Bool:
+		return BoolVal(c != 0)
+	case Imag64:
+		return Imag64Val(complex64(c))
+	case Imag:
+		return ImagVal(c)
+	}
+	return fromFloat(real(c), to, arg)
+}
 
-	[]func(arg Native) Native{ // 14 ← BigFlt
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(BigFltVal).GoBigInt().Cmp(IntVal(0).BigInt().GoBigInt()) != 0 {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(BigFltVal).Int()) },           // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(BigFltVal).Int()) },           // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(BigFltVal).Int()) },           // 4  ← Int32
-		func(arg Native) Native { return IntVal(arg.(BigFltVal).Int()) },           // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(BigFltVal).Int()).BigInt() },  // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(BigFltVal).Uint()) },        // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(BigFltVal).Uint()) },       // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(BigFltVal).Uint()) },       // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(BigFltVal).Uint()) },         // 10 ← Uint
-		func(arg Native) Native { return arg.(BigFltVal).Float().Flt32() },         // 12 ← Flt32
-		func(arg Native) Native { return arg.(BigFltVal).Float() },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(BigFltVal).Int()).BigFlt() },  // 14 ← BigFlt
-		func(arg Native) Native { return (*RatioVal)(arg.(BigFltVal).Ratio()) },    // 15 ← Ratio
-		func(arg Native) Native { return arg.(BigFltVal).Float().Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return arg.(BigFltVal).Float().Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(BigFltVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(BigFltVal).Int()) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(BigFltVal).Int()) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(BigFltVal).Int())) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(BigFltVal).Int())) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(BigFltVal).Int().String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(BigFltVal).Int().String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// time converts to durations as nanoseconds, to numbers as unix time in
+// seconds
+func fromTime(t TimeVal, to TyNat) Native {
+	switch to {
+	case Bool:
+		return BoolVal(!time.Time(t).IsZero())
+	case Duration:
+		return DuraVal(time.Time(t).UnixNano())
+	}
+	return fromInt(big.NewInt(time.Time(t).Unix()), to, t)
+}
 
-	[]func(arg Native) Native{ // 15 ← Ratio
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(RatioVal).GoBigInt().Cmp(IntVal(0).BigInt().GoBigInt()) != 0 {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(RatioVal).Int()) },           // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(RatioVal).Int()) },           // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(RatioVal).Int()) },           // 4  ← Int32
-		func(arg Native) Native { return arg.(RatioVal).Int() },                   // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(RatioVal).Int()).BigInt() },  // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(RatioVal).Uint()) },        // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(RatioVal).Uint()) },       // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(RatioVal).Uint()) },       // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(RatioVal).Uint()) },         // 10 ← Uint
-		func(arg Native) Native { return arg.(RatioVal).Float().Flt32() },         // 12 ← Flt32
-		func(arg Native) Native { return arg.(RatioVal).Float() },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(RatioVal).Int()).BigFlt() },  // 14 ← BigFlt
-		func(arg Native) Native { return arg.(RatioVal) },                         // 15 ← Ratio
-		func(arg Native) Native { return arg.(RatioVal).Float().Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return arg.(RatioVal).Float().Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(RatioVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(RatioVal).Int()) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(RatioVal).Int()) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(RatioVal).Int())) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(RatioVal).Int())) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(RatioVal).Int().String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(RatioVal).Int().String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// layout of the string representation of time values
+const timeStringLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
 
-	[]func(arg Native) Native{ // 16 ← Imag64
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(Imag64Val) != Imag64Val(complex64(0)) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)) },                          // 2  ← Int8
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)) },                          // 3  ← Int16
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)) },                          // 4  ← Int32
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)) },                          // 4  ← Int
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)).BigInt() },                 // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(ImagVal(arg.(Imag64Val)).Uint()) },         // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(ImagVal(arg.(Imag64Val)).Uint()) },        // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(ImagVal(arg.(Imag64Val)).Uint()) },        // 9  ← Uint32
-		func(arg Native) Native { return UintVal(ImagVal(arg.(Imag64Val)).Uint()) },          // 10 ← Uint
-		func(arg Native) Native { return Flt32Val(ImagVal(arg.(Imag64Val)).Float()) },        // 12 ← Flt32
-		func(arg Native) Native { return FltVal(ImagVal(arg.(Imag64Val)).Float()) },          // 13 ← Float
-		func(arg Native) Native { return FltVal(ImagVal(arg.(Imag64Val)).Float()).BigFlt() }, // 14 ← BigFlt
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)).Float().Ratio() },          // 15 ← Ratio
-		func(arg Native) Native { return arg.(Imag64Val) },                                   // 16 ← Imag64
-		func(arg Native) Native { return ImagVal(arg.(Imag64Val)) },                          // 17 ← Imag
-		func(arg Native) Native {
-			if ImagVal(arg.(Imag64Val)).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(ImagVal(arg.(Imag64Val)).Int()) },                 // 19 ← Duration
-		func(arg Native) Native { return DuraVal(ImagVal(arg.(Imag64Val)).Int()) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(ImagVal(arg.(Imag64Val)).Int()) },                 // 21 ← Rune
-		func(arg Native) Native { return BitFlag(ImagVal(arg.(Imag64Val)).Int()) },                 // 22 ← Flag
-		func(arg Native) Native { return StrVal(ImagVal(arg.(Imag64Val)).Int().String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(ImagVal(arg.(Imag64Val)).Int().String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// parses the string as value of the target type. numbers are accepted in
+// the notation strconv and math/big accept, time in RFC3339, or the layout
+// time values are printed in, flags as number, or as names of the types
+// they're composed of.
+func fromString(s StrVal, to TyNat) Native {
+	var str = strings.TrimSpace(string(s))
+	if r, ok := RegisteredType(to); ok {
+		return r.read(str)
+	}
+	var err error
+	switch to {
+	case Bool:
+		var b bool
+		if b, err = StrVal(str).ReadBool(); err == nil {
+			return BoolVal(b)
+		}
+	case Int8, Int16, Int32, Int:
+		var i int64
+		if i, err = strconv.ParseInt(str, 10, intWidth(to)); err == nil {
+			return fromInt(big.NewInt(i), to, s)
+		}
+	case Uint8, Uint16, Uint32, Uint:
+		var u uint64
+		if u, err = strconv.ParseUint(str, 10, intWidth(to)); err == nil {
+			return fromInt(new(big.Int).SetUint64(u), to, s)
+		}
+	case BigInt:
+		if i, ok := new(big.Int).SetString(str, 10); ok {
+			return BigIntVal(*i)
+		}
+	case Ratio:
+		if r, ok := parseRat(str); ok {
+			return RatioVal(*r)
+		}
+	case Decimal:
+		var d DecimalVal
+		if d, err = ParseDecimal(str); err == nil {
+			return d
+		}
+	case BigFlt:
+		if f, ok := new(big.Float).SetString(str); ok && !strings.Contains(str, "_") {
+			return BigFltVal(*f)
+		}
+	case Flt32:
+		var f float64
+		if f, err = strconv.ParseFloat(str, 32); err == nil {
+			return Flt32Val(f)
+		}
+	case Float:
+		var f float64
+		if f, err = strconv.ParseFloat(str, 64); err == nil {
+			return FltVal(f)
+		}
+	case Imag64, Imag:
+		var c complex128
+		str = strings.Replace(strings.Replace(str, " ", "", -1), "+-", "-", 1)
+		if c, err = strconv.ParseComplex(str, 128); err == nil {
+			return fromImag(c, to, s)
+		}
+	case Time:
+		if i := strings.Index(str, " m="); i > 0 {
+			str = str[:i]
+		}
+		var t time.Time
+		if t, err = StrVal(str).ReadTime(time.RFC3339Nano); err == nil {
+			return TimeVal(t)
+		}
+		if t, err = StrVal(str).ReadTime(timeStringLayout); err == nil {
+			return TimeVal(t)
+		}
+	case Duration:
+		var d time.Duration
+		if d, err = StrVal(str).ReadDuration(); err == nil {
+			return DuraVal(d)
+		}
+	case Byte:
+		if len(s) == 1 {
+			return ByteVal(s[0])
+		}
+	case Rune:
+		if utf8.RuneCountInString(string(s)) == 1 {
+			var r, _ = utf8.DecodeRuneInString(string(s))
+			return RuneVal(r)
+		}
+	case Flag:
+		if f, ok := parseFlag(str); ok {
+			return f
+		}
+	}
+	if err != nil {
+		return ErrorVal{err}
+	}
+	return errConversion(s, to)
+}
 
-	[]func(arg Native) Native{ // 17 ← Imag
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(ImagVal) != ImagVal(complex128(0)) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(ImagVal).Int()) },           // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(ImagVal).Int()) },           // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(ImagVal).Int()) },           // 4  ← Int32
-		func(arg Native) Native { return arg.(ImagVal).Int() },                   // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(ImagVal).Int()).BigInt() },  // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(ImagVal).Uint()) },        // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(ImagVal).Uint()) },       // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(ImagVal).Uint()) },       // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(ImagVal).Uint()) },         // 10 ← Uint
-		func(arg Native) Native { return arg.(ImagVal).Float().Flt32() },         // 12 ← Flt32
-		func(arg Native) Native { return arg.(ImagVal).Float() },                 // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(ImagVal).Int()).BigFlt() },  // 14 ← BigFlt
-		func(arg Native) Native { return arg.(ImagVal) },                         // 15 ← Ratio
-		func(arg Native) Native { return arg.(ImagVal).Float().Imag().Imag64() }, // 16 ← Imag64
-		func(arg Native) Native { return arg.(ImagVal).Float().Imag() },          // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(ImagVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return DuraVal(arg.(ImagVal).Int()) },                 // 19 ← Duration
-		func(arg Native) Native { return ByteVal(arg.(ImagVal).Int()) },                 // 20 ← Byte
-		func(arg Native) Native { return RuneVal(UintVal(arg.(ImagVal).Int())) },        // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(ImagVal).Int())) },        // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(ImagVal).Int().String()) },         // 23 ← String
-		func(arg Native) Native { return StrVal(arg.(ImagVal).Int().String()).Bytes() }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// parses fractions of base ten integers and decimal numbers. neither the
+// octal prefix of fraction components, nor underscores are accepted.
+func parseRat(str string) (*big.Rat, bool) {
+	if strings.Contains(str, "_") {
+		return nil, false
+	}
+	if i := strings.IndexByte(str, '/'); i >= 0 {
+		var num, nok = new(big.Int).SetString(str[:i], 10)
+		var den, dok = new(big.Int).SetString(str[i+1:], 10)
+		if !nok || !dok || den.Sign() == 0 {
+			return nil, false
+		}
+		return new(big.Rat).SetFrac(num, den), true
+	}
+	return new(big.Rat).SetString(str)
+}
 
-	[]func(arg Native) Native{ // 18 ← Time
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(TimeVal).Time().Before(time.Now()) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(TimeVal).Int()) },          // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(TimeVal).Int()) },          // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(TimeVal).Int()) },          // 4  ← Int32
-		func(arg Native) Native { return arg.(TimeVal).Int() },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(TimeVal).Int()).BigInt() }, // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(TimeVal).Uint()) },       // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(TimeVal).Uint()) },      // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(TimeVal).Uint()) },      // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(TimeVal).Uint()) },        // 10 ← Uint
-		func(arg Native) Native { return arg.(TimeVal).Float().Flt32() },        // 12 ← Flt32
-		func(arg Native) Native { return arg.(TimeVal).Float() },                // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(TimeVal).Int()).BigFlt() }, // 14 ← BigFlt
-		func(arg Native) Native { return arg.(TimeVal).Ratio() },                // 15 ← Ratio
-		func(arg Native) Native { return Imag64Val(arg.(TimeVal).Imag()) },      // 16 ← Imag64
-		func(arg Native) Native { return arg.(TimeVal).Imag() },                 // 17 ← Imag
-		func(arg Native) Native { return arg.(TimeVal) },                        // 18 ← Time
-		func(arg Native) Native {
-			if time.Now().Before(arg.(TimeVal).Time()) {
-				return DuraVal(arg.(TimeVal).Time().Sub(time.Now()))
-			}
-			return DuraVal(time.Now().Sub(arg.(TimeVal).Time()))
-		}, // 19 ← Duration
-		func(arg Native) Native { return ByteVal([]byte(arg.(TimeVal).String())[0]) }, // 20 ← Byte
-		func(arg Native) Native { return RuneVal([]rune(arg.(TimeVal).String())[0]) }, // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(TimeVal).Int())) },      // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(TimeVal).String()) },             // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte(arg.(TimeVal).String())) },   // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// flags are accepted as numbers with base prefix, or as type names
+func parseFlag(str string) (BitFlag, bool) {
+	if u, err := strconv.ParseUint(str, 0, 64); err == nil {
+		return BitFlag(u), true
+	}
+	var flag BitFlag
+	for _, name := range strings.Split(str, "∙") {
+		var found bool
+		for _, t := range FetchTypes() {
+			if t.TypeName() == name {
+				flag, found = flag|t.Flag(), true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return flag, true
+}
 
-	[]func(arg Native) Native{ // 19 ← Duration
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(DuraVal) > 0 {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(DuraVal).Int()) },          // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(DuraVal).Int()) },          // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(DuraVal).Int()) },          // 4  ← Int32
-		func(arg Native) Native { return arg.(DuraVal).Int() },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(DuraVal).Int()).BigInt() }, // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(DuraVal).Uint()) },       // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(DuraVal).Uint()) },      // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(DuraVal).Uint()) },      // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(DuraVal).Uint()) },        // 10 ← Uint
-		func(arg Native) Native { return arg.(DuraVal).Float().Flt32() },        // 12 ← Flt32
-		func(arg Native) Native { return arg.(DuraVal).Float() },                // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(DuraVal).Int()).BigFlt() }, // 14 ← BigFlt
-		func(arg Native) Native { return arg.(DuraVal).Ratio() },                // 15 ← Ratio
-		func(arg Native) Native { return Imag64Val(arg.(DuraVal).Imag()) },      // 16 ← Imag64
-		func(arg Native) Native { return arg.(DuraVal).Imag() },                 // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(DuraVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return arg.(DuraVal) },                              // 19 ← Duration
-		func(arg Native) Native { return ByteVal([]byte(arg.(DuraVal).String())[0]) }, // 20 ← Byte
-		func(arg Native) Native { return RuneVal([]rune(arg.(DuraVal).String())[0]) }, // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(DuraVal).Int())) },      // 22 ← Flag
-		func(arg Native) Native { return StrVal(arg.(DuraVal).String()) },             // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte(arg.(DuraVal).String())) },   // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// compares values of the same type by value
+func equalNative(a, b Native) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch x := a.(type) {
+	case BigIntVal:
+		return x.GoBigInt().Cmp(b.(BigIntVal).GoBigInt()) == 0
+	case BigFltVal:
+		return x.GoBigFlt().Cmp(b.(BigFltVal).GoBigFlt()) == 0
+	case RatioVal:
+		return x.GoRat().Cmp(b.(RatioVal).GoRat()) == 0
+	case DecimalVal:
+		return x.Cmp(b.(DecimalVal)) == 0
+	case TimeVal:
+		return time.Time(x).Equal(time.Time(b.(TimeVal)))
+	case BytesVal:
+		return bytes.Equal(x, b.(BytesVal))
+	case ErrorVal:
+		return stringOf(a) == stringOf(b)
+	case FltVal:
+		var y = b.(FltVal)
+		return x == y || math.IsNaN(float64(x)) && math.IsNaN(float64(y))
+	case Flt32Val:
+		var y = b.(Flt32Val)
+		return x == y || math.IsNaN(float64(x)) && math.IsNaN(float64(y))
+	}
+	if r, ok := RegisteredType(a.Type()); ok {
+		return r.equal(a, b)
+	}
+	return a == b
+}
 
-	[]func(arg Native) Native{ // 20 ← Byte
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if arg.(ByteVal).GoByte() > byte(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(arg.(ByteVal).Int()) },          // 2  ← Int8
-		func(arg Native) Native { return IntVal(arg.(ByteVal).Int()) },          // 3  ← Int16
-		func(arg Native) Native { return IntVal(arg.(ByteVal).Int()) },          // 4  ← Int32
-		func(arg Native) Native { return arg.(ByteVal).Int() },                  // 4  ← Int
-		func(arg Native) Native { return IntVal(arg.(ByteVal).Int()).BigInt() }, // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(arg.(ByteVal).Uint()) },       // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(arg.(ByteVal).Uint()) },      // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(arg.(ByteVal).Uint()) },      // 9  ← Uint32
-		func(arg Native) Native { return UintVal(arg.(ByteVal).Uint()) },        // 10 ← Uint
-		func(arg Native) Native { return arg.(ByteVal).Float().Flt32() },        // 12 ← Flt32
-		func(arg Native) Native { return arg.(ByteVal).Float() },                // 13 ← Float
-		func(arg Native) Native { return FltVal(arg.(ByteVal).Int()).BigFlt() }, // 14 ← BigFlt
-		func(arg Native) Native { return arg.(ByteVal).Ratio() },                // 15 ← Ratio
-		func(arg Native) Native { return Imag64Val(arg.(ByteVal).Imag()) },      // 16 ← Imag64
-		func(arg Native) Native { return arg.(ByteVal).Imag() },                 // 17 ← Imag
-		func(arg Native) Native {
-			if arg.(ByteVal).Int() > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return arg.(ByteVal) },                            // 19 ← Duration
-		func(arg Native) Native { return arg.(ByteVal) },                            // 20 ← Byte
-		func(arg Native) Native { return RuneVal(rune(arg.(ByteVal))) },             // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(ByteVal))) },          // 22 ← Flag
-		func(arg Native) Native { return StrVal(string(arg.(ByteVal))) },            // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte{arg.(ByteVal).GoByte()}) }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+//// CONVERSION
+///
+// error returned by conversions, that failed, or lost information
+type ConversionError struct {
+	Value Native
+	To    TyNat
+	Lossy bool
+	Err   error
+}
 
-	[]func(arg Native) Native{ // 21 ← Rune
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if rune(arg.(RuneVal)) > rune(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))) },                   // 2  ← Int8
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))) },                   // 3  ← Int16
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))) },                   // 4  ← Int32
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))) },                   // 4  ← Int
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))).BigInt() },          // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(uint(arg.(RuneVal))) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(uint(arg.(RuneVal))) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(uint(arg.(RuneVal))) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(uint(arg.(RuneVal))) },                 // 10 ← Uint
-		func(arg Native) Native { return FltVal(float64(arg.(RuneVal))).Flt32() },       // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(RuneVal)) },                        // 13 ← Float
-		func(arg Native) Native { return FltVal(float64(arg.(RuneVal))).BigFlt() },      // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))).Ratio() },           // 15 ← Ratio
-		func(arg Native) Native { return Imag64Val(IntVal(int(arg.(RuneVal))).Imag()) }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(int(arg.(RuneVal))).Imag() },            // 17 ← Imag
-		func(arg Native) Native {
-			if int(arg.(RuneVal)) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return arg.(RuneVal) },                               // 19 ← Duration
-		func(arg Native) Native { return arg.(RuneVal) },                               // 20 ← Byte
-		func(arg Native) Native { return RuneVal(rune(arg.(RuneVal))) },                // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(RuneVal))) },             // 22 ← Flag
-		func(arg Native) Native { return StrVal(string(arg.(RuneVal))) },               // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte{byte(rune(arg.(RuneVal)))}) }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+func (e ConversionError) Error() string {
+	if e.Lossy {
+		return fmt.Sprintf("conversion of %s from %s to %s is lossy",
+			stringOf(e.Value), e.Value.Type().TypeName(), e.To.TypeName())
+	}
+	return e.Err.Error()
+}
 
-	[]func(arg Native) Native{ // 22 ← Flag
-		func(arg Native) Native { return NilVal{} }, // 0  ← Nil
-		func(arg Native) Native {
-			if rune(arg.(BitFlag)) > rune(0) {
-				return BoolVal(true)
-			}
-			return BoolVal(false)
-		}, // 1  ← Bool
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))) },                   // 2  ← Int8
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))) },                   // 3  ← Int16
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))) },                   // 4  ← Int32
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))) },                   // 4  ← Int
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))).BigInt() },          // 5  ← BigInt
-		func(arg Native) Native { return Uint8Val(uint(arg.(BitFlag))) },                // 6  ← Uint8
-		func(arg Native) Native { return Uint16Val(uint(arg.(BitFlag))) },               // 7  ← Uint16
-		func(arg Native) Native { return Uint32Val(uint(arg.(BitFlag))) },               // 9  ← Uint32
-		func(arg Native) Native { return UintVal(uint(arg.(BitFlag))) },                 // 10 ← Uint
-		func(arg Native) Native { return FltVal(float64(arg.(BitFlag))).Flt32() },       // 12 ← Flt32
-		func(arg Native) Native { return FltVal(arg.(BitFlag)) },                        // 13 ← Float
-		func(arg Native) Native { return FltVal(float64(arg.(BitFlag))).BigFlt() },      // 14 ← BigFlt
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))).Ratio() },           // 15 ← Ratio
-		func(arg Native) Native { return Imag64Val(IntVal(int(arg.(BitFlag))).Imag()) }, // 16 ← Imag64
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))).Imag() },            // 17 ← Imag
-		func(arg Native) Native {
-			if int(arg.(BitFlag)) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		}, // 18 ← Time
-		func(arg Native) Native { return arg.(BitFlag) },                               // 19 ← Duration
-		func(arg Native) Native { return arg.(BitFlag) },                               // 20 ← Byte
-		func(arg Native) Native { return RuneVal(rune(arg.(BitFlag))) },                // 21 ← Rune
-		func(arg Native) Native { return BitFlag(UintVal(arg.(BitFlag))) },             // 22 ← Flag
-		func(arg Native) Native { return StrVal(string(arg.(BitFlag))) },               // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte{byte(rune(arg.(BitFlag)))}) }, // 24 ← Bytes
-		func(arg Native) Native {
-			if arg.(BoolVal).Bool() {
-				return NewError(
-					fmt.Errorf(
-						"error occured during convertion from uint"))
-			}
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+func (e ConversionError) Unwrap() error { return e.Err }
 
-	[]func(arg Native) Native{ // 23 ← String
-		func(arg Native) Native { return NilVal{} },                   // 0  ← Nil
-		func(arg Native) Native { return arg.(StrVal).ReadBoolVal() }, // 1  ← Bool
-		func(arg Native) Native { // 2  ← Int8
-			if i := arg.(StrVal).ReadIntVal(); i.Type().Match(Int) {
-				return Int8Val(i.(IntVal).Int())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Int16
-			if i := arg.(StrVal).ReadIntVal(); i.Type().Match(Int) {
-				return Int16Val(i.(IntVal).Int())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Int32
-			if i := arg.(StrVal).ReadIntVal(); i.Type().Match(Int) {
-				return Int32Val(i.(IntVal).Int())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Int
-			if i := arg.(StrVal).ReadIntVal(); i.Type().Match(Int) {
-				return IntVal(i.(IntVal).Int())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Int32
-			if i := arg.(StrVal).ReadIntVal(); i.Type().Match(Int) {
-				return (*BigIntVal)(big.NewInt(int64(i.(IntVal))))
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Uint8
-			if i := arg.(StrVal).ReadUintVal(); i.Type().Match(Uint) {
-				return Uint8Val(i.(UintVal).Uint())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Uint16
-			if i := arg.(StrVal).ReadUintVal(); i.Type().Match(Uint) {
-				return Uint16Val(i.(UintVal).Uint())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 2  ← Uint32
-			if i := arg.(StrVal).ReadUintVal(); i.Type().Match(Uint) {
-				return Uint32Val(i.(UintVal).Uint())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 11  ← Uint
-			if i := arg.(StrVal).ReadUintVal(); i.Type().Match(Uint) {
-				return UintVal(i.(UintVal).Uint())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 11  ← Flt32
-			if i := arg.(StrVal).ReadFloatVal(); i.Type().Match(Float) {
-				return Flt32Val(i.(FltVal).Float())
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 12  ← Float
-			if i := arg.(StrVal).ReadFloatVal(); i.Type().Match(Float) {
-				return (*BigFltVal)(big.NewFloat(float64(i.(FltVal))))
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { // 13  ← BigFlt
-			if i := arg.(StrVal).ReadFloatVal(); i.Type().Match(Float) {
-				return (*BigFltVal)(big.NewFloat(float64(i.(FltVal))))
-			}
-			return NewNil()
-		},
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))).Ratio() },           // 14 ← Ratio
-		func(arg Native) Native { return Imag64Val(IntVal(int(arg.(BitFlag))).Imag()) }, // 15 ← Imag64
-		func(arg Native) Native { return IntVal(int(arg.(BitFlag))).Imag() },            // 16 ← Imag
-		func(arg Native) Native { // 18 ← Time
-			if int(arg.(BitFlag)) > 0 {
-				return TimeVal(time.Now())
-			}
-			return TimeVal{}
-		},
-		func(arg Native) Native { return arg.(BitFlag) },                            // 19 ← Duration
-		func(arg Native) Native { return ByteVal([]byte(string(arg.(StrVal)))[0]) }, // 20 ← Byte
-		func(arg Native) Native { return RuneVal([]rune(string(arg.(StrVal)))[0]) }, // 21 ← Rune
-		func(arg Native) Native { return RuneVal([]rune(string(arg.(StrVal)))[0]) }, // 22 ← Flag
-		func(arg Native) Native { return arg.(StrVal) },                             // 23 ← String
-		func(arg Native) Native { return BytesVal([]byte(string(arg.(StrVal)))) },   // 24 ← Bytes
-		func(arg Native) Native {
-			return NewError(
-				fmt.Errorf(
-					"error occured during convertion from uint"))
-			return ErrorVal{}
-		}, // 25 ← Error
-	},
+// converts the native passed to the target type by looking up the
+// conversion in the type conversion table. conversions that can't be
+// performed, like parsing a string that does not contain a number, return a
+// ConversionError. narrowing and lossy conversions, that yield a value which
+// does not convert back to the original value, like an int overflowing an
+// int8, or a float truncated to an integer, return the converted value
+// along with a ConversionError marked as lossy. big floats and ratios are
+// converted by the zero numeric context.
+func Convert(v Native, to TyNat) (Native, error) {
+	return NumContext{}.Convert(v, to)
+}
+
+func convert(v Native, to TyNat) (Native, error) {
+	if v == nil {
+		return nil, ConversionError{NilVal{}, to, false, errConversion(v, to).E}
+	}
+	var from = v.Type()
+	if !convertable(from) || !convertable(to) ||
+		from.Flag().Count() != 1 || to.Flag().Count() != 1 {
+		return nil, ConversionError{v, to, false, fmt.Errorf(
+			"no conversion defined from %s to %s",
+			from.TypeName(), to.TypeName())}
+	}
+	var res = TypeConversionTable.Get(from, to)(v)
+	if err, ok := res.(ErrorVal); ok && to != Error {
+		return nil, ConversionError{v, to, false, err.E}
+	}
+	if !lossless(v, res) {
+		return res, ConversionError{v, to, true, nil}
+	}
+	return res, nil
+}
+
+// numbers are compared by their exact value, values of other types by
+// converting the result back to the source type. decimals converted from
+// floats are lossless, if they convert back to the same float.
+func lossless(v, res Native) bool {
+	if res.Type() == Decimal && v.Type().Match(Flt32|Float|Imaginarys) {
+		return equalNative(TypeConversionTable.Get(Decimal, v.Type())(res), v)
+	}
+	var numeric = Numbers | Bool | Byte | Rune | Flag | Duration
+	// numbers parsed from text, like zero padded numbers, don't format
+	// back to the same string, parsing fails, if they are out of range.
+	if (v.Type() == String || v.Type() == Bytes) && res.Type().Match(numeric) {
+		return true
+	}
+	if v.Type().Match(numeric) && res.Type().Match(numeric) {
+		var x, xok = fromNumber(v, Ratio).(RatioVal)
+		var y, yok = fromNumber(res, Ratio).(RatioVal)
+		if xok && yok {
+			return x.GoRat().Cmp(y.GoRat()) == 0 && imagOf(v) == imagOf(res)
+		}
+	}
+	return equalNative(TypeConversionTable.Get(res.Type(), v.Type())(res), v)
+}
+
+func imagOf(v Native) float64 {
+	switch x := v.(type) {
+	case ImagVal:
+		return imag(x)
+	case Imag64Val:
+		return float64(imag(x))
+	}
+	return 0
+}
 
-	[]func(arg Native) Native{ // 24 ← Bytes
-		func(Native) Native { return NilVal{} },                  // 0  ← Nil
-		func(Native) Native { return BoolVal(false) },            // 1  ← Bool
-		func(Native) Native { return Int8Val(0) },                // 2  ← Int8
-		func(Native) Native { return Int16Val(0) },               // 3  ← Int16
-		func(Native) Native { return Int32Val(0) },               // 4  ← Int32
-		func(Native) Native { return IntVal(0) },                 // 4  ← Int
-		func(Native) Native { return IntVal(0).BigInt() },        // 5  ← BigInt
-		func(Native) Native { return Uint8Val(0) },               // 6  ← Uint8
-		func(Native) Native { return Uint16Val(0) },              // 7  ← Uint16
-		func(Native) Native { return Uint32Val(0) },              // 9  ← Uint32
-		func(Native) Native { return UintVal(0) },                // 10 ← Uint
-		func(Native) Native { return Flt32Val(0.0) },             // 12 ← Flt32
-		func(Native) Native { return FltVal(0.0) },               // 13 ← Float
-		func(Native) Native { return FltVal(0.0).BigFlt() },      // 14 ← BigFlt
-		func(Native) Native { return IntVal(0).Ratio() },         // 15 ← Ratio
-		func(Native) Native { return IntVal(0).Imag().Imag64() }, // 16 ← Imag64
-		func(Native) Native { return IntVal(0).Imag() },          // 17 ← Imag
-		func(Native) Native { return TimeVal{} },                 // 18 ← Time
-		func(arg Native) Native { return DuraVal(0) },            // 19 ← Duration
-		func(arg Native) Native { return ByteVal(0) },            // 20 ← Byte
-		func(arg Native) Native { return RuneVal(0) },            // 21 ← Rune
-		func(arg Native) Native { return BitFlag(0) },            // 22 ← Flag
-		func(arg Native) Native { return StrVal("") },            // 23 ← String
-		func(arg Native) Native { return BytesVal{} },            // 24 ← Bytes
-		func(arg Native) Native { return ErrorVal{} },            // 25 ← Error
-	},
+// converts the native passed to the target type, returns an error instead
+// of the converted value, if the conversion is lossy.
+func ConvertExact(v Native, to TyNat) (Native, error) {
+	var res, err = Convert(v, to)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
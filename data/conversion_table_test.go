@@ -0,0 +1,101 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestConversionTableComplete(t *testing.T) {
+	var scalars = marshalNatives[:25]
+	for _, from := range scalars {
+		for _, to := range FetchTypes() {
+			if !to.Match(Convertables) {
+				continue
+			}
+			var res, err = Convert(from, to)
+			if res != nil && res.Type() != to {
+				t.Log("conversion of", from.Type(), "to", to,
+					"yields type", res.Type())
+				t.Fail()
+			}
+			if res == nil && err == nil {
+				t.Log("conversion of", from.Type(), "to", to,
+					"yields neither value, nor error")
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var cases = []struct {
+		from   Native
+		to     TyNat
+		result string
+		lossy  bool
+		fails  bool
+	}{
+		{FltVal(3.0), Int8, "3", false, false},
+		{FltVal(3.5), Int8, "3", true, false},
+		{IntVal(300), Int8, "44", true, false},
+		{BigIntVal(*big.NewInt(1 << 40)), Int32, "0", true, false},
+		{BigIntVal(*big.NewInt(1 << 40)), Int, "1099511627776", false, false},
+		{Int8Val(-8), Uint8, "248", true, false},
+		{RatioVal(*big.NewRat(3, 4)), Float, "0.75", false, false},
+		{RatioVal(*big.NewRat(1, 3)), Int, "0", true, false},
+		{StrVal("42"), Int, "42", false, false},
+		{StrVal("12abc"), Int, "", false, true},
+		{StrVal("300"), Int8, "", false, true},
+		{StrVal("010"), Int, "10", false, false},
+		{StrVal(" 0755"), Uint16, "755", false, false},
+		{StrVal("0100"), BigInt, "100", false, false},
+		{StrVal("010/3"), Ratio, "10/3", false, false},
+		{StrVal("1_000"), Int, "", false, true},
+		{StrVal("1_000"), Ratio, "", false, true},
+		{StrVal("1_000.5"), BigFlt, "", false, true},
+		{StrVal("0x10"), Int, "", false, true},
+		{StrVal("6.4 + -3.2i"), Imag, "6.4 + -3.2i", false, false},
+		{StrVal("Int∙Float"), Flag, "Int∙Float", false, false},
+		{StrVal("3m0s"), Duration, "3m0s", false, false},
+		{BitFlag(Int | Float), String, "Int∙Float", false, false},
+		{ImagVal(complex(1, 2)), Float, "1", true, false},
+		{BoolVal(true), Int, "1", false, false},
+		{IntVal(5), Bool, "true", true, false},
+		{StrVal("error message"), Error, "Error: error message", false, false},
+		{ErrorVal{}, Int, "", false, true},
+	}
+	for _, c := range cases {
+		var res, err = Convert(c.from, c.to)
+		fmt.Printf("%s → %s: %v %v\n", c.from.Type(), c.to, res, err)
+		if c.fails {
+			if err == nil || res != nil {
+				t.Log("conversion should fail", c.from, c.to)
+				t.Fail()
+			}
+			continue
+		}
+		if res == nil || res.String() != c.result {
+			t.Log("expected", c.result, "got", res)
+			t.Fail()
+			continue
+		}
+		if lossy := err != nil && err.(ConversionError).Lossy; lossy != c.lossy {
+			t.Log("expected lossy", c.lossy, "got", err)
+			t.Fail()
+		}
+		exact, err := ConvertExact(c.from, c.to)
+		if c.lossy && (exact != nil || err == nil) {
+			t.Log("exact conversion should fail", c.from, c.to)
+			t.Fail()
+		}
+	}
+}
+
+func TestTypePrecTable(t *testing.T) {
+	var a, b = TypePrec(StrVal("count: "), IntVal(3))
+	if a.Type() != String || b.Type() != String || b.String() != "3" {
+		t.Log("int should be converted to string", a, b)
+		t.Fail()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
+	"math"
 	"math/big"
 	"math/bits"
 	"strings"
@@ -40,6 +41,12 @@ import (
 func TypePrec(a, b Native) (x, y Native) {
 	// if arguments types happend to be different‥.
 	if at, bt := a.Type(), b.Type(); at != bt {
+		// ‥.numbers get promoted to the least type both can be
+		// represented by‥.
+		if at.Match(Numbers|Bool) && bt.Match(Numbers|Bool) {
+			var t = NumberPrec(at, bt)
+			return Promote(a, t), Promote(b, t)
+		}
 		var ati, bti = at.Flag().Index(), bt.Flag().Index()
 		if ati > bti {
 			// return unchanged a and cast b as type of a
@@ -52,6 +59,192 @@ func TypePrec(a, b Native) (x, y Native) {
 	return a, b
 }
 
+// precedence of number types, types of higher rank can represent all values
+// of types with lower rank, regardless of sign and width, or approximate
+// them in case of reals and imaginary numbers.
+var numberRank = map[TyNat]int{
+	Bool:    0,
+	Uint8:   1,
+	Int8:    2,
+	Uint16:  3,
+	Int16:   4,
+	Uint32:  5,
+	Int32:   6,
+	Uint:    7,
+	Int:     8,
+	BigInt:  9,
+	Decimal: 10,
+	Ratio:   11,
+	Flt32:   12,
+	Float:   13,
+	BigFlt:  14,
+	Imag64:  15,
+	Imag:    16,
+}
+
+// yields the number type, values of both types passed get promoted to, when
+// they are operands of the same expression. when a fixed size signed integer
+// meets an unsigned integer of the same or larger size, the least signed
+// type wide enough to hold both is returned.
+func NumberPrec(a, b TyNat) TyNat {
+	if numberRank[a] < numberRank[b] {
+		a, b = b, a
+	}
+	var fixed = Naturals | Int8 | Int16 | Int32 | Int
+	if a.Match(fixed) && b.Match(fixed) {
+		var signed, unsigned = a, b
+		if a.Match(Naturals) {
+			signed, unsigned = b, a
+		}
+		if signed.Match(Naturals) || !unsigned.Match(Naturals) {
+			return a
+		}
+		if intWidth(signed) > intWidth(unsigned) {
+			return signed
+		}
+		switch intWidth(unsigned) {
+		case 8:
+			return Int16
+		case 16:
+			return Int32
+		case 32:
+			return Int
+		}
+		return BigInt
+	}
+	return a
+}
+
+func intWidth(t TyNat) int {
+	switch t {
+	case Int8, Uint8:
+		return 8
+	case Int16, Uint16:
+		return 16
+	case Int32, Uint32:
+		return 32
+	}
+	return 64
+}
+
+// promotes a number to the number type passed, which is expected to be of
+// equal, or higher rank. values that can't be promoted are returned as is.
+func Promote(v Native, to TyNat) Native {
+	if v.Type() == to {
+		return v
+	}
+	switch x := v.(type) {
+	case BoolVal:
+		if x {
+			return promoteUint(1, to, v)
+		}
+		return promoteUint(0, to, v)
+	case Uint8Val:
+		return promoteUint(uint64(x), to, v)
+	case Uint16Val:
+		return promoteUint(uint64(x), to, v)
+	case Uint32Val:
+		return promoteUint(uint64(x), to, v)
+	case UintVal:
+		return promoteUint(uint64(x), to, v)
+	case Int8Val:
+		return promoteInt(int64(x), to, v)
+	case Int16Val:
+		return promoteInt(int64(x), to, v)
+	case Int32Val:
+		return promoteInt(int64(x), to, v)
+	case IntVal:
+		return promoteInt(int64(x), to, v)
+	case BigIntVal:
+		return promoteRat(new(big.Rat).SetInt(x.GoBigInt()), to, v)
+	case RatioVal:
+		return promoteRat(new(big.Rat).Set(x.GoRat()), to, v)
+	case DecimalVal:
+		return promoteRat(x.GoRat(), to, v)
+	case Flt32Val:
+		return promoteFlt(float64(x), to, v)
+	case FltVal:
+		return promoteFlt(float64(x), to, v)
+	case BigFltVal:
+		var f, _ = x.GoBigFlt().Float64()
+		return promoteFlt(f, to, v)
+	case Imag64Val:
+		if to == Imag {
+			return ImagVal(complex128(x))
+		}
+	}
+	return v
+}
+
+func promoteUint(u uint64, to TyNat, v Native) Native {
+	switch to {
+	case Uint8:
+		return Uint8Val(u)
+	case Uint16:
+		return Uint16Val(u)
+	case Uint32:
+		return Uint32Val(u)
+	case Uint:
+		return UintVal(u)
+	case Int8:
+		return Int8Val(u)
+	case Int16:
+		return Int16Val(u)
+	case Int32:
+		return Int32Val(u)
+	case Int:
+		return IntVal(u)
+	}
+	return promoteRat(new(big.Rat).SetInt(new(big.Int).SetUint64(u)), to, v)
+}
+
+func promoteInt(i int64, to TyNat, v Native) Native {
+	switch to {
+	case Int16:
+		return Int16Val(i)
+	case Int32:
+		return Int32Val(i)
+	case Int:
+		return IntVal(i)
+	}
+	return promoteRat(new(big.Rat).SetInt64(i), to, v)
+}
+
+func promoteRat(r *big.Rat, to TyNat, v Native) Native {
+	switch to {
+	case BigInt:
+		if r.IsInt() {
+			return BigIntVal(*new(big.Int).Set(r.Num()))
+		}
+	case Ratio:
+		return RatioVal(*r)
+	case Decimal:
+		return decimalOfRat(r)
+	case BigFlt:
+		return BigFltVal(*new(big.Float).SetRat(r))
+	}
+	var f, _ = r.Float64()
+	return promoteFlt(f, to, v)
+}
+
+func promoteFlt(f float64, to TyNat, v Native) Native {
+	switch to {
+	case Flt32:
+		return Flt32Val(f)
+	case Float:
+		return FltVal(f)
+	case BigFlt:
+		if !math.IsNaN(f) {
+			return BigFltVal(*new(big.Float).SetFloat64(f))
+		}
+	case Imag64:
+		return Imag64Val(complex(float32(f), 0))
+	case Imag:
+		return ImagVal(complex(f, 0))
+	}
+	return v
+}
+
 // BOOL VALUE
 func (v BoolVal) GoRat() *big.Rat      { return (*big.Rat)(v.Ratio()) }
 func (v BoolVal) GoBigInt() *big.Int   { return big.NewInt(int64(v.Int())) }
@@ -164,7 +357,7 @@ func (v Uint8Val) AndNot(arg Uint8Val) Uint8Val { return v &^ arg }
 func (v Uint8Val) Add(arg Uint8Val) Uint8Val       { return v + arg }
 func (v Uint8Val) Substract(arg Uint8Val) Uint8Val { return v - arg }
 func (v Uint8Val) Multipy(arg Uint8Val) Uint8Val   { return v * arg }
-func (v Uint8Val) Power(arg Uint8Val) Uint8Val     { return v ^ arg }
+func (v Uint8Val) Power(arg Uint8Val) Uint8Val     { return Uint8Val(powUint(uint64(v), uint64(arg))) }
 func (v Uint8Val) Quotient(arg Uint8Val) Uint8Val  { return v / arg }
 func (v Uint8Val) QuoRatio(arg Uint8Val) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -188,7 +381,7 @@ func (v Uint8Val) Dec() UintVal                   { r := v.Uint() - 1; return r
 func (v Uint8Val) AddU(arg UintVal) UintVal       { return v.Uint() + arg }
 func (v Uint8Val) SubstractU(arg UintVal) UintVal { return v.Uint() - arg }
 func (v Uint8Val) MultipyU(arg UintVal) UintVal   { return v.Uint() * arg }
-func (v Uint8Val) PowerU(arg UintVal) UintVal     { return v.Uint() ^ arg }
+func (v Uint8Val) PowerU(arg UintVal) UintVal     { return UintVal(powUint(uint64(v), uint64(arg))) }
 func (v Uint8Val) QuotientU(arg UintVal) UintVal  { return v.Uint() / arg }
 func (v Uint8Val) QuoRatioU(arg UintVal) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -243,7 +436,7 @@ func (v Uint16Val) AndNot(arg Uint16Val) Uint16Val { return v &^ arg }
 func (v Uint16Val) Add(arg Uint16Val) Uint16Val       { return v + arg }
 func (v Uint16Val) Substract(arg Uint16Val) Uint16Val { return v - arg }
 func (v Uint16Val) Multipy(arg Uint16Val) Uint16Val   { return v * arg }
-func (v Uint16Val) Power(arg Uint16Val) Uint16Val     { return v ^ arg }
+func (v Uint16Val) Power(arg Uint16Val) Uint16Val     { return Uint16Val(powUint(uint64(v), uint64(arg))) }
 func (v Uint16Val) Quotient(arg Uint16Val) Uint16Val  { return v / arg }
 func (v Uint16Val) QuoRatio(arg Uint16Val) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -267,7 +460,7 @@ func (v Uint16Val) Dec() UintVal                   { r := v.Uint() - 1; return r
 func (v Uint16Val) AddU(arg UintVal) UintVal       { return v.Uint() + arg }
 func (v Uint16Val) SubstractU(arg UintVal) UintVal { return v.Uint() - arg }
 func (v Uint16Val) MultipyU(arg UintVal) UintVal   { return v.Uint() * arg }
-func (v Uint16Val) PowerU(arg UintVal) UintVal     { return v.Uint() ^ arg }
+func (v Uint16Val) PowerU(arg UintVal) UintVal     { return UintVal(powUint(uint64(v), uint64(arg))) }
 func (v Uint16Val) QuotientU(arg UintVal) UintVal  { return v.Uint() / arg }
 func (v Uint16Val) QuoRatioU(arg UintVal) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -324,8 +517,8 @@ func (v Uint32Val) Dec() UintVal                      { r := v.Uint() - 1; retur
 func (v Uint32Val) Add(arg Uint32Val) Uint32Val       { return v + arg }
 func (v Uint32Val) Substract(arg Uint32Val) Uint32Val { return v - arg }
 func (v Uint32Val) Multipy(arg Uint32Val) Uint32Val   { return v * arg }
-func (v Uint32Val) Power(arg Uint32Val) Uint32Val     { return v ^ arg }
-func (v Uint32Val) Quotien(arg Uint32Val) Uint32Val   { return v / arg }
+func (v Uint32Val) Power(arg Uint32Val) Uint32Val     { return Uint32Val(powUint(uint64(v), uint64(arg))) }
+func (v Uint32Val) Quotient(arg Uint32Val) Uint32Val  { return v / arg }
 func (v Uint32Val) QuoRatio(arg Uint32Val) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
 }
@@ -346,7 +539,7 @@ func (v Uint32Val) AndNotU(arg UintVal) UintVal { return v.Uint() &^ arg }
 func (v Uint32Val) AddU(arg UintVal) UintVal       { return v.Uint() + arg }
 func (v Uint32Val) SubstractU(arg UintVal) UintVal { return v.Uint() - arg }
 func (v Uint32Val) MultipyU(arg UintVal) UintVal   { return v.Uint() * arg }
-func (v Uint32Val) PowerU(arg UintVal) UintVal     { return v.Uint() ^ arg }
+func (v Uint32Val) PowerU(arg UintVal) UintVal     { return UintVal(powUint(uint64(v), uint64(arg))) }
 func (v Uint32Val) QuotientU(arg UintVal) UintVal  { return v.Uint() / arg }
 func (v Uint32Val) QuoRatioU(arg UintVal) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -401,10 +594,12 @@ func (v UintVal) AndNot(arg UintVal) UintVal { return v &^ arg }
 func (v UintVal) Add(arg UintVal) UintVal       { return v + arg }
 func (v UintVal) Substract(arg UintVal) UintVal { return v - arg }
 func (v UintVal) Multipy(arg UintVal) UintVal   { return v * arg }
-func (v UintVal) Power(arg UintVal) UintVal     { return v ^ arg }
+func (v UintVal) Power(arg UintVal) UintVal     { return UintVal(powUint(uint64(v), uint64(arg))) }
 func (v UintVal) Quotient(arg UintVal) UintVal  { return v / arg }
 func (v UintVal) QuoRatio(arg UintVal) *RatioVal {
-	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
+	return (*RatioVal)(new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(uint64(v)),
+		new(big.Int).SetUint64(uint64(arg))))
 }
 
 // comparators
@@ -425,7 +620,7 @@ func (v UintVal) Dec() UintVal                   { r := v - 1; return r }
 func (v UintVal) AddU(arg UintVal) UintVal       { return v + arg }
 func (v UintVal) SubstractU(arg UintVal) UintVal { return v - arg }
 func (v UintVal) MultipyU(arg UintVal) UintVal   { return v * arg }
-func (v UintVal) PowerU(arg UintVal) UintVal     { return v ^ arg }
+func (v UintVal) PowerU(arg UintVal) UintVal     { return UintVal(powUint(uint64(v), uint64(arg))) }
 func (v UintVal) QuotientU(arg UintVal) UintVal  { return v / arg }
 func (v UintVal) QuoRatioU(arg UintVal) *RatioVal {
 	return (*RatioVal)(big.NewRat(int64(v), int64(arg)))
@@ -496,7 +691,7 @@ func (v Int8Val) Negate() Int8Val               { return -v }
 func (v Int8Val) Add(arg Int8Val) Int8Val       { return v + arg }
 func (v Int8Val) Substract(arg Int8Val) Int8Val { return v - arg }
 func (v Int8Val) Multipy(arg Int8Val) Int8Val   { return v * arg }
-func (v Int8Val) Power(arg Int8Val) Int8Val     { return v ^ arg }
+func (v Int8Val) Power(arg Int8Val) Int8Val     { return Int8Val(powInt(int64(v), int64(arg))) }
 func (v Int8Val) Quotient(arg Int8Val) Int8Val  { return v / arg }
 
 // comparators
@@ -518,7 +713,7 @@ func (v Int8Val) NegateI() IntVal              { return -v.Int() }
 func (v Int8Val) AddI(arg IntVal) IntVal       { return v.Int() + arg }
 func (v Int8Val) SubstractI(arg IntVal) IntVal { return v.Int() - arg }
 func (v Int8Val) MultipyI(arg IntVal) IntVal   { return v.Int() * arg }
-func (v Int8Val) PowerI(arg IntVal) IntVal     { return v.Int() ^ arg }
+func (v Int8Val) PowerI(arg IntVal) IntVal     { return IntVal(powInt(int64(v), int64(arg))) }
 func (v Int8Val) QuotientI(arg IntVal) IntVal  { return v.Int() / arg }
 
 // comparators
@@ -585,7 +780,7 @@ func (v Int16Val) Negate() Int16Val                { return -v }
 func (v Int16Val) Add(arg Int16Val) Int16Val       { return v + arg }
 func (v Int16Val) Substract(arg Int16Val) Int16Val { return v - arg }
 func (v Int16Val) Multipy(arg Int16Val) Int16Val   { return v * arg }
-func (v Int16Val) Power(arg Int16Val) Int16Val     { return v ^ arg }
+func (v Int16Val) Power(arg Int16Val) Int16Val     { return Int16Val(powInt(int64(v), int64(arg))) }
 func (v Int16Val) Quotient(arg Int16Val) Int16Val  { return v / arg }
 
 // comparators
@@ -605,7 +800,7 @@ func (v Int32Val) NegateI() IntVal              { return -v.Int() }
 func (v Int32Val) AddI(arg IntVal) IntVal       { return v.Int() + arg }
 func (v Int32Val) SubstractI(arg IntVal) IntVal { return v.Int() - arg }
 func (v Int32Val) MultipyI(arg IntVal) IntVal   { return v.Int() * arg }
-func (v Int32Val) PowerI(arg IntVal) IntVal     { return v.Int() ^ arg }
+func (v Int32Val) PowerI(arg IntVal) IntVal     { return IntVal(powInt(int64(v), int64(arg))) }
 func (v Int32Val) QuotientI(arg IntVal) IntVal  { return v.Int() / arg }
 
 // comparators
@@ -674,7 +869,7 @@ func (v Int32Val) Dec() IntVal                     { r := v.Int() - 1; return r
 func (v Int32Val) Add(arg Int32Val) Int32Val       { return v + arg }
 func (v Int32Val) Substract(arg Int32Val) Int32Val { return v - arg }
 func (v Int32Val) Multipy(arg Int32Val) Int32Val   { return v * arg }
-func (v Int32Val) Power(arg Int32Val) Int32Val     { return v ^ arg }
+func (v Int32Val) Power(arg Int32Val) Int32Val     { return Int32Val(powInt(int64(v), int64(arg))) }
 func (v Int32Val) Quotient(arg Int32Val) Int32Val  { return v / arg }
 
 // comparators
@@ -741,7 +936,7 @@ func (v IntVal) Negate() IntVal              { return -v }
 func (v IntVal) Add(arg IntVal) IntVal       { return v + arg }
 func (v IntVal) Substract(arg IntVal) IntVal { return v - arg }
 func (v IntVal) Multipy(arg IntVal) IntVal   { return v * arg }
-func (v IntVal) Power(arg IntVal) IntVal     { return v ^ arg }
+func (v IntVal) Power(arg IntVal) IntVal     { return IntVal(powInt(int64(v), int64(arg))) }
 func (v IntVal) Quotient(arg IntVal) IntVal  { return v / arg }
 
 // comparators
@@ -761,7 +956,7 @@ func (v IntVal) NegateI() IntVal              { return -v }
 func (v IntVal) AddI(arg IntVal) IntVal       { return v + arg }
 func (v IntVal) SubstractI(arg IntVal) IntVal { return v - arg }
 func (v IntVal) MultipyI(arg IntVal) IntVal   { return v * arg }
-func (v IntVal) PowerI(arg IntVal) IntVal     { return v ^ arg }
+func (v IntVal) PowerI(arg IntVal) IntVal     { return IntVal(powInt(int64(v), int64(arg))) }
 func (v IntVal) QuotientI(arg IntVal) IntVal  { return v / arg }
 
 // comparators
@@ -946,23 +1141,23 @@ func (v *RatioVal) Dec() *RatioVal {
 	r := v.Substract((*RatioVal)(big.NewRat(int64(1), int64(1))))
 	return r
 }
-func (v *RatioVal) Negate() *RatioVal { return (*RatioVal)((*big.Rat)(v).Neg((*big.Rat)(v))) }
-func (v *RatioVal) Invert() *RatioVal { return (*RatioVal)((*big.Rat)(v).Inv((*big.Rat)(v))) }
+func (v *RatioVal) Negate() *RatioVal { return (*RatioVal)(new(big.Rat).Neg((*big.Rat)(v))) }
+func (v *RatioVal) Invert() *RatioVal { return (*RatioVal)(new(big.Rat).Inv((*big.Rat)(v))) }
 func (v *RatioVal) Add(arg *RatioVal) *RatioVal {
-	return (*RatioVal)((*big.Rat)(v).Add((*big.Rat)(v), (*big.Rat)(arg)))
+	return (*RatioVal)(new(big.Rat).Add((*big.Rat)(v), (*big.Rat)(arg)))
 }
 func (v *RatioVal) Substract(arg *RatioVal) *RatioVal {
-	return (*RatioVal)((*big.Rat)(v).Sub((*big.Rat)(v), (*big.Rat)(arg)))
+	return (*RatioVal)(new(big.Rat).Sub((*big.Rat)(v), (*big.Rat)(arg)))
 }
 func (v *RatioVal) Multipy(arg *RatioVal) *RatioVal {
-	return (*RatioVal)((*big.Rat)(v).Mul((*big.Rat)(v), (*big.Rat)(arg)))
+	return (*RatioVal)(new(big.Rat).Mul((*big.Rat)(v), (*big.Rat)(arg)))
 }
 func (v *RatioVal) Quotient(arg *RatioVal) *RatioVal {
-	return (*RatioVal)((*big.Rat)(v).Quo((*big.Rat)(v), (*big.Rat)(arg)))
+	return (*RatioVal)(new(big.Rat).Quo((*big.Rat)(v), (*big.Rat)(arg)))
 }
 
 // comparators
-func (v *RatioVal) Cmp(arg *RatioVal) int      { return v.Cmp(arg) }
+func (v *RatioVal) Cmp(arg *RatioVal) int      { return (*big.Rat)(v).Cmp((*big.Rat)(arg)) }
 func (v *RatioVal) Lesser(arg *RatioVal) bool  { return v.Cmp(arg) < 0 }
 func (v *RatioVal) Greater(arg *RatioVal) bool { return v.Cmp(arg) > 0 }
 func (v *RatioVal) Equal(arg *RatioVal) bool   { return v.Cmp(arg) == 0 }
@@ -998,6 +1193,15 @@ func (v Imag64Val) Bool() BoolVal {
 	}
 	return BoolVal(false)
 }
+
+// operators
+func (v Imag64Val) Negate() Imag64Val                 { return -v }
+func (v Imag64Val) Add(arg Imag64Val) Imag64Val       { return v + arg }
+func (v Imag64Val) Substract(arg Imag64Val) Imag64Val { return v - arg }
+func (v Imag64Val) Multipy(arg Imag64Val) Imag64Val   { return v * arg }
+func (v Imag64Val) Quotient(arg Imag64Val) Imag64Val  { return v / arg }
+
+// comparators
 func (v Imag64Val) Equal(arg Imag64Val) bool { return v == arg }
 func (v Imag64Val) EqualI(arg ImagVal) bool  { return v.Imag() == arg }
 
@@ -1032,11 +1236,20 @@ func (v ImagVal) Bool() BoolVal {
 	}
 	return BoolVal(false)
 }
+
+// operators
+func (v ImagVal) Negate() ImagVal               { return -v }
+func (v ImagVal) Add(arg ImagVal) ImagVal       { return v + arg }
+func (v ImagVal) Substract(arg ImagVal) ImagVal { return v - arg }
+func (v ImagVal) Multipy(arg ImagVal) ImagVal   { return v * arg }
+func (v ImagVal) Quotient(arg ImagVal) ImagVal  { return v / arg }
+
+// comparators
 func (v ImagVal) Equal(arg ImagVal) bool  { return v == arg }
 func (v ImagVal) EqualI(arg ImagVal) bool { return v == arg }
 
 /// BIG INT VALUE
-func (v *BigIntVal) Int64() int64      { return v.Int64() }
+func (v *BigIntVal) Int64() int64      { return (*big.Int)(v).Int64() }
 func (v BigIntVal) Idx() int           { return int(v.Int()) }
 func (v BigIntVal) GoInt() int         { return int(v.Int()) }
 func (v BigIntVal) GoUint() uint       { return uint(v.Uint()) }
@@ -1057,16 +1270,37 @@ func (v BigIntVal) atio() *RatioVal      { return IntVal(v.Int()).Ratio() }
 func (v BigIntVal) Imag() ImagVal        { return IntVal(v.Int()).Imag() }
 
 // operators
-func (v BigIntVal) Add(arg BigIntVal) BigIntVal       { return v.Add(arg) }
-func (v BigIntVal) Substract(arg BigIntVal) BigIntVal { return v.Substract(arg) }
-func (v BigIntVal) Multipy(arg BigIntVal) BigIntVal   { return v.Multipy(arg) }
-func (v BigIntVal) Quotient(arg BigIntVal) BigIntVal  { return v.Quotient(arg) }
-func (v BigIntVal) Power(arg BigIntVal) BigIntVal     { return v.Power(arg) }
-func (v BigIntVal) And(arg BigIntVal) BigIntVal       { return v.And(arg) }
-func (v BigIntVal) Xor(arg BigIntVal) BigIntVal       { return v.Xor(arg) }
-func (v BigIntVal) Or(arg BigIntVal) BigIntVal        { return v.Or(arg) }
-func (v BigIntVal) AndNot(arg BigIntVal) BigIntVal    { return v.AndNot(arg) }
-func (v BigIntVal) Not() BigIntVal                    { return v.Not() }
+func (v BigIntVal) Add(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Add(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Substract(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Sub(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Multipy(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Mul(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Quotient(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Quo(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Modulo(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Rem(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Power(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Exp(v.GoBigInt(), arg.GoBigInt(), nil))
+}
+func (v BigIntVal) And(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).And(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Xor(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Xor(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Or(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).Or(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) AndNot(arg BigIntVal) BigIntVal {
+	return BigIntVal(*new(big.Int).AndNot(v.GoBigInt(), arg.GoBigInt()))
+}
+func (v BigIntVal) Not() BigIntVal { return BigIntVal(*new(big.Int).Not(v.GoBigInt())) }
 
 // comparators
 func (v BigIntVal) Cmp(arg BigIntVal) int      { return v.GoBigInt().Cmp(arg.GoBigInt()) }
@@ -1075,7 +1309,7 @@ func (v BigIntVal) Lesser(arg BigIntVal) bool  { return v.Cmp(arg) < 0 }
 func (v BigIntVal) Greater(arg BigIntVal) bool { return v.Cmp(arg) > 0 }
 
 /// BIG FLOAT VALUE
-func (v *BigFltVal) Float64() int64      { return v.Float64() }
+func (v *BigFltVal) Float64() float64    { var f, _ = (*big.Float)(v).Float64(); return f }
 func (v BigFltVal) Idx() int             { return int(v.Int()) }
 func (v BigFltVal) GoInt() int           { return int(v.Int()) }
 func (v BigFltVal) GoUint() uint         { return uint(v.Uint()) }
@@ -1094,10 +1328,18 @@ func (v BigFltVal) Ratio() *RatioVal     { return IntVal(v.Int()).Ratio() }
 func (v BigFltVal) Imag() ImagVal        { return IntVal(v.Int()).Imag() }
 
 // operators
-func (v BigFltVal) Add(arg BigFltVal) BigFltVal       { return v.Add(arg) }
-func (v BigFltVal) Substract(arg BigFltVal) BigFltVal { return v.Substract(arg) }
-func (v BigFltVal) Multipy(arg BigFltVal) BigFltVal   { return v.Multipy(arg) }
-func (v BigFltVal) Quotient(arg BigFltVal) BigFltVal  { return v.Quotient(arg) }
+func (v BigFltVal) Add(arg BigFltVal) BigFltVal {
+	return BigFltVal(*new(big.Float).Add(v.GoBigFlt(), arg.GoBigFlt()))
+}
+func (v BigFltVal) Substract(arg BigFltVal) BigFltVal {
+	return BigFltVal(*new(big.Float).Sub(v.GoBigFlt(), arg.GoBigFlt()))
+}
+func (v BigFltVal) Multipy(arg BigFltVal) BigFltVal {
+	return BigFltVal(*new(big.Float).Mul(v.GoBigFlt(), arg.GoBigFlt()))
+}
+func (v BigFltVal) Quotient(arg BigFltVal) BigFltVal {
+	return BigFltVal(*new(big.Float).Quo(v.GoBigFlt(), arg.GoBigFlt()))
+}
 
 // comparators
 func (v BigFltVal) Cmp(arg BigFltVal) int      { return v.GoBigFlt().Cmp(arg.GoBigFlt()) }
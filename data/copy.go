@@ -0,0 +1,322 @@
+package data
+
+import (
+	"math/big"
+	"reflect"
+)
+
+//// DEEP COPY
+///
+// copies trees of natives, so that no buffer, big number, or collection is
+// shared between the copy and the original. copies can be mutated without
+// affecting the original. errors, expressions and natives, that don't
+// implement Reproduceable, like instances of registered types, are
+// considered immutable and returned as they are. pointers to big numbers
+// are returned as pointers to copies.
+func DeepCopy(nat Native) Native {
+	switch v := nat.(type) {
+	case nil:
+		return nil
+	case *BigIntVal:
+		if v != nil {
+			var c = v.Copy().(BigIntVal)
+			return &c
+		}
+	case *BigFltVal:
+		if v != nil {
+			var c = v.Copy().(BigFltVal)
+			return &c
+		}
+	case *RatioVal:
+		if v != nil {
+			var c = v.Copy().(RatioVal)
+			return &c
+		}
+	case Reproduceable:
+		return v.Copy()
+	}
+	return nat
+}
+
+func (s MapString) Copy() Native {
+	var m = make(MapString, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+func (s MapUint) Copy() Native {
+	var m = make(MapUint, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+func (s MapInt) Copy() Native {
+	var m = make(MapInt, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+func (s MapFloat) Copy() Native {
+	var m = make(MapFloat, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+func (s MapFlag) Copy() Native {
+	var m = make(MapFlag, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+
+// keys of generic maps are comparable natives and are not copied
+func (s MapVal) Copy() Native {
+	var m = make(MapVal, len(s))
+	for k, v := range s {
+		m[k] = DeepCopy(v)
+	}
+	return m
+}
+func (s MapHash) Copy() Native {
+	var m = make(MapHash, len(s))
+	for h, bucket := range s {
+		var b = make([]PairVal, 0, len(bucket))
+		for _, pair := range bucket {
+			b = append(b, PairVal{DeepCopy(pair.L), DeepCopy(pair.R)})
+		}
+		m[h] = b
+	}
+	return m
+}
+
+// persistent maps share their nodes between versions, copies are rebuilt
+// from copies of their fields.
+func (m HashMap) Copy() Native {
+	var b = NewHashMapBuilder()
+	for _, field := range m.Fields() {
+		b.Set(DeepCopy(field.Left()), DeepCopy(field.Right()))
+	}
+	return b.Persistent()
+}
+func (m OrderedMap) Copy() Native {
+	var fields = m.Fields()
+	for i, field := range fields {
+		fields[i] = PairVal{DeepCopy(field.Left()), DeepCopy(field.Right())}
+	}
+	return NewOrderedMap(fields...)
+}
+func (s SortedSet) Copy() Native {
+	var elems = s.Slice()
+	for i, elem := range elems {
+		elems[i] = DeepCopy(elem)
+	}
+	return NewSortedSet(elems...)
+}
+func (v Vector) Copy() Native {
+	var elems = v.Slice()
+	for i, elem := range elems {
+		elems[i] = DeepCopy(elem)
+	}
+	return NewVector(elems...)
+}
+func (t Table) Copy() Native {
+	var c = Table{
+		names: append([]string{}, t.names...),
+		cols:  make([]Sliceable, 0, len(t.cols)),
+		rows:  t.rows,
+	}
+	for _, col := range t.cols {
+		c.cols = append(c.cols, DeepCopy(col).(Sliceable))
+	}
+	return c
+}
+
+//// CLEAR
+///
+// clear zeroes the contents of buffers, big numbers and collections and
+// releases them, leaving the empty value of the type. elements of cleared
+// collections are dropped, not cleared, since they may be shared with
+// other collections. clear is bound to pointer receivers, to be able to
+// release the instance pointed to. values, that are not addressed, like
+// elements of slices, are cleared by Clear.
+func (v *BytesVal) Clear()   { clearSlice(v) }
+func (v *DataSlice) Clear()  { clearSlice(v) }
+func (v *PairVal) Clear()    { *v = PairVal{} }
+func (v *BigFltVal) Clear()  { clearBigFlt((*big.Float)(v)); *v = BigFltVal{} }
+func (v *BigIntVal) Clear()  { clearBigInt((*big.Int)(v)) }
+func (v *RatioVal) Clear()   { clearRat((*big.Rat)(v)) }
+func (v *DecimalVal) Clear() { clearBigInt(v.i); *v = DecimalVal{} }
+
+func (v *NilVec) Clear()    { clearSlice(v) }
+func (v *BoolVec) Clear()   { clearSlice(v) }
+func (v *IntVec) Clear()    { clearSlice(v) }
+func (v *Int8Vec) Clear()   { clearSlice(v) }
+func (v *Int16Vec) Clear()  { clearSlice(v) }
+func (v *Int32Vec) Clear()  { clearSlice(v) }
+func (v *UintVec) Clear()   { clearSlice(v) }
+func (v *Uint8Vec) Clear()  { clearSlice(v) }
+func (v *Uint16Vec) Clear() { clearSlice(v) }
+func (v *Uint32Vec) Clear() { clearSlice(v) }
+func (v *FltVec) Clear()    { clearSlice(v) }
+func (v *Flt32Vec) Clear()  { clearSlice(v) }
+func (v *ImagVec) Clear()   { clearSlice(v) }
+func (v *Imag64Vec) Clear() { clearSlice(v) }
+func (v *ByteVec) Clear()   { clearSlice(v) }
+func (v *RuneVec) Clear()   { clearSlice(v) }
+func (v *StrVec) Clear()    { clearSlice(v) }
+func (v *TimeVec) Clear()   { clearSlice(v) }
+func (v *DuraVec) Clear()   { clearSlice(v) }
+func (v *ErrorVec) Clear()  { clearSlice(v) }
+func (v *FlagSet) Clear()   { clearSlice(v) }
+func (v *BytesVec) Clear() {
+	for _, b := range *v {
+		(*BytesVal)(&b).Clear()
+	}
+	clearSlice(v)
+}
+func (v *BigIntVec) Clear() {
+	for _, i := range *v {
+		clearBigInt(i)
+	}
+	clearSlice(v)
+}
+func (v *BigFltVec) Clear() {
+	for _, f := range *v {
+		clearBigFlt(f)
+	}
+	clearSlice(v)
+}
+func (v *RatioVec) Clear() {
+	for _, r := range *v {
+		clearRat(r)
+	}
+	clearSlice(v)
+}
+
+func (s *MapString) Clear() { clearMap(s) }
+func (s *MapUint) Clear()   { clearMap(s) }
+func (s *MapInt) Clear()    { clearMap(s) }
+func (s *MapFloat) Clear()  { clearMap(s) }
+func (s *MapFlag) Clear()   { clearMap(s) }
+func (s *MapVal) Clear()    { clearMap(s) }
+func (s *MapHash) Clear()   { clearMap(s) }
+
+// persistent collections may share nodes with other versions, the
+// reference to them is released only.
+func (m *HashMap) Clear()    { *m = HashMap{} }
+func (m *OrderedMap) Clear() { *m = OrderedMap{} }
+func (s *SortedSet) Clear()  { *s = SortedSet{} }
+func (v *Vector) Clear()     { *v = Vector{} }
+func (t *Table) Clear()      { *t = Table{} }
+
+// clears the native and all natives contained in it, elements of slices,
+// fields of maps and members of pairs and persistent collections. natives,
+// that are not addressed, are cleared by a copy, that shares their
+// buffers, big numbers and maps with them. other copies of a cleared value
+// see its contents zeroed, but keep referencing its buffers.
+func Clear(nat Native) {
+	switch v := nat.(type) {
+	case nil:
+		return
+	case PairVal:
+		Clear(v.L)
+		Clear(v.R)
+	case *PairVal:
+		if v != nil {
+			Clear(v.L)
+			Clear(v.R)
+		}
+	case Mapped:
+		for _, field := range v.Fields() {
+			Clear(field.Left())
+			Clear(field.Right())
+		}
+	case DataSlice, Vector, SortedSet:
+		for _, elem := range v.(Sliced).Slice() {
+			Clear(elem)
+		}
+	case Table:
+		for _, col := range v.cols {
+			Clear(col)
+		}
+	}
+	clearNative(nat)
+}
+
+// clears the native, or a copy of it, if it is not addressed
+func clearNative(nat Native) {
+	if d, ok := nat.(Destructable); ok {
+		d.Clear()
+		return
+	}
+	var val = reflect.ValueOf(nat)
+	var ptr = reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	if d, ok := ptr.Interface().(Destructable); ok {
+		d.Clear()
+	}
+}
+
+// zeroes the elements of the slice pointed to and sets it to nil
+func clearSlice(ptr interface{}) {
+	var v = reflect.ValueOf(ptr).Elem()
+	var zero = reflect.Zero(v.Type().Elem())
+	for i := 0; i < v.Len(); i++ {
+		v.Index(i).Set(zero)
+	}
+	v.Set(reflect.Zero(v.Type()))
+}
+
+// deletes all keys of the map pointed to and sets it to nil
+func clearMap(ptr interface{}) {
+	var v = reflect.ValueOf(ptr).Elem()
+	for _, k := range v.MapKeys() {
+		v.SetMapIndex(k, reflect.Value{})
+	}
+	v.Set(reflect.Zero(v.Type()))
+}
+
+// zeroes the words of the integer and sets it to zero
+func clearBigInt(i *big.Int) {
+	if i == nil {
+		return
+	}
+	var words = i.Bits()
+	for n := range words {
+		words[n] = 0
+	}
+	i.SetBits(nil)
+}
+
+// big floats don't expose the words of their mantissa. their number is
+// taken from the length of the gob encoding, which holds ten bytes of
+// version, mode, precision and exponent ahead of the words. they are
+// overwritten by setting the float to a value, that occupies as many words,
+// with all bits but the highest and the lowest unset.
+func clearBigFlt(f *big.Float) {
+	if f == nil || f.IsInf() || f.Sign() == 0 {
+		return
+	}
+	var enc, _ = f.GobEncode()
+	var bits = uint(len(enc)-10) * 8
+	for i := range enc {
+		enc[i] = 0
+	}
+	var fill = new(big.Int).Lsh(big.NewInt(1), bits-1)
+	f.Set(new(big.Float).SetPrec(bits).SetInt(fill.SetBit(fill, 0, 1)))
+	f.SetInt64(0)
+}
+
+func clearRat(r *big.Rat) {
+	if r == nil {
+		return
+	}
+	clearBigInt(r.Num())
+	clearBigInt(r.Denom())
+	r.SetInt64(0)
+}
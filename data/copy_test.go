@@ -0,0 +1,138 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func newCopyTestTree() DataSlice {
+	var bi = BigIntVal(*big.NewInt(1 << 40))
+	return DataSlice{
+		BytesVal("abc"),
+		bi,
+		&bi,
+		RatioVal(*big.NewRat(1, 3)),
+		NewDecimal(125, 2),
+		IntVec{1, 2},
+		BytesVec{[]byte("x")},
+		BigIntVec{big.NewInt(7)},
+		NewPair(StrVal("k"), BytesVal("v")),
+		MapString{"a": BytesVal("map")},
+		MapVal{IntVal(1): DataSlice{BytesVal("nested")}},
+		NewHashMap(NewPair(StrVal("h"), BytesVal("hamt"))),
+		nil,
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	var orig = newCopyTestTree()
+	var cp = DeepCopy(orig).(DataSlice)
+	if !DeepEqual(orig, cp) {
+		t.Log("copy differs from original", orig, cp)
+		t.FailNow()
+	}
+	// mutate every buffer of the copy
+	cp[0].(BytesVal)[0] = 'X'
+	var bi = cp[1].(BigIntVal)
+	(&bi).GoBigInt().SetInt64(5)
+	cp[2].(*BigIntVal).GoBigInt().SetInt64(5)
+	var r = cp[3].(RatioVal)
+	r.GoRat().SetInt64(5)
+	cp[5].(IntVec)[0] = 0
+	cp[6].(BytesVec)[0][0] = 'Y'
+	cp[7].(BigIntVec)[0].SetInt64(5)
+	cp[8].(PairVal).R.(BytesVal)[0] = 'V'
+	cp[9].(MapString)["a"].(BytesVal)[0] = 'M'
+	cp[10].(MapVal)[IntVal(1)].(DataSlice)[0].(BytesVal)[0] = 'N'
+	var h, _ = cp[11].(Mapped).Get(StrVal("h"))
+	h.(BytesVal)[0] = 'H'
+	fmt.Println(orig[:len(orig)-1])
+	fmt.Println(cp[:len(cp)-1])
+	if !DeepEqual(orig, newCopyTestTree()) {
+		t.Log("mutating the copy changed the original", orig)
+		t.Fail()
+	}
+	if cp[2].(*BigIntVal) == orig[2].(*BigIntVal) {
+		t.Log("pointers to big numbers should be copied")
+		t.Fail()
+	}
+}
+
+func TestClear(t *testing.T) {
+	var buf = BytesVal("secret")
+	var alias = buf
+	buf.Clear()
+	if buf != nil || string(alias) != "\x00\x00\x00\x00\x00\x00" {
+		t.Log("clearing bytes should zero and release the buffer", buf, alias)
+		t.Fail()
+	}
+	var bi = BigIntVal(*new(big.Int).Lsh(big.NewInt(1), 100))
+	var words = bi.GoBigInt().Bits()
+	bi.Clear()
+	if bi.GoBigInt().Sign() != 0 || words[len(words)-1] != 0 {
+		t.Log("clearing big int should zero its words", bi.String(), words)
+		t.Fail()
+	}
+	var vec = IntVec{1, 2, 3}
+	var view = vec[:]
+	vec.Clear()
+	if vec != nil || view[0] != 0 {
+		t.Log("clearing vector should zero and release elements", vec, view)
+		t.Fail()
+	}
+	var m = MapString{"a": IntVal(1)}
+	var other = m
+	m.Clear()
+	if m != nil || len(other) != 0 {
+		t.Log("clearing map should delete all fields", m, other)
+		t.Fail()
+	}
+	var d = NewDecimal(12345, 2)
+	d.Clear()
+	if d.Sign() != 0 {
+		t.Log("clearing decimal should yield zero", d)
+		t.Fail()
+	}
+	var pi = BigFltVal(*new(big.Float).SetPrec(200).SetFloat64(math.Pi))
+	var shared = pi
+	pi.Clear()
+	if pi.GoBigFlt().Sign() != 0 || shared.GoBigFlt().Cmp(big.NewFloat(math.Pi)) == 0 {
+		t.Log("clearing big float should overwrite its mantissa", pi.String(), shared.String())
+		t.Fail()
+	}
+	var nats = []Destructable{
+		&DataSlice{IntVal(1)}, &BytesVec{[]byte("x")}, &RatioVec{big.NewRat(1, 2)},
+		&MapVal{IntVal(1): IntVal(2)}, &PairVal{IntVal(1), IntVal(2)},
+	}
+	for _, nat := range nats {
+		nat.Clear()
+		if n, ok := nat.(interface{ Len() int }); ok && n.Len() != 0 {
+			t.Log("expected cleared instance to be empty", nat)
+			t.Fail()
+		}
+	}
+}
+
+func TestClearTree(t *testing.T) {
+	var buf = BytesVal("secret")
+	var bi = BigIntVal(*big.NewInt(42))
+	var words = bi.GoBigInt().Bits()
+	var vec = IntVec{1, 2}
+	var tree = DataSlice{buf, NewPair(StrVal("big"), bi),
+		MapString{"vec": vec}, NewVector(BytesVal("key"))}
+	Clear(tree)
+	fmt.Println(buf, words, vec)
+	if string(buf) != "\x00\x00\x00\x00\x00\x00" || words[0] != 0 || vec[1] != 0 {
+		t.Log("expected contents of tree to be zeroed", buf, words, vec)
+		t.Fail()
+	}
+	var slice = DataSlice{BytesVal("x"), RatioVal(*big.NewRat(3, 7))}
+	var num = slice[1].(RatioVal).GoRat().Num().Bits()
+	SliceClear(slice)
+	if slice[0].(BytesVal)[0] != 0 || num[0] != 0 {
+		t.Log("expected elements of slice to be cleared", slice, num)
+		t.Fail()
+	}
+}
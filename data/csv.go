@@ -0,0 +1,328 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//// CSV IMPORT
+///
+// reads comma separated values and infers the type of every column from a
+// sample of its cells. the first type of int, float, bool, duration, time
+// and string, all non empty sample cells of a column parse as, is the
+// columns type. types of columns can be set explicitly by name.
+type CSVReader struct {
+	Header  bool             // first record names the columns
+	Sample  int              // number of rows to infer types from, all if < 1
+	Types   map[string]TyNat // explicit column types by name
+	Layouts []string         // time layouts tried in order
+	r       *csv.Reader
+}
+
+// error of a cell, that could not be parsed as the type of its column. row
+// and column are zero based indices of the cell in the data, not counting
+// the header.
+type CellError struct {
+	Row, Col int
+	Value    string
+	Type     TyNat
+	Err      error
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("row %d, column %d: can not parse %q as %s: %s",
+		e.Row, e.Col, e.Value, e.Type.TypeName(), e.Err)
+}
+
+func (e CellError) Unwrap() error { return e.Err }
+
+var csvInferTypes = []TyNat{Int, Float, Bool, Duration, Time}
+
+func NewCSVReader(r io.Reader) *CSVReader {
+	var cr = csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	return &CSVReader{
+		Header: true,
+		Sample: 100,
+		Types:  map[string]TyNat{},
+		Layouts: []string{
+			time.RFC3339Nano,
+			"2006-01-02 15:04:05",
+			"2006-01-02",
+			timeStringLayout,
+		},
+		r: cr,
+	}
+}
+
+// sets the field delimiter, defaults to comma
+func (c *CSVReader) Comma(r rune) *CSVReader {
+	c.r.Comma = r
+	return c
+}
+
+// reads all records, returns the column names, types and the records
+// without header.
+func (c *CSVReader) read() ([]string, []TyNat, [][]string, error) {
+	var records, err = c.r.ReadAll()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var width int
+	for _, rec := range records {
+		if len(rec) > width {
+			width = len(rec)
+		}
+	}
+	var names = make([]string, width)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	if c.Header && len(records) > 0 {
+		copy(names, records[0])
+		records = records[1:]
+	}
+	var types = make([]TyNat, width)
+	for i, name := range names {
+		if t, ok := c.Types[name]; ok {
+			types[i] = t
+			continue
+		}
+		types[i] = c.infer(records, i)
+	}
+	return names, types, records, nil
+}
+
+func (c *CSVReader) infer(records [][]string, col int) TyNat {
+	var sample = records
+	if c.Sample > 0 && len(sample) > c.Sample {
+		sample = sample[:c.Sample]
+	}
+	for _, typ := range csvInferTypes {
+		var match, empty = true, true
+		for _, rec := range sample {
+			if col >= len(rec) || strings.TrimSpace(rec[col]) == "" {
+				continue
+			}
+			empty = false
+			if _, ok := c.parse(rec[col], typ).(ErrorVal); ok {
+				match = false
+				break
+			}
+		}
+		if match && !empty {
+			return typ
+		}
+	}
+	return String
+}
+
+// numbers are parsed in base ten, so that zero padded cells keep their value
+// and underscores are not taken as digit separators.
+func (c *CSVReader) parse(cell string, typ TyNat) Native {
+	var str = StrVal(strings.TrimSpace(cell))
+	switch typ {
+	case String:
+		return StrVal(cell)
+	case Int:
+		var i, err = str.ReadInt()
+		if err != nil {
+			return ErrorVal{err}
+		}
+		return IntVal(i)
+	case Uint:
+		var u, err = str.ReadUint()
+		if err != nil {
+			return ErrorVal{err}
+		}
+		return UintVal(u)
+	case Float:
+		// strconv accepts hexadecimal floats and underscores between digits
+		var f, err = str.ReadFloat()
+		if err == nil && strings.ContainsAny(string(str), "xX_") {
+			err = fmt.Errorf("can not parse %q as float in base ten", str)
+		}
+		if err != nil {
+			return ErrorVal{err}
+		}
+		return FltVal(f)
+	case Bool:
+		var b, err = str.ReadBool()
+		if err != nil {
+			return ErrorVal{err}
+		}
+		return BoolVal(b)
+	case Duration:
+		var d, err = str.ReadDuration()
+		if err != nil {
+			return ErrorVal{err}
+		}
+		return DuraVal(d)
+	case Time:
+		var err error
+		for _, layout := range c.Layouts {
+			var t time.Time
+			if t, err = str.ReadTime(layout); err == nil {
+				return TimeVal(t)
+			}
+		}
+		return ErrorVal{err}
+	}
+	return fromString(str, typ)
+}
+
+// yields the parsed cell, nil for empty cells, or an error value containing
+// a cell error.
+func (c *CSVReader) cell(rec []string, row, col int, typ TyNat) Native {
+	if col >= len(rec) || (typ != String && strings.TrimSpace(rec[col]) == "") {
+		return nil
+	}
+	var nat = c.parse(rec[col], typ)
+	if e, ok := nat.(ErrorVal); ok {
+		return ErrorVal{CellError{row, col, rec[col], typ, e.E}}
+	}
+	return nat
+}
+
+// returns the column names and a slice of rows, every row is a data slice
+// of the parsed cells. empty cells are nil values, invalid cells error
+// values containing a cell error.
+func (c *CSVReader) ReadRows() ([]string, DataSlice, error) {
+	var names, types, records, err = c.read()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows = make(DataSlice, 0, len(records))
+	for r, rec := range records {
+		var row = make(DataSlice, len(types))
+		for i, typ := range types {
+			if row[i] = c.cell(rec, r, i, typ); row[i] == nil {
+				row[i] = NilVal{}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return names, rows, nil
+}
+
+// returns a column of unboxed vectors per csv column. empty and invalid
+// cells are set to the zero value of the column type, errors of invalid
+// cells are returned as vector of cell errors.
+func (c *CSVReader) ReadColumns() ([]Column, ErrorVec, error) {
+	var names, types, records, err = c.read()
+	if err != nil {
+		return nil, nil, err
+	}
+	var errs = ErrorVec{}
+	var cols = make([]Column, 0, len(types))
+	for i, typ := range types {
+		var elems = make([]Native, 0, len(records))
+		for r, rec := range records {
+			var nat = c.cell(rec, r, i, typ)
+			if e, ok := nat.(ErrorVal); ok {
+				errs = append(errs, e.E)
+				nat = nil
+			}
+			if nat == nil {
+				nat = zeroOf(typ)
+			}
+			elems = append(elems, nat)
+		}
+		var vec, err = newColumnVec(typ, elems...)
+		if err != nil {
+			return nil, nil, err
+		}
+		cols = append(cols, Column{names[i], vec})
+	}
+	return cols, errs, nil
+}
+
+// like read columns, yields a table
+func (c *CSVReader) ReadTable() (Table, ErrorVec, error) {
+	var cols, errs, err = c.ReadColumns()
+	if err != nil {
+		return Table{}, nil, err
+	}
+	tab, err := NewTable(cols...)
+	return tab, errs, err
+}
+
+//// CSV EXPORT
+///
+// writes natives as comma separated values. times are formatted as RFC3339,
+// nil values as empty cells and cell errors by the value that failed to
+// parse, so that tables read from csv are written back unchanged.
+type CSVWriter struct {
+	Header bool
+	w      *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{Header: true, w: csv.NewWriter(w)}
+}
+
+// sets the field delimiter, defaults to comma
+func (c *CSVWriter) Comma(r rune) *CSVWriter {
+	c.w.Comma = r
+	return c
+}
+
+func formatCell(nat Native) string {
+	switch v := nat.(type) {
+	case nil, NilVal:
+		return ""
+	case TimeVal:
+		return time.Time(v).Format(time.RFC3339Nano)
+	case FltVal:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64)
+	case Flt32Val:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case ErrorVal:
+		if e, ok := v.E.(CellError); ok {
+			return e.Value
+		}
+	}
+	return stringOf(nat)
+}
+
+// writes a row for each sliced element of rows, preceded by the names
+// passed, if the writer writes a header.
+func (c *CSVWriter) WriteRows(names []string, rows DataSlice) error {
+	if c.Header && names != nil {
+		if err := c.w.Write(names); err != nil {
+			return err
+		}
+	}
+	for i, row := range rows {
+		var s, ok = row.(Sliced)
+		if !ok {
+			return fmt.Errorf("row %d of type %s is not sliceable",
+				i, row.Type().TypeName())
+		}
+		var rec = make([]string, 0, len(s.Slice()))
+		for _, cell := range s.Slice() {
+			rec = append(rec, formatCell(cell))
+		}
+		if err := c.w.Write(rec); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *CSVWriter) WriteColumns(cols ...Column) error {
+	var tab, err = NewTable(cols...)
+	if err != nil {
+		return err
+	}
+	return c.WriteTable(tab)
+}
+
+func (c *CSVWriter) WriteTable(t Table) error {
+	return c.WriteRows(t.Names(), DataSlice(t.Slice()))
+}
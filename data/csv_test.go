@@ -0,0 +1,136 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var csvTestData = `name,age,score,member,wait,joined
+ada,36,1.5,true,1h30m,2019-03-01
+bob,25,2,false,10s,2020-01-02T15:04:05Z
+cy,x41,3.25,true,,2018-07-07
+`
+
+func TestCSVReadRows(t *testing.T) {
+	var names, rows, err = NewCSVReader(strings.NewReader(csvTestData)).ReadRows()
+	fmt.Println(names, rows)
+	if err != nil || len(names) != 6 || len(rows) != 3 {
+		t.Log("unexpected result", names, rows, err)
+		t.Fail()
+		return
+	}
+	var first = rows[0].(DataSlice)
+	var types = []TyNat{String, String, Float, Bool, Duration, Time}
+	for i, typ := range types {
+		if first[i].Type() != typ {
+			t.Log("column", names[i], "inferred as", first[i].Type(), "expected", typ)
+			t.Fail()
+		}
+	}
+	if rows[2].(DataSlice)[4].Type() != Nil {
+		t.Log("empty cell should be nil", rows[2])
+		t.Fail()
+	}
+}
+
+func TestCSVReadColumns(t *testing.T) {
+	var r = NewCSVReader(strings.NewReader(csvTestData))
+	r.Sample = 2
+	var cols, errs, err = r.ReadColumns()
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	fmt.Println(cols, errs)
+	if !DeepEqual(cols[1].Vec, IntVec{36, 25, 0}) {
+		t.Log("expected sampled int column with zero for invalid cell", cols[1].Vec)
+		t.Fail()
+	}
+	if len(errs) != 1 {
+		t.Log("expected a single cell error", errs)
+		t.Fail()
+		return
+	}
+	if e, ok := errs[0].(CellError); !ok || e.Row != 2 || e.Col != 1 || e.Value != "x41" {
+		t.Log("unexpected cell error", errs[0])
+		t.Fail()
+	}
+	r = NewCSVReader(strings.NewReader(csvTestData))
+	r.Types["score"] = String
+	cols, _, _ = r.ReadColumns()
+	if cols[2].Vec.TypeElem() != String {
+		t.Log("explicit type should override inference", cols[2].Vec)
+		t.Fail()
+	}
+}
+
+func TestCSVBaseTen(t *testing.T) {
+	var r = NewCSVReader(strings.NewReader("zip,mode,id,amount\n010,0755,0x10,1_000.5\n02134,0644,7,2.5\n"))
+	var names, rows, err = r.ReadRows()
+	fmt.Println(names, rows)
+	if err != nil || len(rows) != 2 {
+		t.Log("unexpected result", rows, err)
+		t.Fail()
+		return
+	}
+	var first = rows[0].(DataSlice)
+	if first[0] != IntVal(10) || first[1] != IntVal(755) ||
+		rows[1].(DataSlice)[0] != IntVal(2134) {
+		t.Log("zero padded cells should be read in base ten", rows)
+		t.Fail()
+	}
+	if first[2] != StrVal("0x10") || first[3] != StrVal("1_000.5") {
+		t.Log("prefixed and underscored cells should not be read as numbers", rows)
+		t.Fail()
+	}
+	r = NewCSVReader(strings.NewReader("n\n1_000\n"))
+	r.Types["n"] = Int
+	if _, rows, _ = r.ReadRows(); rows[0].(DataSlice)[0].Type() != Error {
+		t.Log("underscored cell should not parse as int", rows)
+		t.Fail()
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	var r = NewCSVReader(strings.NewReader(csvTestData))
+	r.Sample = 2
+	var names, rows, err = r.ReadRows()
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	if e, ok := rows[2].(DataSlice)[1].(ErrorVal); !ok {
+		t.Log("invalid cell should be an error value", rows[2])
+		t.Fail()
+	} else {
+		fmt.Println(e)
+	}
+	var buf = &bytes.Buffer{}
+	if err := NewCSVWriter(buf).WriteRows(names, rows); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	fmt.Println(buf.String())
+	var expect = strings.Replace(csvTestData, "2019-03-01", "2019-03-01T00:00:00Z", 1)
+	expect = strings.Replace(expect, "1h30m", "1h30m0s", 1)
+	expect = strings.Replace(expect, "2018-07-07", "2018-07-07T00:00:00Z", 1)
+	if buf.String() != expect {
+		t.Log("round trip changed data", buf.String())
+		t.Fail()
+	}
+	var tab, errs, _ = NewCSVReader(strings.NewReader(buf.String())).ReadTable()
+	buf.Reset()
+	if err := NewCSVWriter(buf).WriteTable(tab); err != nil || len(errs) != 0 {
+		t.Log(err, errs)
+		t.Fail()
+	}
+	fmt.Println(buf.String())
+	if !strings.HasPrefix(buf.String(), "name,age,score,member,wait,joined\nada,36,1.5,true,") {
+		t.Log("unexpected table output", buf.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,283 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//// DECIMAL
+///
+// fixed point decimal number, the value is the unscaled integer divided by
+// ten to the power of the scale. decimals are immutable, operations return
+// new values and never modify the unscaled integer of their operands.
+type DecimalVal struct {
+	i     *big.Int // unscaled value, nil is zero
+	scale int
+}
+
+// rounding mode applied, when a decimal looses fractional digits
+type Rounding uint8
+
+const (
+	RoundHalfEven Rounding = iota // ties to the even neighbour
+	RoundHalfUp                   // ties away from zero
+	RoundDown                     // truncates towards zero
+)
+
+func (r Rounding) String() string {
+	switch r {
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundDown:
+		return "Down"
+	}
+	return "Rounding(" + strconv.Itoa(int(r)) + ")"
+}
+
+// scale and rounding of quotients, and of decimals converted from ratios
+// that have no finite decimal representation.
+var (
+	DecimalScale    = 16
+	DecimalRounding = RoundHalfEven
+)
+
+// largest scale and exponent of parsed decimals, and largest number of
+// digits of powers. larger numbers take unreasonable time and memory.
+const MaxDecimalDigits = 1 << 16
+
+var bigOne, bigTen = big.NewInt(1), big.NewInt(10)
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// returns unscaled divided by ten to the power of scale
+func NewDecimal(unscaled int64, scale int) DecimalVal {
+	return NewBigDecimal(big.NewInt(unscaled), scale)
+}
+
+// like new decimal, the integer passed is copied. negative scales multiply
+// the unscaled value, the scale of the result is zero.
+func NewBigDecimal(unscaled *big.Int, scale int) DecimalVal {
+	var i = new(big.Int).Set(unscaled)
+	if scale < 0 {
+		i.Mul(i, pow10(-scale))
+		scale = 0
+	}
+	return DecimalVal{i, scale}
+}
+
+// parses decimal notation with optional sign, fraction and exponent. the
+// scale of the result is the number of fractional digits, reduced by the
+// exponent.
+func ParseDecimal(s string) (DecimalVal, error) {
+	var str, exp = strings.TrimSpace(s), 0
+	if e := strings.IndexAny(str, "eE"); e >= 0 {
+		var x, err = strconv.Atoi(str[e+1:])
+		if err != nil || x > MaxDecimalDigits || x < -MaxDecimalDigits {
+			return DecimalVal{}, fmt.Errorf("can not parse %q as decimal", s)
+		}
+		str, exp = str[:e], x
+	}
+	var scale int
+	if p := strings.IndexByte(str, '.'); p >= 0 {
+		scale = len(str) - p - 1
+		str = str[:p] + str[p+1:]
+	}
+	// big ints would also accept base prefixes and underscores
+	var digits = strings.TrimLeft(str, "+-")
+	if len(str)-len(digits) > 1 || digits == "" ||
+		strings.Trim(digits, "0123456789") != "" {
+		return DecimalVal{}, fmt.Errorf("can not parse %q as decimal", s)
+	}
+	// the bound applies to the scale of the result, which is what
+	// unmarshaling accepts
+	if scale-exp > MaxDecimalDigits {
+		return DecimalVal{}, fmt.Errorf("scale of %q exceeds %d", s, MaxDecimalDigits)
+	}
+	var i, _ = new(big.Int).SetString(str, 10)
+	return NewBigDecimal(i, scale-exp), nil
+}
+
+// converts the ratio exactly, if its denominator has no prime factors other
+// than two and five, otherwise the result is rounded to the decimal scale.
+func decimalOfRat(r *big.Rat) DecimalVal {
+	var den, rem = new(big.Int).Set(r.Denom()), new(big.Int)
+	var twos, fives int
+	for den.Bit(0) == 0 {
+		den.Rsh(den, 1)
+		twos++
+	}
+	for five := big.NewInt(5); ; fives++ {
+		var q, _ = new(big.Int).QuoRem(den, five, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		den = q
+	}
+	if den.Cmp(bigOne) == 0 {
+		var scale = twos
+		if fives > scale {
+			scale = fives
+		}
+		var num = new(big.Int).Mul(r.Num(), pow10(scale))
+		return DecimalVal{num.Quo(num, r.Denom()), scale}
+	}
+	var num = new(big.Int).Mul(r.Num(), pow10(DecimalScale))
+	return DecimalVal{roundQuo(num, r.Denom(), DecimalRounding), DecimalScale}
+}
+
+// divides and rounds the quotient according to the rounding mode passed
+func roundQuo(x, y *big.Int, mode Rounding) *big.Int {
+	var q, r = new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 || mode == RoundDown {
+		return q
+	}
+	// twice the remainder compared to the divisor tells, if it's a tie
+	var c = new(big.Int).Abs(r)
+	var cmp = c.Lsh(c, 1).Cmp(new(big.Int).Abs(y))
+	if cmp > 0 || cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1) {
+		if x.Sign() != y.Sign() {
+			return q.Sub(q, bigOne)
+		}
+		return q.Add(q, bigOne)
+	}
+	return q
+}
+
+func (v DecimalVal) unscaled() *big.Int {
+	if v.i == nil {
+		return new(big.Int)
+	}
+	return v.i
+}
+
+// returns a copy of the unscaled integer
+func (v DecimalVal) Unscaled() *big.Int { return new(big.Int).Set(v.unscaled()) }
+func (v DecimalVal) Scale() int         { return v.scale }
+func (v DecimalVal) Sign() int          { return v.unscaled().Sign() }
+
+// returns the decimal with the scale passed, rounded by the mode passed,
+// when the scale decreases.
+func (v DecimalVal) Rescale(scale int, mode Rounding) DecimalVal {
+	if scale < 0 {
+		scale = 0
+	}
+	switch {
+	case scale == v.scale:
+		return v
+	case scale > v.scale:
+		return DecimalVal{
+			new(big.Int).Mul(v.unscaled(), pow10(scale-v.scale)), scale}
+	}
+	return DecimalVal{roundQuo(v.unscaled(), pow10(v.scale-scale), mode), scale}
+}
+
+// strips trailing zeros of the fraction
+func (v DecimalVal) Normalize() DecimalVal {
+	var i, scale = v.unscaled(), v.scale
+	for rem := new(big.Int); scale > 0; scale-- {
+		var q, _ = new(big.Int).QuoRem(i, bigTen, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		i = q
+	}
+	return DecimalVal{i, scale}
+}
+
+// returns the unscaled values of both decimals at their common scale
+func (v DecimalVal) align(arg DecimalVal) (x, y *big.Int, scale int) {
+	if v.scale < arg.scale {
+		v = v.Rescale(arg.scale, RoundDown)
+	} else {
+		arg = arg.Rescale(v.scale, RoundDown)
+	}
+	return v.unscaled(), arg.unscaled(), v.scale
+}
+
+// sum, difference and remainder have the greater scale of both operands, the
+// product has the sum of both scales.
+func (v DecimalVal) Negate() DecimalVal {
+	return DecimalVal{new(big.Int).Neg(v.unscaled()), v.scale}
+}
+func (v DecimalVal) Add(arg DecimalVal) DecimalVal {
+	var x, y, scale = v.align(arg)
+	return DecimalVal{new(big.Int).Add(x, y), scale}
+}
+func (v DecimalVal) Substract(arg DecimalVal) DecimalVal {
+	var x, y, scale = v.align(arg)
+	return DecimalVal{new(big.Int).Sub(x, y), scale}
+}
+func (v DecimalVal) Multipy(arg DecimalVal) DecimalVal {
+	return DecimalVal{
+		new(big.Int).Mul(v.unscaled(), arg.unscaled()), v.scale + arg.scale}
+}
+func (v DecimalVal) Modulo(arg DecimalVal) (DecimalVal, error) {
+	if arg.Sign() == 0 {
+		return DecimalVal{}, fmt.Errorf("division by zero: %s %% %s", v, arg)
+	}
+	var x, y, scale = v.align(arg)
+	return DecimalVal{new(big.Int).Rem(x, y), scale}, nil
+}
+
+// returns the quotient with the scale passed, rounded by the mode passed
+func (v DecimalVal) Quotient(arg DecimalVal, scale int, mode Rounding) (DecimalVal, error) {
+	if arg.Sign() == 0 {
+		return DecimalVal{}, fmt.Errorf("division by zero: %s / %s", v, arg)
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	var num, den = new(big.Int).Set(v.unscaled()), new(big.Int).Set(arg.unscaled())
+	if exp := scale + arg.scale - v.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return DecimalVal{roundQuo(num, den, mode), scale}, nil
+}
+
+// raises the decimal to a non negative integer power. powers with more
+// than MaxDecimalDigits digits, or fractional digits yield an error.
+func (v DecimalVal) Power(exp uint) (DecimalVal, error) {
+	var i = v.unscaled()
+	if exp > 1 && (i.CmpAbs(bigOne) > 0 || v.scale > 0) {
+		// bits times log10(2) estimates the number of digits
+		if exp > MaxDecimalDigits || uint(v.scale)*exp > MaxDecimalDigits ||
+			uint(i.BitLen())*exp*3/10 > MaxDecimalDigits {
+			return DecimalVal{}, fmt.Errorf(
+				"%s ^ %d exceeds %d digits", v, exp, MaxDecimalDigits)
+		}
+	}
+	var e = new(big.Int).SetUint64(uint64(exp))
+	return DecimalVal{new(big.Int).Exp(i, e, nil), v.scale * int(exp)}, nil
+}
+
+// compares by value, regardless of scale
+func (v DecimalVal) Cmp(arg DecimalVal) int {
+	var x, y, _ = v.align(arg)
+	return x.Cmp(y)
+}
+
+// conversions to integers truncate the fraction
+func (v DecimalVal) GoRat() *big.Rat {
+	return new(big.Rat).SetFrac(v.unscaled(), pow10(v.scale))
+}
+func (v DecimalVal) GoBigInt() *big.Int { return v.Rescale(0, RoundDown).Unscaled() }
+func (v DecimalVal) GoInt() int         { return int(v.GoBigInt().Int64()) }
+func (v DecimalVal) GoUint() uint       { return uint(v.GoInt()) }
+func (v DecimalVal) GoFlt() float64     { var f, _ = v.GoRat().Float64(); return f }
+func (v DecimalVal) GoImag() complex128 { return complex(v.GoFlt(), 0) }
+func (v DecimalVal) Idx() int           { return v.GoInt() }
+func (v DecimalVal) Int() IntVal        { return IntVal(v.GoInt()) }
+func (v DecimalVal) Uint() UintVal      { return UintVal(v.GoUint()) }
+func (v DecimalVal) Float() FltVal      { return FltVal(v.GoFlt()) }
+func (v DecimalVal) Imag() ImagVal      { return ImagVal(v.GoImag()) }
+func (v DecimalVal) BigInt() *BigIntVal { return (*BigIntVal)(v.GoBigInt()) }
+func (v DecimalVal) Ratio() *RatioVal   { return (*RatioVal)(v.GoRat()) }
+func (v DecimalVal) Bool() BoolVal      { return BoolVal(v.Sign() != 0) }
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestDecimalParseString(t *testing.T) {
+	var cases = map[string]string{
+		"12.340":  "12.340",
+		"-0.05":   "-0.05",
+		".5":      "0.5",
+		"+7":      "7",
+		"1.5e3":   "1500",
+		"25e-4":   "0.0025",
+		"-1e-1":   "-0.1",
+		" 42.00 ": "42.00",
+	}
+	for in, out := range cases {
+		var d, err = ParseDecimal(in)
+		fmt.Println(in, d)
+		if err != nil || d.String() != out {
+			t.Log("parsing", in, "expected", out, "got", d, err)
+			t.Fail()
+		}
+	}
+	for _, in := range []string{"", "-", "1.2.3", "0x10", "1_000", "--1", "1e", "1e999999999", "1e-70000", "1.5e-65536"} {
+		if d, err := ParseDecimal(in); err == nil {
+			t.Log("expected error parsing", in, "got", d)
+			t.Fail()
+		}
+	}
+	if StrVal("3.14").ReadDecimalVal().Type() != Decimal ||
+		StrVal("pi").ReadDecimalVal().Type() != Nil {
+		t.Log("unexpected result reading decimals from strings")
+		t.Fail()
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	var cases = []struct {
+		in   string
+		mode Rounding
+		out  string
+	}{
+		{"2.5", RoundHalfEven, "2"},
+		{"3.5", RoundHalfEven, "4"},
+		{"-2.5", RoundHalfEven, "-2"},
+		{"2.5", RoundHalfUp, "3"},
+		{"-2.5", RoundHalfUp, "-3"},
+		{"2.51", RoundHalfEven, "3"},
+		{"2.9", RoundDown, "2"},
+		{"-2.9", RoundDown, "-2"},
+	}
+	for _, c := range cases {
+		var d, _ = ParseDecimal(c.in)
+		if r := d.Rescale(0, c.mode); r.String() != c.out {
+			t.Log("rounding", c.in, c.mode, "expected", c.out, "got", r)
+			t.Fail()
+		}
+	}
+	if s := NewDecimal(5, 1).Rescale(3, RoundDown).String(); s != "0.500" {
+		t.Log("rescaling to a greater scale should pad zeros", s)
+		t.Fail()
+	}
+	var q, err = NewDecimal(2, 0).Quotient(NewDecimal(3, 0), 4, RoundHalfUp)
+	if err != nil || q.String() != "0.6667" {
+		t.Log("unexpected quotient", q, err)
+		t.Fail()
+	}
+	if _, err := q.Quotient(DecimalVal{}, 2, RoundDown); err == nil {
+		t.Log("division by zero should fail")
+		t.Fail()
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	var a, b = NewDecimal(1050, 2), NewDecimal(-25, 1)
+	if s := a.Add(b).String(); s != "8.00" {
+		t.Log("unexpected sum", s)
+		t.Fail()
+	}
+	if s := a.Substract(b).String(); s != "13.00" {
+		t.Log("unexpected difference", s)
+		t.Fail()
+	}
+	if s := a.Multipy(b).String(); s != "-26.250" {
+		t.Log("unexpected product", s)
+		t.Fail()
+	}
+	if a.Cmp(NewDecimal(105, 1)) != 0 || b.Cmp(a) != -1 {
+		t.Log("decimals should compare by value")
+		t.Fail()
+	}
+	var cases = []struct {
+		op   OpStr
+		a, b Native
+		res  string
+	}{
+		{Add, NewDecimal(15, 1), IntVal(2), "3.5"},
+		{Multiply, Uint8Val(3), NewDecimal(-5, 2), "-0.15"},
+		{Quotient, NewDecimal(1, 0), NewDecimal(8, 0), "0.1250000000000000"},
+		{QuoModul, NewDecimal(75, 1), NewDecimal(2, 0), "1.5"},
+		{Power, NewDecimal(15, 1), IntVal(2), "2.25"},
+		{Power, NewDecimal(2, 0), IntVal(-2), "1/4"},
+		{Greater, NewDecimal(101, 2), IntVal(1), "true"},
+		{Add, NewDecimal(1, 1), RatioVal(*big.NewRat(1, 3)), "13/30"},
+	}
+	for _, c := range cases {
+		var res, err = Eval(c.op, c.a, c.b)
+		fmt.Println(c.a, c.op, c.b, "=", res)
+		if err != nil || res.String() != c.res {
+			t.Log("expected", c.res, "got", res, err)
+			t.Fail()
+		}
+	}
+	if _, err := Eval(Quotient, NewDecimal(1, 0), DecimalVal{}); err == nil {
+		t.Log("division by zero should fail")
+		t.Fail()
+	}
+	for _, exp := range []Native{IntVal(1 << 40), IntVal(-1 << 40), BigIntVal(*new(big.Int).Lsh(bigOne, 70))} {
+		if res, err := Eval(Power, NewDecimal(11, 1), exp); err == nil {
+			t.Log("power exceeding the digit limit should fail", res)
+			t.Fail()
+		}
+	}
+	if p, err := NewDecimal(1, 0).Power(1 << 40); err != nil || p.String() != "1" {
+		t.Log("powers of one should not be limited", p, err)
+		t.Fail()
+	}
+}
+
+func TestDecimalConversion(t *testing.T) {
+	var cases = []struct {
+		from  Native
+		res   string
+		lossy bool
+	}{
+		{IntVal(-12), "-12", false},
+		{FltVal(0.1), "0.1", false},
+		{Flt32Val(0.1), "0.1", false},
+		{RatioVal(*big.NewRat(3, 8)), "0.375", false},
+		{RatioVal(*big.NewRat(1, 3)), "0.3333333333333333", true},
+		{StrVal("1.250"), "1.250", false},
+		{ImagVal(complex(2.5, 1)), "2.5", true},
+	}
+	for _, c := range cases {
+		var res, err = Convert(c.from, Decimal)
+		fmt.Println(c.from, "→", res, err)
+		if res == nil || res.String() != c.res || (err != nil) != c.lossy {
+			t.Log("converting", c.from, "expected", c.res, "got", res, err)
+			t.Fail()
+		}
+	}
+	if _, err := Convert(FltVal(1e300), Decimal); err != nil {
+		t.Log("large floats should convert without loss", err)
+		t.Fail()
+	}
+	var d = NewDecimal(-2575, 2)
+	if i, _ := Convert(d, Int); i != IntVal(-25) {
+		t.Log("conversion to integers should truncate", i)
+		t.Fail()
+	}
+	if f, err := Convert(d, Float); err != nil || f != FltVal(-25.75) {
+		t.Log("unexpected float", f, err)
+		t.Fail()
+	}
+	if p := Promote(Int16Val(7), Decimal); p.String() != "7" {
+		t.Log("unexpected promotion", p)
+		t.Fail()
+	}
+	if !DeepEqual(NewDecimal(10, 1), NewDecimal(100, 2)) ||
+		Hash(NewDecimal(10, 1)) != Hash(NewDecimal(1, 0)) {
+		t.Log("decimals of equal value should be equal and hash alike")
+		t.Fail()
+	}
+	if Compare(NewDecimal(15, 1), IntVal(2)) != -1 {
+		t.Log("decimals should compare to other numbers by value")
+		t.Fail()
+	}
+}
+
+func TestDecimalMarshal(t *testing.T) {
+	var d = NewDecimal(-123456789, 4)
+	var buf, err = d.MarshalBinary()
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	var nat Native
+	if nat, err = UnmarshalNative(Decimal, buf); err != nil ||
+		nat.(DecimalVal).String() != d.String() {
+		t.Log("binary round trip failed", nat, err)
+		t.Fail()
+	}
+	js, err := json.Marshal(d)
+	fmt.Println(string(js))
+	if err != nil || string(js) != "-12345.6789" {
+		t.Log("unexpected json", string(js), err)
+		t.Fail()
+	}
+	// parsing accepts scales up to the bound unmarshaling accepts
+	var small, _ = ParseDecimal("1e-65536")
+	buf, err = small.MarshalBinary()
+	if nat, err = UnmarshalNative(Decimal, buf); err != nil ||
+		nat.(DecimalVal).Scale() != MaxDecimalDigits {
+		t.Log("expected largest parsed scale to round trip", err)
+		t.Fail()
+	}
+	var back DecimalVal
+	if err := json.Unmarshal([]byte(`"0.10"`), &back); err != nil ||
+		back.String() != "0.10" {
+		t.Log("json decoding failed", back, err)
+		t.Fail()
+	}
+}
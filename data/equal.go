@@ -0,0 +1,248 @@
+package data
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"io"
+	"math"
+	"time"
+)
+
+//// STRUCTURAL EQUALITY
+///
+// natives are equal, if they are of the same type and hold the same value.
+// pointers to big numbers are compared by the value they point to. pairs,
+// slices and unboxed vectors are equal, if all their elements are equal in
+// order, maps if they contain equal keys mapped to equal values, regardless
+// of the map implementation, tables if they have equal named columns.
+func DeepEqual(a, b Native) bool {
+	a, b = indirect(a), indirect(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Type() {
+	case Pair:
+		var x, xok = a.(Paired)
+		var y, yok = b.(Paired)
+		if xok && yok {
+			return DeepEqual(x.Left(), y.Left()) &&
+				DeepEqual(x.Right(), y.Right())
+		}
+	case Map:
+		var x, xok = a.(Mapped)
+		var y, yok = b.(Mapped)
+		if xok && yok {
+			return equalMapped(x, y)
+		}
+	case Tabular:
+		var x, xok = a.(Table)
+		var y, yok = b.(Table)
+		if xok && yok {
+			return equalTables(x, y)
+		}
+	case Slice, Unboxed:
+		var x, xok = a.(Sliced)
+		var y, yok = b.(Sliced)
+		if xok && yok {
+			if a.Type() == Unboxed && typeElem(a) != typeElem(b) {
+				return false
+			}
+			return equalSlices(x.Slice(), y.Slice())
+		}
+	}
+	return equalNative(a, b)
+}
+
+func equalSlices(x, y []Native) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if !DeepEqual(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// tables are equal, if they have equal column names and columns in order
+func equalTables(x, y Table) bool {
+	if x.Width() != y.Width() || x.Len() != y.Len() {
+		return false
+	}
+	for i, name := range x.names {
+		if name != y.names[i] || !DeepEqual(x.cols[i], y.cols[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// keys of maps backed by go maps may not be hashable by go, fields of the
+// second map are therefore looked up in a hash keyed copy.
+func equalMapped(x, y Mapped) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	var m = NewHashedMap(y.Fields()...)
+	for _, field := range x.Fields() {
+		var val, ok = m.Get(field.Left())
+		if !ok || !DeepEqual(field.Right(), val) {
+			return false
+		}
+	}
+	return true
+}
+
+func indirect(nat Native) Native {
+	switch v := nat.(type) {
+	case *BigIntVal:
+		if v != nil {
+			return *v
+		}
+		return nil
+	case *BigFltVal:
+		if v != nil {
+			return *v
+		}
+		return nil
+	case *RatioVal:
+		if v != nil {
+			return *v
+		}
+		return nil
+	}
+	return nat
+}
+
+func typeElem(nat Native) Typed {
+	if v, ok := nat.(interface{ TypeElem() Typed }); ok {
+		return v.TypeElem()
+	}
+	return Nil
+}
+
+//// HASHING
+///
+// hashes the type and value of a native. natives considered equal by deep
+// equal yield the same hash. the hash of maps does not depend on the order
+// of their fields.
+func Hash(nat Native) uint64 {
+	var h = fnv.New64a()
+	writeHash(h, nat)
+	return h.Sum64()
+}
+
+func writeHash(h hash.Hash64, nat Native) {
+	var buf [8]byte
+	var put = func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+	if nat = indirect(nat); nat == nil {
+		put(0)
+		return
+	}
+	put(uint64(nat.Type()))
+	switch nat.Type() {
+	case Pair:
+		if p, ok := nat.(Paired); ok {
+			writeHash(h, p.Left())
+			writeHash(h, p.Right())
+			return
+		}
+	case Map:
+		if m, ok := nat.(Mapped); ok {
+			var sum uint64
+			for _, field := range m.Fields() {
+				sum += Hash(PairVal{field.Left(), field.Right()})
+			}
+			put(uint64(m.Len()))
+			put(sum)
+			return
+		}
+	case Tabular:
+		if t, ok := nat.(Table); ok {
+			put(uint64(t.Width()))
+			for i, name := range t.names {
+				writeHash(h, StrVal(name))
+				writeHash(h, t.cols[i])
+			}
+			return
+		}
+	case Slice, Unboxed:
+		if s, ok := nat.(Sliced); ok {
+			if nat.Type() == Unboxed {
+				put(uint64(typeElem(nat).Flag()))
+			}
+			var elems = s.Slice()
+			put(uint64(len(elems)))
+			for _, elem := range elems {
+				writeHash(h, elem)
+			}
+			return
+		}
+	}
+	switch v := nat.(type) {
+	case TimeVal:
+		put(uint64(time.Time(v).UnixNano()))
+		return
+	case FltVal:
+		put(floatBits(float64(v)))
+		return
+	case Flt32Val:
+		put(floatBits(float64(v)))
+		return
+	case ImagVal:
+		put(floatBits(real(v)))
+		put(floatBits(imag(v)))
+		return
+	case Imag64Val:
+		put(floatBits(float64(real(v))))
+		put(floatBits(float64(imag(v))))
+		return
+	case BigFltVal:
+		var f = v.GoBigFlt()
+		if f.Sign() == 0 {
+			put(0)
+			return
+		}
+		io.WriteString(h, f.Text('p', 0))
+		return
+	case RatioVal:
+		io.WriteString(h, v.GoRat().RatString())
+		return
+	case DecimalVal:
+		io.WriteString(h, v.Normalize().String())
+		return
+	case ErrorVal:
+		io.WriteString(h, stringOf(v))
+		return
+	}
+	if isRegistered(nat.Type()) {
+		io.WriteString(h, nat.String())
+		return
+	}
+	if m, ok := nat.(BinaryMarshaler); ok {
+		if b, err := m.MarshalBinary(); err == nil {
+			h.Write(b)
+			return
+		}
+	}
+	io.WriteString(h, nat.String())
+}
+
+// negative zero and not a number are normalized
+func floatBits(f float64) uint64 {
+	switch {
+	case f == 0:
+		return 0
+	case math.IsNaN(f):
+		return math.Float64bits(math.NaN())
+	}
+	return math.Float64bits(f)
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestDeepEqual(t *testing.T) {
+	var now = time.Now()
+	var cases = []struct {
+		a, b  Native
+		equal bool
+	}{
+		{IntVal(1), IntVal(1), true},
+		{IntVal(1), Int8Val(1), false},
+		{(*BigIntVal)(big.NewInt(42)), (*BigIntVal)(big.NewInt(42)), true},
+		{(*BigIntVal)(big.NewInt(42)), BigIntVal(*big.NewInt(42)), true},
+		{RatioVal(*big.NewRat(2, 4)), RatioVal(*big.NewRat(1, 2)), true},
+		{BigFltVal(*big.NewFloat(1.5).SetPrec(200)), BigFltVal(*big.NewFloat(1.5)), true},
+		{FltVal(math.NaN()), FltVal(math.NaN()), true},
+		{FltVal(math.Copysign(0, -1)), FltVal(0), true},
+		{TimeVal(now), TimeVal(now.UTC()), true},
+		{BytesVal("abc"), BytesVal("abc"), true},
+		{BytesVal("abc"), BytesVal("abd"), false},
+		{ErrorVal{errors.New("e")}, ErrorVal{errors.New("e")}, true},
+		{NewPair(IntVal(1), StrVal("a")), NewPair(IntVal(1), StrVal("a")), true},
+		{NewPair(IntVal(1), StrVal("a")), NewPair(IntVal(1), StrVal("b")), false},
+		{DataSlice{IntVal(1), BytesVal("x")}, DataSlice{IntVal(1), BytesVal("x")}, true},
+		{DataSlice{IntVal(1)}, DataSlice{IntVal(1), IntVal(2)}, false},
+		{IntVec{1, 2}, IntVec{1, 2}, true},
+		{IntVec{}, FltVec{}, false},
+		{IntVec{1, 2}, IntVec{2, 1}, false},
+		{
+			NewStringMap(NewPair(StrVal("a"), IntVal(1))),
+			NewValMap(NewPair(StrVal("a"), IntVal(1))),
+			true,
+		},
+		{
+			NewHashedMap(NewPair(DataSlice{IntVal(1)}, IntVal(1))),
+			NewHashMap(NewPair(DataSlice{IntVal(1)}, IntVal(1))),
+			true,
+		},
+		{
+			NewValMap(NewPair(StrVal("a"), IntVal(1))),
+			NewValMap(NewPair(StrVal("a"), IntVal(2))),
+			false,
+		},
+	}
+	for _, c := range cases {
+		if DeepEqual(c.a, c.b) != c.equal {
+			t.Log("expected equality of", c.a, "and", c.b, "to be", c.equal)
+			t.Fail()
+		}
+		if c.equal && Hash(c.a) != Hash(c.b) {
+			t.Log("equal natives yield different hashes", c.a, c.b)
+			t.Fail()
+		}
+	}
+}
+
+func TestHashedMap(t *testing.T) {
+	var m = NewHashedMap()
+	m = m.Set(BytesVal("key"), IntVal(1))
+	m = m.Set(DataSlice{IntVal(1), IntVal(2)}, IntVal(2))
+	m = m.Set((*BigIntVal)(big.NewInt(3)), IntVal(3))
+	m = m.Set(NewValMap(NewPair(StrVal("a"), IntVal(1))), IntVal(4))
+	fmt.Println(m)
+	if m.Len() != 4 {
+		t.Log("expected four fields", m)
+		t.Fail()
+	}
+	if v, ok := m.Get(BytesVal("key")); !ok || v != IntVal(1) {
+		t.Log("bytes key not found", v)
+		t.Fail()
+	}
+	if v, ok := m.Get(DataSlice{IntVal(1), IntVal(2)}); !ok || v != IntVal(2) {
+		t.Log("slice key not found", v)
+		t.Fail()
+	}
+	if v, ok := m.Get((*BigIntVal)(big.NewInt(3))); !ok || v != IntVal(3) {
+		t.Log("big int key not found", v)
+		t.Fail()
+	}
+	if v, ok := m.Get(NewStringMap(NewPair(StrVal("a"), IntVal(1)))); !ok || v != IntVal(4) {
+		t.Log("map key not found", v)
+		t.Fail()
+	}
+	m = m.Set(BytesVal("key"), IntVal(10))
+	if v, _ := m.Get(BytesVal("key")); m.Len() != 4 || v != IntVal(10) {
+		t.Log("expected value to be replaced", m)
+		t.Fail()
+	}
+	if !m.Delete(DataSlice{IntVal(1), IntVal(2)}) || m.Len() != 3 {
+		t.Log("slice key not deleted", m)
+		t.Fail()
+	}
+}
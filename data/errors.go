@@ -0,0 +1,337 @@
+package data
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//// STRUCTURED ERRORS
+///
+// error values implement the error interface and unwrap to the error they
+// contain, compound errors unwrap to all errors they are composed of, so
+// that errors.Is and errors.As inspect the whole tree of wrapped errors.
+// errors can carry context fields, a string map of natives, attached by
+// wrapping them in a field error.
+func (v ErrorVal) Unwrap() error { return v.E }
+func (v ErrorVal) Error() string {
+	if v.E == nil {
+		return ""
+	}
+	return v.E.Error()
+}
+
+func (e CompoundError) Unwrap() []error { return e() }
+
+// wraps an error, to attach context fields
+type FieldError struct {
+	Err    error
+	Fields MapString
+}
+
+func (e FieldError) Unwrap() error      { return e.Err }
+func (e FieldError) Context() MapString { return e.Fields }
+func (e FieldError) Error() string {
+	var msg string
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if len(e.Fields) == 0 {
+		return msg
+	}
+	return msg + " " + stringFields(e.Fields)
+}
+
+// fields sorted by key, like {key: value, …}
+func stringFields(m MapString) string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	var str = make([]string, 0, len(keys))
+	for _, k := range keys {
+		str = append(str, k+": "+stringOf(m[StrVal(k)]))
+	}
+	return "{" + strings.Join(str, ", ") + "}"
+}
+
+// returns the error with the field attached. fields of a field error
+// wrapped by the error value directly are extended, instead of wrapping it
+// again.
+func (v ErrorVal) With(key string, val Native) ErrorVal {
+	return v.WithFields(MapString{StrVal(key): val})
+}
+func (v ErrorVal) WithFields(fields MapString) ErrorVal {
+	var m = MapString{}
+	var err = v.E
+	if f, ok := err.(FieldError); ok {
+		for k, val := range f.Fields {
+			m[k] = val
+		}
+		err = f.Err
+	}
+	for k, val := range fields {
+		m[k] = val
+	}
+	return ErrorVal{FieldError{err, m}}
+}
+
+// collects the context fields of all errors in the tree of wrapped errors.
+// fields of outer errors take precedence over those of errors they wrap.
+func (v ErrorVal) Fields() MapString {
+	var m = MapString{}
+	walkErrors(v.E, func(err error) {
+		if c, ok := err.(interface{ Context() MapString }); ok {
+			for k, val := range c.Context() {
+				if _, ok := m[k]; !ok {
+					m[k] = val
+				}
+			}
+		}
+	})
+	return m
+}
+
+// calls the function for every error of the tree, outer errors first
+func walkErrors(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+	fn(err)
+	for _, e := range unwrapErrors(err) {
+		walkErrors(e, fn)
+	}
+}
+
+func unwrapErrors(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	case interface{ Unwrap() error }:
+		if u := e.Unwrap(); u != nil {
+			return []error{u}
+		}
+	}
+	return nil
+}
+
+// errors that neither wrap other errors, nor carry fields, are plain
+// errors, which are encoded by their message only.
+func isPlainError(err error) bool {
+	if _, ok := err.(interface{ Context() MapString }); ok {
+		return false
+	}
+	return len(unwrapErrors(err)) == 0
+}
+
+// structured errors decode to a tree of decoded errors, preserving
+// messages, fields and wrapped errors, but not the types of the errors
+// encoded. decoded errors match errors of the same message, when compared
+// by errors.Is.
+type decodedError struct {
+	msg    string
+	fields MapString
+	errs   []error
+}
+
+func (e *decodedError) Error() string      { return e.msg }
+func (e *decodedError) Unwrap() []error    { return e.errs }
+func (e *decodedError) Context() MapString { return e.fields }
+func (e *decodedError) Is(target error) bool {
+	return target != nil && target.Error() == e.msg
+}
+
+// error values nested in the tree are encoded as the error they contain
+func unwrapVal(err error) error {
+	for {
+		var v, ok = err.(ErrorVal)
+		if !ok || v.E == nil {
+			return err
+		}
+		err = v.E
+	}
+}
+
+func errorFields(err error) MapString {
+	if c, ok := err.(interface{ Context() MapString }); ok {
+		return c.Context()
+	}
+	return nil
+}
+
+//// ENCODING
+///
+// plain errors are encoded as their message. structured errors are
+// prefixed by a zero byte, followed by the encoded tree of errors. every
+// error of the tree is encoded as length prefixed message, length prefixed
+// binary encoding of its fields and the number of errors it wraps,
+// followed by those. an empty buffer encodes nil, so errors of empty
+// message are encoded structured.
+func marshalError(err error) ([]byte, error) {
+	if isPlainError(err) && err.Error() != "" {
+		return []byte(err.Error()), nil
+	}
+	return appendError([]byte{0}, err)
+}
+
+func appendError(buf []byte, err error) ([]byte, error) {
+	err = unwrapVal(err)
+	buf = appendUvarint(buf, uint64(len(err.Error())))
+	buf = append(buf, err.Error()...)
+	var fields []byte
+	if m := errorFields(err); len(m) > 0 {
+		var e error
+		if fields, e = m.MarshalBinary(); e != nil {
+			return nil, e
+		}
+	}
+	buf = appendUvarint(buf, uint64(len(fields)))
+	buf = append(buf, fields...)
+	var errs = unwrapErrors(err)
+	buf = appendUvarint(buf, uint64(len(errs)))
+	for _, e := range errs {
+		var err error
+		if buf, err = appendError(buf, e); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func unmarshalError(buf []byte) (error, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	if buf[0] != 0 {
+		return errors.New(string(buf)), nil
+	}
+	var err, rest, e = readError(buf[1:])
+	if e != nil {
+		return nil, e
+	}
+	if len(rest) > 0 {
+		return nil, errMalformed(Error)
+	}
+	return err, nil
+}
+
+// reads a length prefixed chunk from the head of the buffer
+func readChunk(buf []byte) ([]byte, []byte, error) {
+	var l, n = binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf[n:])) < l {
+		return nil, nil, errMalformed(Error)
+	}
+	return buf[n : n+int(l)], buf[n+int(l):], nil
+}
+
+func readError(buf []byte) (*decodedError, []byte, error) {
+	var msg, fields []byte
+	var err error
+	if msg, buf, err = readChunk(buf); err != nil {
+		return nil, nil, err
+	}
+	if fields, buf, err = readChunk(buf); err != nil {
+		return nil, nil, err
+	}
+	var dec = &decodedError{msg: string(msg)}
+	if len(fields) > 0 {
+		if err = dec.fields.UnmarshalBinary(fields); err != nil {
+			return nil, nil, err
+		}
+	}
+	var l, n = binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, nil, errMalformed(Error)
+	}
+	buf = buf[n:]
+	for i := uint64(0); i < l; i++ {
+		var e *decodedError
+		if e, buf, err = readError(buf); err != nil {
+			return nil, nil, err
+		}
+		dec.errs = append(dec.errs, e)
+	}
+	return dec, buf, nil
+}
+
+// json encoding of structured errors
+type jsonError struct {
+	Error   string            `json:"error"`
+	Fields  MapString         `json:"fields,omitempty"`
+	Wrapped []json.RawMessage `json:"wrapped,omitempty"`
+}
+
+// plain errors are encoded as string, structured errors as object of
+// message, fields and wrapped errors.
+func marshalJSONError(err error) ([]byte, error) {
+	err = unwrapVal(err)
+	if isPlainError(err) {
+		return json.Marshal(err.Error())
+	}
+	var je = jsonError{Error: err.Error(), Fields: errorFields(err)}
+	for _, e := range unwrapErrors(err) {
+		var buf, err = marshalJSONError(e)
+		if err != nil {
+			return nil, err
+		}
+		je.Wrapped = append(je.Wrapped, buf)
+	}
+	return json.Marshal(je)
+}
+
+// decodes a string, or an object, returns nil for json null.
+func unmarshalJSONError(buf []byte, nested bool) (error, error) {
+	var str *string
+	if err := json.Unmarshal(buf, &str); err == nil {
+		if str == nil {
+			return nil, nil
+		}
+		if nested {
+			return &decodedError{msg: *str}, nil
+		}
+		return errors.New(*str), nil
+	}
+	var je jsonError
+	if err := json.Unmarshal(buf, &je); err != nil {
+		return nil, fmt.Errorf("expected json string, or error object, got %s", buf)
+	}
+	var dec = &decodedError{msg: je.Error, fields: je.Fields}
+	for _, w := range je.Wrapped {
+		var e, err = unmarshalJSONError(w, true)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			dec.errs = append(dec.errs, e)
+		}
+	}
+	return dec, nil
+}
+
+//// ERROR VECTOR
+///
+// collects errors of bulk operations, nil errors are skipped.
+func (v ErrorVec) Append(errs ...error) ErrorVec {
+	for _, err := range errs {
+		if err != nil {
+			v = append(v, err)
+		}
+	}
+	return v
+}
+
+// returns nil, if no errors have been collected, the single error, or a
+// compound error of all errors collected.
+func (v ErrorVec) Err() error {
+	switch len(v) {
+	case 0:
+		return nil
+	case 1:
+		return v[0]
+	}
+	return NewCompoundError(append([]error{}, v...)...)
+}
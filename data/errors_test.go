@@ -0,0 +1,135 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func newStructuredTestError() ErrorVal {
+	var wrapped = fmt.Errorf("reading header: %w", io.ErrUnexpectedEOF)
+	return NewError(
+		ErrorVal{wrapped}.With("line", IntVal(3)),
+		ConversionError{StrVal("x"), Int, false, io.EOF},
+	).With("file", StrVal("data.csv"))
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	var err error = newStructuredTestError()
+	fmt.Println(err)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || !errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrClosedPipe) {
+		t.Log("expected wrapped errors to be found by errors.Is")
+		t.Fail()
+	}
+	var conv ConversionError
+	if !errors.As(err, &conv) || conv.To != Int {
+		t.Log("expected conversion error to be found by errors.As")
+		t.Fail()
+	}
+	var fields = newStructuredTestError().Fields()
+	if fields["file"] != StrVal("data.csv") || fields["line"] != IntVal(3) {
+		t.Log("expected fields of all wrapped errors", fields)
+		t.Fail()
+	}
+	var twice = ErrorVal{io.EOF}.With("a", IntVal(1)).With("b", IntVal(2))
+	if _, ok := twice.E.(FieldError).Err.(FieldError); ok || len(twice.Fields()) != 2 {
+		t.Log("attaching fields should extend the field error", twice)
+		t.Fail()
+	}
+	if twice.Error() != "EOF {a: 1, b: 2}" {
+		t.Log("unexpected message", twice.Error())
+		t.Fail()
+	}
+	var vec = ErrorVec{}.Append(nil, io.EOF, nil)
+	if len(vec) != 1 || vec.Err() != io.EOF || (ErrorVec{}).Err() != nil {
+		t.Log("unexpected collected errors", vec)
+		t.Fail()
+	}
+	vec = vec.Append(io.ErrClosedPipe)
+	if err := vec.Err(); !errors.Is(err, io.ErrClosedPipe) ||
+		!strings.Contains(err.Error(), "1: io: read/write on closed pipe") {
+		t.Log("expected compound error of collected errors", err)
+		t.Fail()
+	}
+}
+
+func checkDecodedError(t *testing.T, orig, dec ErrorVal) {
+	if dec.Error() != orig.Error() {
+		t.Log("expected message", orig.Error(), "got", dec.Error())
+		t.Fail()
+	}
+	if !errors.Is(dec, io.ErrUnexpectedEOF) || !errors.Is(dec, io.EOF) ||
+		errors.Is(dec, io.ErrClosedPipe) {
+		t.Log("decoded error should match wrapped errors by message")
+		t.Fail()
+	}
+	var fields = dec.Fields()
+	if fields["file"] != StrVal("data.csv") || fields["line"] != IntVal(3) {
+		t.Log("decoded error lost its fields", fields)
+		t.Fail()
+	}
+}
+
+func TestErrorEncoding(t *testing.T) {
+	var orig = newStructuredTestError()
+	var buf, err = orig.MarshalBinary()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var dec ErrorVal
+	if err = dec.UnmarshalBinary(buf); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	checkDecodedError(t, orig, dec)
+	if buf, err = orig.MarshalJSON(); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	fmt.Println(string(buf))
+	dec = ErrorVal{}
+	if err = dec.UnmarshalJSON(buf); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	checkDecodedError(t, orig, dec)
+	// plain errors keep their encoding as message
+	if buf, _ = (ErrorVal{io.EOF}).MarshalJSON(); string(buf) != `"EOF"` {
+		t.Log("unexpected encoding of plain error", string(buf))
+		t.Fail()
+	}
+	if buf, _ = (ErrorVal{io.EOF}).MarshalBinary(); string(buf) != "EOF" {
+		t.Log("unexpected encoding of plain error", buf)
+		t.Fail()
+	}
+	if err = dec.UnmarshalBinary([]byte{0, 5, 'a'}); err == nil {
+		t.Log("expected malformed encoding to fail")
+		t.Fail()
+	}
+	// nil stays nil, errors of empty message stay errors
+	for _, e := range []error{nil, errors.New("")} {
+		buf, _ = (ErrorVal{e}).MarshalBinary()
+		dec = ErrorVal{io.EOF}
+		if err = dec.UnmarshalBinary(buf); err != nil ||
+			(dec.E == nil) != (e == nil) {
+			t.Log("binary round trip of", e, "yields", dec.E, err)
+			t.Fail()
+		}
+		buf, _ = (ErrorVal{e}).MarshalJSON()
+		dec = ErrorVal{io.EOF}
+		if err = dec.UnmarshalJSON(buf); err != nil ||
+			(dec.E == nil) != (e == nil) {
+			t.Log("json round trip of", e, "yields", dec.E, err)
+			t.Fail()
+		}
+	}
+	var nat, _ = UnmarshalNative(Error, []byte{})
+	if e, ok := nat.(ErrorVal); !ok || e.E != nil {
+		t.Log("expected empty encoding to yield nil error", nat)
+		t.Fail()
+	}
+}
@@ -25,6 +25,7 @@ func (v BitFlag) Concat(f Typed) Typed { return FlagConcat(v, f).Flag() }
 func (v BitFlag) Mask(f Typed) Typed   { return FlagMask(v, f).Flag() }
 func (v BitFlag) Match(f Typed) bool   { return FlagMatch(v, f) }
 func (v BitFlag) Decompose() []Typed   { return FlagDecompose(v) }
+func (v BitFlag) Wide() WideFlag       { return NewWideFlag(uint64(v)) }
 
 ///// FREE TYPE FLAG METHOD IMPLEMENTATIONS /////
 func flag(t Typed) BitFlag { return t.Flag() }
@@ -35,7 +36,7 @@ func FlagCount(t Typed) int {
 	return bits.OnesCount(uint(t.Flag().Uint()))
 }
 func FlagLeast(t Typed) int {
-	return bits.TrailingZeros(uint(t.Flag().Uint()) + 1)
+	return bits.TrailingZeros(uint(t.Flag().Uint()))
 }
 func FlagMost(t Typed) int {
 	return bits.LeadingZeros(uint(t.Flag().Uint()) - 1)
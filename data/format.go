@@ -46,7 +46,13 @@ func (p PairVal) String() string {
 }
 
 //// SETS ////
-func (v MapVal) String() string { return StringSlice(", ", "[", "]", v.Slice()...) }
+func (v MapVal) String() string  { return StringSlice(", ", "[", "]", v.Slice()...) }
+func (m HashMap) String() string { return StringSlice(", ", "[", "]", m.Slice()...) }
+func (m OrderedMap) String() string {
+	return StringSlice(", ", "[", "]", m.Slice()...)
+}
+func (s SortedSet) String() string { return StringSlice(", ", "[", "]", s.Slice()...) }
+func (v Vector) String() string    { return StringSlice(", ", "[", "]", v.Slice()...) }
 
 //// NATIVE SETS /////
 func (s MapInt) String() string    { return StringSlice(", ", "[", "]", s.Slice()...) }
@@ -54,11 +60,11 @@ func (s MapUint) String() string   { return StringSlice(", ", "[", "]", s.Slice(
 func (s MapFloat) String() string  { return StringSlice(", ", "[", "]", s.Slice()...) }
 func (s MapFlag) String() string   { return StringSlice(", ", "[", "]", s.Slice()...) }
 func (s MapString) String() string { return StringSlice(", ", "[", "]", s.Slice()...) }
+func (s MapHash) String() string   { return StringSlice(", ", "[", "]", s.Slice()...) }
 
 // string nullables
 func (NilVal) String() string      { return Nil.String() }
 func (v ErrorVal) String() string  { return "Error: " + v.E.Error() }
-func (v ErrorVal) Error() ErrorVal { return ErrorVal{v.E} }
 func (v BoolVal) String() string   { return strconv.FormatBool(bool(v)) }
 func (v IntVal) String() string    { return strconv.Itoa(int(v)) }
 func (v Int8Val) String() string   { return strconv.Itoa(int(v)) }
@@ -75,6 +81,20 @@ func (v DuraVal) String() string   { return time.Duration(v).String() }
 func (v BigIntVal) String() string { return ((*big.Int)(&v)).String() }
 func (v RatioVal) String() string  { return ((*big.Rat)(&v)).String() }
 func (v BigFltVal) String() string { return ((*big.Float)(&v)).String() }
+func (v DecimalVal) String() string {
+	var digits, sign = new(big.Int).Abs(v.unscaled()).String(), ""
+	if v.Sign() < 0 {
+		sign = "-"
+	}
+	if v.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= v.scale {
+		digits = strings.Repeat("0", v.scale-len(digits)+1) + digits
+	}
+	var p = len(digits) - v.scale
+	return sign + digits[:p] + "." + digits[p:]
+}
 func (v FltVal) String() string {
 	return strconv.FormatFloat(float64(v), 'G', -1, 64)
 }
@@ -98,7 +118,7 @@ func StringBitFlag(v BitFlag) string {
 	var str string
 	if bits.OnesCount(uint(v.Uint())) > 1 {
 		for i, f := range FlagDecompose(v) {
-			str = str + f.(TyNat).String()
+			str = str + TyNat(f.Flag()).String()
 			if i < len(FlagDecompose(v))-1 {
 				str = str + "∙"
 			}
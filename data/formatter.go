@@ -0,0 +1,304 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//// FORMATTER
+///
+// natives implement fmt.Formatter. %v and %s print the string
+// representation, %+v appends the type like 3::Int8, type flags print their
+// decomposed flag names instead. %#v prints go syntax like data.IntVal(3),
+// %q the quoted string representation. other verbs, as well as %v with
+// precision, are applied to the go value the native is defined as, so that
+// numeric natives accept width, precision and flags like go's own types.
+func (v NilVal) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, nil) }
+func (v BoolVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, bool(v)) }
+func (v IntVal) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, int(v)) }
+func (v Int8Val) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, int8(v)) }
+func (v Int16Val) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, int16(v)) }
+func (v Int32Val) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, int32(v)) }
+func (v UintVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, uint(v)) }
+func (v Uint8Val) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, uint8(v)) }
+func (v Uint16Val) Format(s fmt.State, verb rune) { formatNative(s, verb, v, uint16(v)) }
+func (v Uint32Val) Format(s fmt.State, verb rune) { formatNative(s, verb, v, uint32(v)) }
+func (v FltVal) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, float64(v)) }
+func (v Flt32Val) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, float32(v)) }
+func (v ImagVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, complex128(v)) }
+func (v Imag64Val) Format(s fmt.State, verb rune) { formatNative(s, verb, v, complex64(v)) }
+func (v ByteVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, byte(v)) }
+func (v RuneVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, rune(v)) }
+func (v StrVal) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, string(v)) }
+func (v BytesVal) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []byte(v)) }
+func (v BigIntVal) Format(s fmt.State, verb rune) { formatNative(s, verb, v, (*big.Int)(&v)) }
+func (v BigFltVal) Format(s fmt.State, verb rune) { formatNative(s, verb, v, (*big.Float)(&v)) }
+func (v RatioVal) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, ratFloat(v.GoRat())) }
+func (v TimeVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, time.Time(v)) }
+func (v DuraVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, time.Duration(v)) }
+func (v ErrorVal) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, v.E) }
+func (v BitFlag) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, uint64(v)) }
+func (v TyNat) Format(s fmt.State, verb rune)     { formatNative(s, verb, v, uint64(v)) }
+func (v PairVal) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, nil) }
+func (v DataSlice) Format(s fmt.State, verb rune) { formatNative(s, verb, v, nil) }
+
+func (v NilVec) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, []struct{}(v)) }
+func (v BoolVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []bool(v)) }
+func (v IntVec) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, []int(v)) }
+func (v Int8Vec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []int8(v)) }
+func (v Int16Vec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []int16(v)) }
+func (v Int32Vec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []int32(v)) }
+func (v UintVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []uint(v)) }
+func (v Uint8Vec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []uint8(v)) }
+func (v Uint16Vec) Format(s fmt.State, verb rune) { formatNative(s, verb, v, []uint16(v)) }
+func (v Uint32Vec) Format(s fmt.State, verb rune) { formatNative(s, verb, v, []uint32(v)) }
+func (v FltVec) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, []float64(v)) }
+func (v Flt32Vec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []float32(v)) }
+func (v ImagVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []complex128(v)) }
+func (v Imag64Vec) Format(s fmt.State, verb rune) { formatNative(s, verb, v, []complex64(v)) }
+func (v ByteVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []byte(v)) }
+func (v RuneVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []rune(v)) }
+func (v BytesVec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, [][]byte(v)) }
+func (v StrVec) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, []string(v)) }
+func (v BigIntVec) Format(s fmt.State, verb rune) { formatNative(s, verb, v, []*big.Int(v)) }
+func (v BigFltVec) Format(s fmt.State, verb rune) { formatNative(s, verb, v, []*big.Float(v)) }
+func (v RatioVec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []*big.Rat(v)) }
+func (v TimeVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []time.Time(v)) }
+func (v DuraVec) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []time.Duration(v)) }
+func (v ErrorVec) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, []error(v)) }
+func (v FlagSet) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, []BitFlag(v)) }
+
+func (v MapString) Format(s fmt.State, verb rune) { formatNative(s, verb, v, nil) }
+func (v MapUint) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, nil) }
+func (v MapInt) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, nil) }
+func (v MapFloat) Format(s fmt.State, verb rune)  { formatNative(s, verb, v, nil) }
+func (v MapFlag) Format(s fmt.State, verb rune)   { formatNative(s, verb, v, nil) }
+func (v MapVal) Format(s fmt.State, verb rune)    { formatNative(s, verb, v, nil) }
+
+// decimals format exactly with %f, rounded to the precision, if one is given
+func (v DecimalVal) Format(s fmt.State, verb rune) {
+	if verb != 'f' && verb != 'F' {
+		formatNative(s, verb, v, ratFloat(v.GoRat()))
+		return
+	}
+	var str = v.String()
+	if prec, ok := s.Precision(); ok {
+		str = v.Rescale(prec, DecimalRounding).String()
+	}
+	if s.Flag('+') && v.Sign() >= 0 {
+		str = "+" + str
+	}
+	padFormat(s, str)
+}
+
+func formatNative(s fmt.State, verb rune, nat Native, val interface{}) {
+	var _, prec = s.Precision()
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		padFormat(s, goSyntax(nat))
+	case verb == 'v' && s.Flag('+'):
+		padFormat(s, annotated(nat))
+	case verb == 'v' && prec && isNumeric(val), verb != 'v' && verb != 's' &&
+		verb != 'q' && val != nil:
+		fmt.Fprintf(s, formatString(s, verb), val)
+	case verb == 'q':
+		padFormat(s, strconv.Quote(nat.String()))
+	case verb == 'v', verb == 's':
+		fmt.Fprintf(s, formatString(s, 's'), nat.String())
+	default:
+		fmt.Fprintf(s, "%%!%c(%s=%s)", verb, goTypeName(nat), nat.String())
+	}
+}
+
+// reconstructs the format string from the state and verb passed
+func formatString(s fmt.State, verb rune) string {
+	var str = "%"
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			str = str + string(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		str = str + strconv.Itoa(width)
+	}
+	if prec, ok := s.Precision(); ok {
+		str = str + "." + strconv.Itoa(prec)
+	}
+	return str + string(verb)
+}
+
+// pads the string to the width, without truncating it to the precision
+func padFormat(s fmt.State, str string) {
+	var format = "%"
+	if s.Flag('-') {
+		format = format + "-"
+	}
+	if width, ok := s.Width(); ok {
+		format = format + strconv.Itoa(width)
+	}
+	fmt.Fprintf(s, format+"s", str)
+}
+
+func isNumeric(val interface{}) bool {
+	switch val.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, complex64, complex128, *big.Int, *big.Float:
+		return true
+	}
+	return false
+}
+
+// ratios are formatted as big float, since big.Rat is no formatter
+func ratFloat(r *big.Rat) *big.Float {
+	return new(big.Float).SetPrec(256).SetRat(r)
+}
+
+func annotated(nat Native) string {
+	switch v := nat.(type) {
+	case BitFlag:
+		return flagNames(TyNat(v))
+	case TyNat:
+		return flagNames(v)
+	}
+	return nat.String() + "::" + nat.Type().TypeName()
+}
+
+// names of all flags, the type flag is composed of
+func flagNames(t TyNat) string {
+	var names = []string{}
+	for _, flag := range t.Flag().Decompose() {
+		names = append(names, TyNat(flag.Flag()).TypeName())
+	}
+	if len(names) == 0 {
+		return t.String()
+	}
+	return strings.Join(names, "|")
+}
+
+func goTypeName(nat Native) string {
+	return "data." + reflect.TypeOf(nat).Name()
+}
+
+// element types of unboxed vectors, that go syntax prints without prefix
+var goBasicSlice = func() map[string]bool {
+	var m = map[string]bool{}
+	for _, name := range strings.Fields(`bool int int8 int16 int32 int64
+		uint uint8 uint16 uint32 uint64 float32 float64 complex64
+		complex128 string`) {
+		m["[]"+name] = true
+	}
+	return m
+}()
+
+// go syntax representation of the native
+func goSyntax(nat Native) string {
+	var name = goTypeName(nat)
+	switch v := nat.(type) {
+	case nil:
+		return "nil"
+	case NilVal:
+		return name + "{}"
+	case ErrorVal:
+		if v.E == nil {
+			return name + "{}"
+		}
+		return name + "{E: errors.New(" + strconv.Quote(v.E.Error()) + ")}"
+	case TyNat:
+		var names = []string{}
+		for _, flag := range v.Flag().Decompose() {
+			names = append(names, "data."+TyNat(flag.Flag()).String())
+		}
+		if len(names) == 0 || len(names) != v.Flag().Count() {
+			return name + "(" + strconv.FormatUint(uint64(v), 10) + ")"
+		}
+		return strings.Join(names, " | ")
+	case BitFlag:
+		return name + "(0x" + strconv.FormatUint(uint64(v), 16) + ")"
+	case PairVal:
+		return name + "{L: " + goSyntax(v.L) + ", R: " + goSyntax(v.R) + "}"
+	case BigIntVal, BigFltVal, RatioVal, DecimalVal:
+		return name + "(" + nat.String() + ")"
+	case TimeVal:
+		return name + "(" + fmt.Sprintf("%#v", time.Time(v)) + ")"
+	case BytesVal:
+		return name + strings.TrimPrefix(fmt.Sprintf("%#v", []byte(v)), "[]byte")
+	}
+	switch nat.Type() {
+	case Map:
+		if m, ok := nat.(Mapped); ok {
+			var fields = m.Fields()
+			sort.SliceStable(fields, func(i, j int) bool {
+				return Compare(fields[i].Left(), fields[j].Left()) < 0
+			})
+			var elems = make([]string, 0, len(fields))
+			for _, field := range fields {
+				elems = append(elems,
+					goSyntax(field.Left())+": "+goSyntax(field.Right()))
+			}
+			return name + "{" + strings.Join(elems, ", ") + "}"
+		}
+	case Slice, Unboxed:
+		var val = reflect.ValueOf(nat)
+		if val.Kind() == reflect.Slice && goBasicSlice["[]"+val.Type().Elem().Name()] {
+			var str = fmt.Sprintf("%#v", val.Convert(
+				reflect.SliceOf(val.Type().Elem())).Interface())
+			return name + str[strings.IndexByte(str, '{'):]
+		}
+		if s, ok := nat.(Sliced); ok {
+			var elems = make([]string, 0, len(s.Slice()))
+			for _, elem := range s.Slice() {
+				elems = append(elems, goSyntax(elem))
+			}
+			return name + "{" + strings.Join(elems, ", ") + "}"
+		}
+	}
+	var val = reflect.ValueOf(nat)
+	if val.Kind() == reflect.Struct || val.Kind() == reflect.Slice {
+		return nat.String()
+	}
+	return name + "(" + fmt.Sprintf("%#v", val.Convert(
+		basicType(val.Kind())).Interface()) + ")"
+}
+
+// go type of the kind passed, natives are converted to, to print them in
+// go syntax without recursing into their formatter.
+func basicType(k reflect.Kind) reflect.Type {
+	switch k {
+	case reflect.Bool:
+		return reflect.TypeOf(false)
+	case reflect.Int:
+		return reflect.TypeOf(int(0))
+	case reflect.Int8:
+		return reflect.TypeOf(int8(0))
+	case reflect.Int16:
+		return reflect.TypeOf(int16(0))
+	case reflect.Int32:
+		return reflect.TypeOf(int32(0))
+	case reflect.Int64:
+		return reflect.TypeOf(int64(0))
+	case reflect.Uint:
+		return reflect.TypeOf(uint(0))
+	case reflect.Uint8:
+		return reflect.TypeOf(uint8(0))
+	case reflect.Uint16:
+		return reflect.TypeOf(uint16(0))
+	case reflect.Uint32:
+		return reflect.TypeOf(uint32(0))
+	case reflect.Uint64:
+		return reflect.TypeOf(uint64(0))
+	case reflect.Float32:
+		return reflect.TypeOf(float32(0))
+	case reflect.Float64:
+		return reflect.TypeOf(float64(0))
+	case reflect.Complex64:
+		return reflect.TypeOf(complex64(0))
+	case reflect.Complex128:
+		return reflect.TypeOf(complex128(0))
+	}
+	return reflect.TypeOf("")
+}
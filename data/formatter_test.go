@@ -0,0 +1,76 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	var cases = []struct {
+		format string
+		arg    Native
+		expect string
+	}{
+		{"%v", IntVal(3), "3"},
+		{"%+v", Int8Val(3), "3::Int8"},
+		{"%#v", Int8Val(3), "data.Int8Val(3)"},
+		{"%#v", StrVal("a\"b"), `data.StrVal("a\"b")`},
+		{"%#v", FltVal(1.5), "data.FltVal(1.5)"},
+		{"%#v", NilVal{}, "data.NilVal{}"},
+		{"%#v", ErrorVal{errors.New("boom")}, `data.ErrorVal{E: errors.New("boom")}`},
+		{"%#v", NewPair(IntVal(1), StrVal("x")), `data.PairVal{L: data.IntVal(1), R: data.StrVal("x")}`},
+		{"%#v", IntVec{1, 2}, "data.IntVec{1, 2}"},
+		{"%#v", NewSlice(IntVal(1), BoolVal(true)), "data.DataSlice{data.IntVal(1), data.BoolVal(true)}"},
+		{"%#v", MapString{"b": IntVal(2), "a": IntVal(1)}, `data.MapString{data.StrVal("a"): data.IntVal(1), data.StrVal("b"): data.IntVal(2)}`},
+		{"%#v", BytesVal{1, 255}, "data.BytesVal{0x1, 0xff}"},
+		{"%#v", Int | String, "data.Int | data.String"},
+		{"%#v", BigIntVal(*big.NewInt(42)), "data.BigIntVal(42)"},
+		{"%x", BytesVal{1, 255}, "01ff"},
+		{"%X", BitFlag(255), "FF"},
+		{"%x", IntVec{10, 11}, "[a b]"},
+		{"%5d|", IntVal(42), "   42|"},
+		{"%-5d|", IntVal(42), "42   |"},
+		{"%05.1f", FltVal(3.14159), "003.1"},
+		{"%.2e", Flt32Val(1234.5), "1.23e+03"},
+		{"%.3v", FltVal(3.14159), "3.14"},
+		{"%8v|", StrVal("ab"), "      ab|"},
+		{"%q", StrVal("a\n"), `"a\n"`},
+		{"%s", Uint8Val(7), "7"},
+		{"%d", BigIntVal(*big.NewInt(-7)), "-7"},
+		{"%.2f", RatioVal(*big.NewRat(1, 3)), "0.33"},
+		{"%.2f", NewDecimal(2675, 3), "2.68"},
+		{"%+f", NewDecimal(5, 1), "+0.5"},
+		{"%v", NewDecimal(500, 2), "5.00"},
+		{"%+v", (Int | String).Flag(), "Int|String"},
+		{"%+v", Numbers, "Int8|Int16|Int32|Int|BigInt|Uint8|Uint16|Uint32|Uint|Flt32|Float|BigFlt|Ratio|Imag64|Imag|Decimal"},
+		{"%v", Int, "Int"},
+		{"%+v", IntVec{1}, "[1]::Unboxed"},
+		{"%d", DuraVal(5), "5"},
+	}
+	for _, c := range cases {
+		var str = fmt.Sprintf(c.format, c.arg)
+		fmt.Println(c.format, str)
+		if str != c.expect {
+			t.Log("formatting", c.format, "expected", c.expect, "got", str)
+			t.Fail()
+		}
+	}
+}
+
+func TestFormatUnchanged(t *testing.T) {
+	// printing natives with %v needs to yield their string representation
+	var nats = []Native{
+		IntVal(-1), FltVal(1e21), ImagVal(complex(1, 2)), StrVal("x"),
+		BoolVal(true), NewSlice(IntVal(1), StrVal("y")), TimeVal{},
+		ErrorVal{errors.New("e")}, Int | Float, RuneVec{'a'},
+		MapInt{1: StrVal("one")},
+	}
+	for _, nat := range nats {
+		if fmt.Sprint(nat) != nat.String() {
+			t.Log("expected", nat.String(), "got", fmt.Sprint(nat))
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,200 @@
+//go:build ignore
+// +build ignore
+
+// generates the element-wise operators and reductions of the numeric
+// unboxed vectors in vector_typed.go. run by go generate from vector.go.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"text/template"
+)
+
+type vecType struct {
+	Vec, Val, Elem string
+	Integer        bool // division by zero yields an error
+	Float          bool // min and max propagate not a number
+	Imag           bool // no ordering, no min and max
+}
+
+var vecTypes = []vecType{
+	{Vec: "IntVec", Val: "IntVal", Elem: "int", Integer: true},
+	{Vec: "Int8Vec", Val: "Int8Val", Elem: "int8", Integer: true},
+	{Vec: "Int16Vec", Val: "Int16Val", Elem: "int16", Integer: true},
+	{Vec: "Int32Vec", Val: "Int32Val", Elem: "int32", Integer: true},
+	{Vec: "UintVec", Val: "UintVal", Elem: "uint", Integer: true},
+	{Vec: "Uint8Vec", Val: "Uint8Val", Elem: "uint8", Integer: true},
+	{Vec: "Uint16Vec", Val: "Uint16Val", Elem: "uint16", Integer: true},
+	{Vec: "Uint32Vec", Val: "Uint32Val", Elem: "uint32", Integer: true},
+	{Vec: "ByteVec", Val: "ByteVal", Elem: "byte", Integer: true},
+	{Vec: "FltVec", Val: "FltVal", Elem: "float64", Float: true},
+	{Vec: "Flt32Vec", Val: "Flt32Val", Elem: "float32", Float: true},
+	{Vec: "ImagVec", Val: "ImagVal", Elem: "complex128", Imag: true},
+	{Vec: "Imag64Vec", Val: "Imag64Val", Elem: "complex64", Imag: true},
+}
+
+type operator struct{ Name, Sym string }
+
+var (
+	arithmetic = []operator{
+		{"Add", "+"}, {"Substract", "-"}, {"Multiply", "*"}, {"Quotient", "/"},
+	}
+	comparison = []operator{
+		{"Equal", "=="}, {"Lesser", "<"}, {"Greater", ">"}, {"Leq", "<="}, {"Geq", ">="},
+	}
+)
+
+var source = template.Must(template.New("vector").Parse(`// Code generated by "go run gen_vector.go"; DO NOT EDIT.
+
+package data
+
+import "math"
+
+// dispatches on the type of the vector operand, yields false if either
+// operand doesn't match it.
+func evalVector(op OpStr, vec, a, b Native) (Native, bool, error) {
+	switch x := vec.(type) {
+{{- range .Types}}
+	case {{.Vec}}:
+		var v, vok = x.operand(a)
+		var w, wok = x.operand(b)
+		if vok && wok {
+			var res, err = v.evalVec(op, w)
+			return res, true, err
+		}
+{{- end}}
+	}
+	return nil, false, nil
+}
+{{range $t := .Types}}
+// operands are either vectors of the same type, or scalars of their element
+// type, which are converted to vectors of length one.
+func (v {{.Vec}}) operand(arg Native) ({{.Vec}}, bool) {
+	switch x := arg.(type) {
+	case {{.Vec}}:
+		return x, true
+	case {{.Val}}:
+		return {{.Vec}}{ {{- .Elem}}(x)}, true
+	}
+	return nil, false
+}
+
+// the operator is dispatched once, each case loops over the elements.
+func (v {{.Vec}}) evalVec(op OpStr, w {{.Vec}}) (Native, error) {
+	var n, sv, sw, err = broadcast(len(v), len(w))
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+{{- range $.Arithmetic}}
+	case {{.Name}}:
+		var res = make({{$t.Vec}}, n)
+{{- if and $t.Integer (eq .Name "Quotient")}}
+		for i := range res {
+			var y = w[i*sw]
+			if y == 0 {
+				return nil, errVecZero(i)
+			}
+			res[i] = v[i*sv] / y
+		}
+{{- else}}
+		for i := range res {
+			res[i] = v[i*sv] {{.Sym}} w[i*sw]
+		}
+{{- end}}
+		return res, nil
+{{- end}}
+{{- range $.Comparison}}
+{{- if or (not $t.Imag) (eq .Name "Equal")}}
+	case {{.Name}}:
+		var res = make(BoolVec, n)
+		for i := range res {
+			res[i] = v[i*sv] {{.Sym}} w[i*sw]
+		}
+		return res, nil
+{{- end}}
+{{- end}}
+	}
+	return nil, errOperator(op, v)
+}
+
+func (v {{.Vec}}) Sum() {{.Val}} {
+	var sum {{.Elem}}
+	for _, x := range v {
+		sum += x
+	}
+	return {{.Val}}(sum)
+}
+
+func (v {{.Vec}}) Product() {{.Val}} {
+	var prod {{.Elem}} = 1
+	for _, x := range v {
+		prod *= x
+	}
+	return {{.Val}}(prod)
+}
+
+func (v {{.Vec}}) Dot(w {{.Vec}}) ({{.Val}}, error) {
+	if len(v) != len(w) {
+		return 0, errDotLen(len(v), len(w))
+	}
+	var sum {{.Elem}}
+	for i, x := range v {
+		sum += x * w[i]
+	}
+	return {{.Val}}(sum), nil
+}
+{{- if .Float}}
+{{- range $fn := $.MinMax}}
+
+func (v {{$t.Vec}}) {{$fn.Name}}() ({{$t.Val}}, bool) {
+	if len(v) == 0 {
+		return 0, false
+	}
+	var m = float64(v[0])
+	for _, x := range v[1:] {
+		m = math.{{$fn.Name}}(m, float64(x))
+	}
+	return {{$t.Val}}(m), true
+}
+{{- end}}
+{{- else if not .Imag}}
+{{- range $fn := $.MinMax}}
+
+func (v {{$t.Vec}}) {{$fn.Name}}() ({{$t.Val}}, bool) {
+	if len(v) == 0 {
+		return 0, false
+	}
+	var m = v[0]
+	for _, x := range v[1:] {
+		if x {{$fn.Sym}} m {
+			m = x
+		}
+	}
+	return {{$t.Val}}(m), true
+}
+{{- end}}
+{{- end}}
+{{end}}`))
+
+func main() {
+	var buf bytes.Buffer
+	if err := source.Execute(&buf, map[string]interface{}{
+		"Types":      vecTypes,
+		"Arithmetic": arithmetic,
+		"Comparison": comparison,
+		"MinMax":     []operator{{"Min", "<"}, {"Max", ">"}},
+	}); err != nil {
+		log.Fatal(err)
+	}
+	var src, err = format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("vector_typed.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
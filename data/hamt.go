@@ -0,0 +1,378 @@
+package data
+
+import "math/bits"
+
+//// PERSISTENT HASH MAP
+///
+// hash array mapped trie of natives. set and delete return a new version of
+// the map, that shares all unchanged nodes with the version it has been
+// derived from, which stays valid and unchanged. keys are hashed and
+// compared by Hash and DeepEqual, so any native can be used as a key. every
+// level consumes five bits of the keys hash, keys with identical hashes are
+// kept in a collision node at the bottom of the trie.
+type HashMap struct {
+	root *hamtNode
+	size int
+}
+
+const (
+	hamtBits  = 5
+	hamtMask  = 1<<hamtBits - 1
+	hamtDepth = 64
+)
+
+// nodes are owned by the builder, that holds the same edit token and can be
+// mutated in place by it. nodes of persistent maps are never mutated.
+type hamtEdit struct{ _ byte }
+
+type hamtNode struct {
+	bitmap    uint32
+	slots     []hamtSlot
+	collision bool
+	edit      *hamtEdit
+}
+
+// a slot either references a sub node, or holds a key value pair
+type hamtSlot struct {
+	key, val Native
+	hash     uint64
+	node     *hamtNode
+}
+
+func NewHashMap(acc ...Paired) Mapped {
+	var b = NewHashMapBuilder()
+	for _, pair := range acc {
+		b.Set(pair.Left(), pair.Right())
+	}
+	return b.Persistent()
+}
+
+func (m HashMap) Type() TyNat { return Map }
+func (m HashMap) Len() int    { return m.size }
+func (m HashMap) First() Paired {
+	if m.size > 0 {
+		return m.Fields()[0]
+	}
+	return NewPair(NewNil(), NewNil())
+}
+func (m HashMap) TypeKey() Typed   { return m.First().Left().Type() }
+func (m HashMap) TypeValue() Typed { return m.First().Right().Type() }
+
+func (m HashMap) Keys() []Native {
+	var keys = make([]Native, 0, m.size)
+	m.root.each(func(s hamtSlot) { keys = append(keys, s.key) })
+	return keys
+}
+
+func (m HashMap) Data() []Native {
+	var dat = make([]Native, 0, m.size)
+	m.root.each(func(s hamtSlot) { dat = append(dat, s.val) })
+	return dat
+}
+
+func (m HashMap) Slice() []Native {
+	var native = make([]Native, 0, m.size)
+	m.root.each(func(s hamtSlot) { native = append(native, PairVal{s.key, s.val}) })
+	return native
+}
+
+func (m HashMap) Fields() []Paired {
+	var pairs = make([]Paired, 0, m.size)
+	m.root.each(func(s hamtSlot) { pairs = append(pairs, PairVal{s.key, s.val}) })
+	return pairs
+}
+
+func (m HashMap) Has(acc Native) bool {
+	var _, ok = m.Get(acc)
+	return ok
+}
+
+func (m HashMap) Get(acc Native) (Native, bool) {
+	return m.root.get(acc, Hash(acc), 0)
+}
+
+// returns a new version of the map, containing the field passed
+func (m HashMap) Set(acc Native, dat Native) Mapped {
+	var added bool
+	var root = m.root.set(nil, acc, dat, Hash(acc), 0, &added)
+	if added {
+		return HashMap{root, m.size + 1}
+	}
+	return HashMap{root, m.size}
+}
+
+// persistent maps are never changed in place. delete removes nothing and
+// returns false, to satisfy Mapped. use Without to derive a version of the
+// map without the field.
+func (m HashMap) Delete(acc Native) bool { return false }
+
+// returns a new version of the map without the field indexed by the key
+// passed and true, or the unchanged map and false, if there is no such field.
+func (m HashMap) Without(acc Native) (HashMap, bool) {
+	var removed bool
+	var root = m.root.delete(nil, acc, Hash(acc), 0, &removed)
+	if !removed {
+		return m, false
+	}
+	return HashMap{root, m.size - 1}, true
+}
+
+// returns a transient builder, initialized with the fields of the map
+func (m HashMap) Builder() *HashMapBuilder {
+	return &HashMapBuilder{m.root, m.size, &hamtEdit{}}
+}
+
+//// TRANSIENT BUILDER
+///
+// builder mutates the nodes it created in place, which makes bulk loading
+// cheap. nodes shared with persistent maps are copied before they get
+// changed. persistent yields the current state as immutable map, further
+// changes made by the builder don't affect maps returned before.
+type HashMapBuilder struct {
+	root *hamtNode
+	size int
+	edit *hamtEdit
+}
+
+func NewHashMapBuilder() *HashMapBuilder {
+	return &HashMapBuilder{edit: &hamtEdit{}}
+}
+
+func (b *HashMapBuilder) Len() int { return b.size }
+
+func (b *HashMapBuilder) Has(acc Native) bool {
+	var _, ok = b.Get(acc)
+	return ok
+}
+
+func (b *HashMapBuilder) Get(acc Native) (Native, bool) {
+	return b.root.get(acc, Hash(acc), 0)
+}
+
+func (b *HashMapBuilder) Set(acc Native, dat Native) *HashMapBuilder {
+	var added bool
+	b.root = b.root.set(b.edit, acc, dat, Hash(acc), 0, &added)
+	if added {
+		b.size += 1
+	}
+	return b
+}
+
+func (b *HashMapBuilder) Delete(acc Native) bool {
+	var removed bool
+	b.root = b.root.delete(b.edit, acc, Hash(acc), 0, &removed)
+	if removed {
+		b.size -= 1
+	}
+	return removed
+}
+
+func (b *HashMapBuilder) Persistent() HashMap {
+	b.edit = &hamtEdit{}
+	return HashMap{b.root, b.size}
+}
+
+//// TRIE NODES
+///
+func hamtIndex(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & hamtMask)
+}
+
+func (n *hamtNode) position(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// returns the node itself, if it is owned by the edit token, or a copy
+// owned by it otherwise.
+func (n *hamtNode) editable(edit *hamtEdit) *hamtNode {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	var slots = make([]hamtSlot, len(n.slots), len(n.slots)+1)
+	copy(slots, n.slots)
+	return &hamtNode{n.bitmap, slots, n.collision, edit}
+}
+
+func (n *hamtNode) each(fn func(hamtSlot)) {
+	if n == nil {
+		return
+	}
+	for _, s := range n.slots {
+		if s.node != nil {
+			s.node.each(fn)
+			continue
+		}
+		fn(s)
+	}
+}
+
+func (n *hamtNode) get(key Native, hash uint64, shift uint) (Native, bool) {
+	for n != nil {
+		if n.collision {
+			for _, s := range n.slots {
+				if DeepEqual(s.key, key) {
+					return s.val, true
+				}
+			}
+			return nil, false
+		}
+		var bit = hamtIndex(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+		var s = n.slots[n.position(bit)]
+		if s.node == nil {
+			if s.hash == hash && DeepEqual(s.key, key) {
+				return s.val, true
+			}
+			return nil, false
+		}
+		n, shift = s.node, shift+hamtBits
+	}
+	return nil, false
+}
+
+func (n *hamtNode) set(
+	edit *hamtEdit,
+	key, val Native,
+	hash uint64,
+	shift uint,
+	added *bool,
+) *hamtNode {
+	var leaf = hamtSlot{key: key, val: val, hash: hash}
+	if n == nil {
+		*added = true
+		return &hamtNode{
+			bitmap: hamtIndex(hash, shift),
+			slots:  []hamtSlot{leaf},
+			edit:   edit,
+		}
+	}
+	if n.collision {
+		for i, s := range n.slots {
+			if DeepEqual(s.key, key) {
+				var c = n.editable(edit)
+				c.slots[i] = leaf
+				return c
+			}
+		}
+		*added = true
+		var c = n.editable(edit)
+		c.slots = append(c.slots, leaf)
+		return c
+	}
+	var bit = hamtIndex(hash, shift)
+	var pos = n.position(bit)
+	if n.bitmap&bit == 0 {
+		*added = true
+		var c = n.editable(edit)
+		c.bitmap |= bit
+		c.slots = append(c.slots, hamtSlot{})
+		copy(c.slots[pos+1:], c.slots[pos:])
+		c.slots[pos] = leaf
+		return c
+	}
+	var s = n.slots[pos]
+	if s.node != nil {
+		var node = s.node.set(edit, key, val, hash, shift+hamtBits, added)
+		if node == s.node {
+			return n
+		}
+		var c = n.editable(edit)
+		c.slots[pos] = hamtSlot{node: node}
+		return c
+	}
+	var c = n.editable(edit)
+	if s.hash == hash && DeepEqual(s.key, key) {
+		c.slots[pos] = leaf
+		return c
+	}
+	*added = true
+	c.slots[pos] = hamtSlot{node: mergeLeaves(edit, s, leaf, shift+hamtBits)}
+	return c
+}
+
+// creates the sub trie, containing both leaves, that share the hash prefix
+// consumed by the levels above.
+func mergeLeaves(edit *hamtEdit, a, b hamtSlot, shift uint) *hamtNode {
+	if shift >= hamtDepth {
+		return &hamtNode{slots: []hamtSlot{a, b}, collision: true, edit: edit}
+	}
+	var ba, bb = hamtIndex(a.hash, shift), hamtIndex(b.hash, shift)
+	if ba == bb {
+		return &hamtNode{
+			bitmap: ba,
+			slots:  []hamtSlot{{node: mergeLeaves(edit, a, b, shift+hamtBits)}},
+			edit:   edit,
+		}
+	}
+	if bb < ba {
+		a, b = b, a
+	}
+	return &hamtNode{bitmap: ba | bb, slots: []hamtSlot{a, b}, edit: edit}
+}
+
+// returns nil, when the last field has been removed from the node. sub
+// nodes left with a single field are replaced by that field.
+func (n *hamtNode) delete(
+	edit *hamtEdit,
+	key Native,
+	hash uint64,
+	shift uint,
+	removed *bool,
+) *hamtNode {
+	if n == nil {
+		return nil
+	}
+	if n.collision {
+		for i, s := range n.slots {
+			if DeepEqual(s.key, key) {
+				*removed = true
+				if len(n.slots) == 1 {
+					return nil
+				}
+				var c = n.editable(edit)
+				c.slots = append(c.slots[:i], c.slots[i+1:]...)
+				return c
+			}
+		}
+		return n
+	}
+	var bit = hamtIndex(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+	var pos = n.position(bit)
+	var s = n.slots[pos]
+	if s.node == nil {
+		if s.hash != hash || !DeepEqual(s.key, key) {
+			return n
+		}
+		*removed = true
+		return n.without(edit, bit, pos)
+	}
+	var node = s.node.delete(edit, key, hash, shift+hamtBits, removed)
+	if !*removed {
+		return n
+	}
+	if node == nil {
+		return n.without(edit, bit, pos)
+	}
+	var c = n.editable(edit)
+	if len(node.slots) == 1 && node.slots[0].node == nil {
+		c.slots[pos] = node.slots[0]
+	} else {
+		c.slots[pos] = hamtSlot{node: node}
+	}
+	return c
+}
+
+func (n *hamtNode) without(edit *hamtEdit, bit uint32, pos int) *hamtNode {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	var c = n.editable(edit)
+	c.bitmap &^= bit
+	c.slots = append(c.slots[:pos], c.slots[pos+1:]...)
+	return c
+}
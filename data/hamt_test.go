@@ -0,0 +1,173 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestHashMapPersistence(t *testing.T) {
+	var m = NewHashMap(
+		NewPair(StrVal("one"), IntVal(1)),
+		NewPair(StrVal("two"), IntVal(2)),
+	)
+	var n = m.Set(StrVal("three"), IntVal(3))
+	fmt.Println(m, n)
+	if m.Len() != 2 || n.Len() != 3 || m.Has(StrVal("three")) {
+		t.Log("set must not change the version it derived from", m, n)
+		t.Fail()
+	}
+	var o = n.Set(StrVal("one"), IntVal(10))
+	if v, _ := n.Get(StrVal("one")); v != IntVal(1) {
+		t.Log("replacing a value changed the previous version", n)
+		t.Fail()
+	}
+	if v, _ := o.Get(StrVal("one")); v != IntVal(10) || o.Len() != 3 {
+		t.Log("expected value to be replaced", o)
+		t.Fail()
+	}
+	var p, ok = o.(HashMap).Without(StrVal("two"))
+	fmt.Println(o, p)
+	if !ok || p.Len() != 2 || p.Has(StrVal("two")) || !o.Has(StrVal("two")) {
+		t.Log("delete must not change the version it derived from", o, p)
+		t.Fail()
+	}
+	if q, ok := p.Without(StrVal("none")); ok || q.Len() != 2 {
+		t.Log("deleting a missing key should yield the unchanged map", q)
+		t.Fail()
+	}
+	if p.Delete(StrVal("one")) || !p.Has(StrVal("one")) {
+		t.Log("persistent maps should not be changed in place", p)
+		t.Fail()
+	}
+}
+
+func TestHashMapMany(t *testing.T) {
+	var versions = []Mapped{NewHashMap()}
+	for i := 0; i < 2000; i++ {
+		var m = versions[len(versions)-1].Set(IntVal(i), StrVal(fmt.Sprint(i)))
+		versions = append(versions, m)
+	}
+	for n, m := range versions {
+		if m.Len() != n {
+			t.Log("version has wrong length", n, m.Len())
+			t.Fail()
+			return
+		}
+	}
+	var m = versions[len(versions)-1]
+	for i := 0; i < 2000; i++ {
+		if v, ok := m.Get(IntVal(i)); !ok || v != StrVal(fmt.Sprint(i)) {
+			t.Log("missing key", i)
+			t.Fail()
+		}
+	}
+	if m.Has(IntVal(2000)) || versions[1000].Has(IntVal(1000)) {
+		t.Log("found key, that has not been set")
+		t.Fail()
+	}
+	for i := 0; i < 2000; i += 2 {
+		m, _ = m.(HashMap).Without(IntVal(i))
+	}
+	if m.Len() != 1000 || m.Has(IntVal(10)) || !m.Has(IntVal(11)) {
+		t.Log("delete failed", m.Len())
+		t.Fail()
+	}
+	if len(m.Fields()) != 1000 || len(m.Keys()) != 1000 {
+		t.Log("fields and keys should match length", len(m.Fields()))
+		t.Fail()
+	}
+}
+
+func TestHashMapCollisions(t *testing.T) {
+	// force keys onto the same path through the trie by passing a constant
+	// hash to the nodes directly.
+	var root *hamtNode
+	var added bool
+	for i := 0; i < 4; i++ {
+		root = root.set(nil, IntVal(i), IntVal(i*i), 42, 0, &added)
+	}
+	var m = HashMap{root, 4}
+	fmt.Println(m)
+	for i := 0; i < 4; i++ {
+		if v, ok := root.get(IntVal(i), 42, 0); !ok || v != IntVal(i*i) {
+			t.Log("colliding key not found", i)
+			t.Fail()
+		}
+	}
+	var removed bool
+	var n = root
+	for i := 0; i < 3; i++ {
+		removed = false
+		n = n.delete(nil, IntVal(i), 42, 0, &removed)
+		if !removed {
+			t.Log("colliding key not removed", i)
+			t.Fail()
+		}
+	}
+	// remaining leaf should be collapsed into the root
+	if len(n.slots) != 1 || n.slots[0].node != nil {
+		t.Log("single remaining leaf should be collapsed into root", n.slots)
+		t.Fail()
+	}
+	if _, ok := root.get(IntVal(0), 42, 0); !ok {
+		t.Log("delete changed the previous version")
+		t.Fail()
+	}
+}
+
+func TestHashMapBuilder(t *testing.T) {
+	var b = NewHashMapBuilder()
+	for i := 0; i < 500; i++ {
+		b.Set(IntVal(i), BoolVal(i%2 == 0))
+	}
+	var m = b.Persistent()
+	b.Set(IntVal(500), BoolVal(true))
+	b.Delete(IntVal(0))
+	fmt.Println(m.Len(), b.Len())
+	if m.Len() != 500 || !m.Has(IntVal(0)) || m.Has(IntVal(500)) {
+		t.Log("builder changed map returned by persistent", m.Len())
+		t.Fail()
+	}
+	if b.Len() != 500 || b.Has(IntVal(0)) || !b.Has(IntVal(500)) {
+		t.Log("builder lost changes", b.Len())
+		t.Fail()
+	}
+	var c = m.Builder()
+	c.Delete(IntVal(1))
+	if !m.Has(IntVal(1)) || c.Has(IntVal(1)) {
+		t.Log("builder derived from map must not change it")
+		t.Fail()
+	}
+}
+
+func TestHashMapSerialization(t *testing.T) {
+	var m = NewHashMap(
+		NewPair(StrVal("one"), IntVal(1)),
+		NewPair(StrVal("list"), NewSlice(StrVal("a"))),
+	).(HashMap)
+	var buf, err = json.Marshal(m)
+	fmt.Println(string(buf))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var decoded HashMap
+	if err = json.Unmarshal(buf, &decoded); err != nil || !DeepEqual(m, decoded) {
+		t.Log("expected hash map to survive json round trip", decoded, err)
+		t.Fail()
+	}
+	var keyed = m.Set(NewSlice(IntVal(1), IntVal(2)), BytesVal("x"))
+	var stream = bytes.NewBuffer([]byte{})
+	if err = NewEncoder(stream).Encode(keyed); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var nat Native
+	nat, err = NewDecoder(stream).Decode()
+	if _, ok := nat.(HashMap); !ok || err != nil || !DeepEqual(keyed, nat) {
+		t.Log("expected hash map to be decoded as hash map", nat, err)
+		t.Fail()
+	}
+}
@@ -43,6 +43,11 @@ type BinaryMarshaler interface {
 	MarshalBinary() ([]byte, error)
 }
 
+// implemented by pointers to natives, decoding binary encoded instances
+type BinaryUnmarshaler interface {
+	UnmarshalBinary([]byte) error
+}
+
 // deep copy
 type Reproduceable interface {
 	Copy() Native
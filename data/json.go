@@ -0,0 +1,591 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//// JSON SERIALIZATION
+///
+// numbers, bools and strings map to their json counterparts. big integers
+// and floats are written as number literals of full precision, ratios as
+// strings like "1/3", imaginary numbers as two element array of real and
+// imaginary part, durations as strings like "3m0s", pairs as two element
+// array and errors as their message.
+func (v NilVal) MarshalJSON() ([]byte, error)    { return []byte("null"), nil }
+func (v BoolVal) MarshalJSON() ([]byte, error)   { return json.Marshal(bool(v)) }
+func (v IntVal) MarshalJSON() ([]byte, error)    { return json.Marshal(int(v)) }
+func (v Int8Val) MarshalJSON() ([]byte, error)   { return json.Marshal(int8(v)) }
+func (v Int16Val) MarshalJSON() ([]byte, error)  { return json.Marshal(int16(v)) }
+func (v Int32Val) MarshalJSON() ([]byte, error)  { return json.Marshal(int32(v)) }
+func (v UintVal) MarshalJSON() ([]byte, error)   { return json.Marshal(uint(v)) }
+func (v Uint8Val) MarshalJSON() ([]byte, error)  { return json.Marshal(uint8(v)) }
+func (v Uint16Val) MarshalJSON() ([]byte, error) { return json.Marshal(uint16(v)) }
+func (v Uint32Val) MarshalJSON() ([]byte, error) { return json.Marshal(uint32(v)) }
+func (v FltVal) MarshalJSON() ([]byte, error)    { return json.Marshal(float64(v)) }
+func (v Flt32Val) MarshalJSON() ([]byte, error)  { return json.Marshal(float32(v)) }
+func (v ByteVal) MarshalJSON() ([]byte, error)   { return json.Marshal(byte(v)) }
+func (v BitFlag) MarshalJSON() ([]byte, error)   { return json.Marshal(uint(v)) }
+func (v StrVal) MarshalJSON() ([]byte, error)    { return json.Marshal(string(v)) }
+func (v BytesVal) MarshalJSON() ([]byte, error)  { return json.Marshal([]byte(v)) }
+func (v RuneVal) MarshalJSON() ([]byte, error)   { return json.Marshal(string(v)) }
+func (v TimeVal) MarshalJSON() ([]byte, error)   { return time.Time(v).MarshalJSON() }
+func (v DuraVal) MarshalJSON() ([]byte, error)   { return json.Marshal(v.String()) }
+func (v PairVal) MarshalJSON() ([]byte, error)   { return json.Marshal([]Native{v.L, v.R}) }
+func (v BigIntVal) MarshalJSON() ([]byte, error) { return (*big.Int)(&v).MarshalJSON() }
+func (v RatioVal) MarshalJSON() ([]byte, error)  { return json.Marshal(v.String()) }
+func (v BigFltVal) MarshalJSON() ([]byte, error) {
+	var f = (*big.Float)(&v)
+	if f.IsInf() {
+		return nil, fmt.Errorf("unsupported big float value: %s", f.String())
+	}
+	return []byte(f.Text('g', -1)), nil
+}
+func (v DecimalVal) MarshalJSON() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+func (v ImagVal) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{real(v), imag(v)})
+}
+func (v Imag64Val) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float32{real(v), imag(v)})
+}
+func (v ErrorVal) MarshalJSON() ([]byte, error) {
+	if v.E == nil {
+		return []byte("null"), nil
+	}
+	return marshalJSONError(v.E)
+}
+
+// slices and maps of natives delegate to the json methods of their elements.
+// generic maps use the string representation of their keys.
+func (v DataSlice) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Native(v))
+}
+func (s MapString) MarshalJSON() ([]byte, error) {
+	var m = make(map[string]Native, len(s))
+	for k, v := range s {
+		m[string(k)] = v
+	}
+	return json.Marshal(m)
+}
+func (s MapVal) MarshalJSON() ([]byte, error) {
+	var m = make(map[string]Native, len(s))
+	for k, v := range s {
+		m[k.String()] = v
+	}
+	return json.Marshal(m)
+}
+
+// persistent maps are written as objects, keyed by the string
+// representation of their keys, in the order of their fields.
+func (m HashMap) MarshalJSON() ([]byte, error)    { return marshalJSONFields(m.Fields()) }
+func (m OrderedMap) MarshalJSON() ([]byte, error) { return marshalJSONFields(m.Fields()) }
+
+// vectors and sorted sets are written as arrays, sorted sets in ascending
+// order.
+func (v Vector) MarshalJSON() ([]byte, error)    { return DataSlice(v.Slice()).MarshalJSON() }
+func (s SortedSet) MarshalJSON() ([]byte, error) { return DataSlice(s.Slice()).MarshalJSON() }
+
+func marshalJSONFields(fields []Paired) ([]byte, error) {
+	var buf = bytes.NewBufferString("{")
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		var key, err = json.Marshal(field.Left().String())
+		if err != nil {
+			return nil, err
+		}
+		var val []byte
+		if val, err = json.Marshal(field.Right()); err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unboxed vectors of go types json knows about are marshaled directly, all
+// others are marshaled element wise.
+func (v BoolVec) MarshalJSON() ([]byte, error)   { return json.Marshal([]bool(v)) }
+func (v IntVec) MarshalJSON() ([]byte, error)    { return json.Marshal([]int(v)) }
+func (v Int8Vec) MarshalJSON() ([]byte, error)   { return json.Marshal([]int8(v)) }
+func (v Int16Vec) MarshalJSON() ([]byte, error)  { return json.Marshal([]int16(v)) }
+func (v Int32Vec) MarshalJSON() ([]byte, error)  { return json.Marshal([]int32(v)) }
+func (v UintVec) MarshalJSON() ([]byte, error)   { return json.Marshal([]uint(v)) }
+func (v Uint16Vec) MarshalJSON() ([]byte, error) { return json.Marshal([]uint16(v)) }
+func (v Uint32Vec) MarshalJSON() ([]byte, error) { return json.Marshal([]uint32(v)) }
+func (v FltVec) MarshalJSON() ([]byte, error)    { return json.Marshal([]float64(v)) }
+func (v Flt32Vec) MarshalJSON() ([]byte, error)  { return json.Marshal([]float32(v)) }
+func (v StrVec) MarshalJSON() ([]byte, error)    { return json.Marshal([]string(v)) }
+func (v BytesVec) MarshalJSON() ([]byte, error)  { return json.Marshal([][]byte(v)) }
+func (v BigIntVec) MarshalJSON() ([]byte, error) { return json.Marshal([]*big.Int(v)) }
+func (v TimeVec) MarshalJSON() ([]byte, error)   { return json.Marshal([]time.Time(v)) }
+func (v FlagSet) MarshalJSON() ([]byte, error)   { return json.Marshal([]BitFlag(v)) }
+func (v ByteVec) MarshalJSON() ([]byte, error)   { return json.Marshal([]byte(v)) }
+func (v Uint8Vec) MarshalJSON() ([]byte, error)  { return json.Marshal(v.Slice()) }
+func (v NilVec) MarshalJSON() ([]byte, error)    { return json.Marshal(v.Slice()) }
+func (v RuneVec) MarshalJSON() ([]byte, error)   { return json.Marshal(v.Slice()) }
+func (v ImagVec) MarshalJSON() ([]byte, error)   { return json.Marshal(v.Slice()) }
+func (v Imag64Vec) MarshalJSON() ([]byte, error) { return json.Marshal(v.Slice()) }
+func (v BigFltVec) MarshalJSON() ([]byte, error) { return json.Marshal(v.Slice()) }
+func (v RatioVec) MarshalJSON() ([]byte, error)  { return json.Marshal(v.Slice()) }
+func (v DuraVec) MarshalJSON() ([]byte, error)   { return json.Marshal(v.Slice()) }
+func (v ErrorVec) MarshalJSON() ([]byte, error)  { return json.Marshal(v.Slice()) }
+
+//// JSON DESERIALIZATION
+///
+// decodes a json document as tree of natives. objects are decoded as
+// MapString, arrays as DataSlice. numbers are decoded as IntVal, if they
+// are integral and fit into an int, as BigIntVal if they are integral and
+// don't, as FltVal otherwise. data following the document yields an error.
+func UnmarshalJSONNative(buf []byte) (Native, error) {
+	var dec = json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after json value in %s", buf)
+	}
+	return jsonToNative(val)
+}
+
+func jsonToNative(val interface{}) (Native, error) {
+	switch v := val.(type) {
+	case nil:
+		return NilVal{}, nil
+	case bool:
+		return BoolVal(v), nil
+	case string:
+		return StrVal(v), nil
+	case json.Number:
+		return jsonNumber(v)
+	case []interface{}:
+		var slice = make(DataSlice, 0, len(v))
+		for _, elem := range v {
+			var nat, err = jsonToNative(elem)
+			if err != nil {
+				return nil, err
+			}
+			slice = append(slice, nat)
+		}
+		return slice, nil
+	case map[string]interface{}:
+		var m = make(MapString, len(v))
+		for key, elem := range v {
+			var nat, err = jsonToNative(elem)
+			if err != nil {
+				return nil, err
+			}
+			m[StrVal(key)] = nat
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("unexpected json value %v", val)
+}
+
+// picks the native number type by the precision the literal requires
+func jsonNumber(num json.Number) (Native, error) {
+	var str = num.String()
+	if !strings.ContainsAny(str, ".eE") {
+		if i, err := num.Int64(); err == nil {
+			return IntVal(i), nil
+		}
+		if i, ok := new(big.Int).SetString(str, 10); ok {
+			return BigIntVal(*i), nil
+		}
+	}
+	var f, err = num.Float64()
+	if err != nil {
+		return nil, err
+	}
+	return FltVal(f), nil
+}
+
+func (v *NilVal) UnmarshalJSON(buf []byte) error {
+	if string(bytes.TrimSpace(buf)) != "null" {
+		return fmt.Errorf("expected null, got %s", buf)
+	}
+	*v = NilVal{}
+	return nil
+}
+func (v *BoolVal) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*bool)(v)) }
+func (v *IntVal) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*int)(v)) }
+func (v *Int8Val) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*int8)(v)) }
+func (v *Int16Val) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*int16)(v)) }
+func (v *Int32Val) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*int32)(v)) }
+func (v *UintVal) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*uint)(v)) }
+func (v *Uint8Val) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*uint8)(v)) }
+func (v *Uint16Val) UnmarshalJSON(buf []byte) error { return json.Unmarshal(buf, (*uint16)(v)) }
+func (v *Uint32Val) UnmarshalJSON(buf []byte) error { return json.Unmarshal(buf, (*uint32)(v)) }
+func (v *FltVal) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*float64)(v)) }
+func (v *Flt32Val) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*float32)(v)) }
+func (v *ByteVal) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*byte)(v)) }
+func (v *BitFlag) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*uint)(v)) }
+func (v *StrVal) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*string)(v)) }
+func (v *BytesVal) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*[]byte)(v)) }
+func (v *TimeVal) UnmarshalJSON(buf []byte) error   { return (*time.Time)(v).UnmarshalJSON(buf) }
+func (v *BigIntVal) UnmarshalJSON(buf []byte) error { return (*big.Int)(v).UnmarshalJSON(buf) }
+func (v *RuneVal) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(str) != 1 {
+		return fmt.Errorf("expected a single rune, got %q", str)
+	}
+	var r, _ = utf8.DecodeRuneInString(str)
+	*v = RuneVal(r)
+	return nil
+}
+
+// durations are accepted as string, or as number of nanoseconds
+func (v *DuraVal) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return json.Unmarshal(buf, (*int64)(v))
+	}
+	var d, err = time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*v = DuraVal(d)
+	return nil
+}
+
+// ratios are accepted as string, or as number literal
+func (v *RatioVal) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		str = string(bytes.TrimSpace(buf))
+	}
+	if _, ok := (*big.Rat)(v).SetString(str); !ok {
+		return fmt.Errorf("can not decode %s as ratio", buf)
+	}
+	return nil
+}
+
+// decimals are accepted as number literal, or as string
+func (v *DecimalVal) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		str = string(bytes.TrimSpace(buf))
+	}
+	var d, err = ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+	*v = d
+	return nil
+}
+
+// big floats are accepted as number literal, or as string
+func (v *BigFltVal) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		str = string(bytes.TrimSpace(buf))
+	}
+	return (*big.Float)(v).UnmarshalText([]byte(str))
+}
+
+func (v *ImagVal) UnmarshalJSON(buf []byte) error {
+	var parts []float64
+	if err := json.Unmarshal(buf, &parts); err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("expected real and imaginary part, got %s", buf)
+	}
+	*v = ImagVal(complex(parts[0], parts[1]))
+	return nil
+}
+
+func (v *Imag64Val) UnmarshalJSON(buf []byte) error {
+	var parts []float32
+	if err := json.Unmarshal(buf, &parts); err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("expected real and imaginary part, got %s", buf)
+	}
+	*v = Imag64Val(complex(parts[0], parts[1]))
+	return nil
+}
+
+func (v *ErrorVal) UnmarshalJSON(buf []byte) error {
+	var err, e = unmarshalJSONError(buf, false)
+	if e != nil {
+		return e
+	}
+	*v = ErrorVal{err}
+	return nil
+}
+
+func (v *PairVal) UnmarshalJSON(buf []byte) error {
+	var nat, err = UnmarshalJSONNative(buf)
+	if err != nil {
+		return err
+	}
+	var slice, ok = nat.(DataSlice)
+	if !ok || len(slice) != 2 {
+		return fmt.Errorf("expected two element array, got %s", buf)
+	}
+	*v = PairVal{slice[0], slice[1]}
+	return nil
+}
+
+func (v *DataSlice) UnmarshalJSON(buf []byte) error {
+	var nat, err = UnmarshalJSONNative(buf)
+	if err != nil {
+		return err
+	}
+	var slice, ok = nat.(DataSlice)
+	if !ok {
+		return fmt.Errorf("expected json array, got %s", buf)
+	}
+	*v = slice
+	return nil
+}
+
+func (s *MapString) UnmarshalJSON(buf []byte) error {
+	var nat, err = UnmarshalJSONNative(buf)
+	if err != nil {
+		return err
+	}
+	var m, ok = nat.(MapString)
+	if !ok {
+		return fmt.Errorf("expected json object, got %s", buf)
+	}
+	*s = m
+	return nil
+}
+
+// generic maps decode their keys as StrVal
+func (s *MapVal) UnmarshalJSON(buf []byte) error {
+	var m MapString
+	if err := m.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	*s = make(MapVal, len(m))
+	for k, v := range m {
+		(*s)[k] = v
+	}
+	return nil
+}
+
+// persistent maps decode their keys as StrVal
+func (m *HashMap) UnmarshalJSON(buf []byte) error {
+	var s MapString
+	if err := s.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	var b = NewHashMapBuilder()
+	for k, v := range s {
+		b.Set(k, v)
+	}
+	*m = b.Persistent()
+	return nil
+}
+
+func (m *OrderedMap) UnmarshalJSON(buf []byte) error {
+	var s MapString
+	if err := s.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	var o = OrderedMap{}
+	for k, v := range s {
+		o = o.set(k, v)
+	}
+	*m = o
+	return nil
+}
+
+func (v *Vector) UnmarshalJSON(buf []byte) error {
+	var slice DataSlice
+	if err := slice.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	*v = NewVector(slice...)
+	return nil
+}
+
+func (s *SortedSet) UnmarshalJSON(buf []byte) error {
+	var slice DataSlice
+	if err := slice.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	*s = NewSortedSet(slice...)
+	return nil
+}
+
+func (v *BoolVec) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]bool)(v)) }
+func (v *IntVec) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*[]int)(v)) }
+func (v *Int8Vec) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]int8)(v)) }
+func (v *Int16Vec) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*[]int16)(v)) }
+func (v *Int32Vec) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*[]int32)(v)) }
+func (v *UintVec) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]uint)(v)) }
+func (v *Uint16Vec) UnmarshalJSON(buf []byte) error { return json.Unmarshal(buf, (*[]uint16)(v)) }
+func (v *Uint32Vec) UnmarshalJSON(buf []byte) error { return json.Unmarshal(buf, (*[]uint32)(v)) }
+func (v *FltVec) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*[]float64)(v)) }
+func (v *Flt32Vec) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*[]float32)(v)) }
+func (v *StrVec) UnmarshalJSON(buf []byte) error    { return json.Unmarshal(buf, (*[]string)(v)) }
+func (v *BytesVec) UnmarshalJSON(buf []byte) error  { return json.Unmarshal(buf, (*[][]byte)(v)) }
+func (v *BigIntVec) UnmarshalJSON(buf []byte) error { return json.Unmarshal(buf, (*[]*big.Int)(v)) }
+func (v *TimeVec) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]time.Time)(v)) }
+func (v *FlagSet) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]BitFlag)(v)) }
+func (v *ByteVec) UnmarshalJSON(buf []byte) error   { return json.Unmarshal(buf, (*[]byte)(v)) }
+
+func (v *Uint8Vec) UnmarshalJSON(buf []byte) error {
+	var vec = Uint8Vec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var u Uint8Val
+		var err = u.UnmarshalJSON(elem)
+		vec = append(vec, uint8(u))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *NilVec) UnmarshalJSON(buf []byte) error {
+	var vec = NilVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var n NilVal
+		var err = n.UnmarshalJSON(elem)
+		vec = append(vec, struct{}{})
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *RuneVec) UnmarshalJSON(buf []byte) error {
+	var vec = RuneVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var r RuneVal
+		var err = r.UnmarshalJSON(elem)
+		vec = append(vec, rune(r))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *ImagVec) UnmarshalJSON(buf []byte) error {
+	var vec = ImagVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var i ImagVal
+		var err = i.UnmarshalJSON(elem)
+		vec = append(vec, complex128(i))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *Imag64Vec) UnmarshalJSON(buf []byte) error {
+	var vec = Imag64Vec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var i Imag64Val
+		var err = i.UnmarshalJSON(elem)
+		vec = append(vec, complex64(i))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *BigFltVec) UnmarshalJSON(buf []byte) error {
+	var vec = BigFltVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var f BigFltVal
+		var err = f.UnmarshalJSON(elem)
+		vec = append(vec, (*big.Float)(&f))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *RatioVec) UnmarshalJSON(buf []byte) error {
+	var vec = RatioVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var r RatioVal
+		var err = r.UnmarshalJSON(elem)
+		vec = append(vec, (*big.Rat)(&r))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *DuraVec) UnmarshalJSON(buf []byte) error {
+	var vec = DuraVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var d DuraVal
+		var err = d.UnmarshalJSON(elem)
+		vec = append(vec, time.Duration(d))
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+func (v *ErrorVec) UnmarshalJSON(buf []byte) error {
+	var vec = ErrorVec{}
+	if err := unmarshalJSONElems(buf, func(elem []byte) error {
+		var e ErrorVal
+		var err = e.UnmarshalJSON(elem)
+		vec = append(vec, e.E)
+		return err
+	}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// calls the function passed on the raw encoding of every array element
+func unmarshalJSONElems(buf []byte, fn func(elem []byte) error) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(buf, &elems); err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if err := fn(elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
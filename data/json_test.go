@@ -0,0 +1,189 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestJSONScalarRoundTrip(t *testing.T) {
+	for _, nat := range marshalNatives {
+		if nat.Type().Match(Map | Slice | Pair) {
+			continue
+		}
+		buf, err := json.Marshal(nat)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		var ptr = newNullPtr(nat)
+		if err := json.Unmarshal(buf, ptr); err != nil {
+			t.Log(nat.Type(), err)
+			t.Fail()
+			continue
+		}
+		fmt.Printf("%s: %s → %s\n", nat.Type(), nat, buf)
+		if dec := derefNative(ptr.(BinaryUnmarshaler)); dec.String() != nat.String() {
+			t.Log("value mismatch", nat, dec)
+			t.Fail()
+		}
+	}
+}
+
+// yields a pointer to a zero instance of the type of the native passed
+func newNullPtr(nat Native) json.Unmarshaler {
+	switch nat.(type) {
+	case NilVal:
+		return new(NilVal)
+	case BoolVal:
+		return new(BoolVal)
+	case Int8Val:
+		return new(Int8Val)
+	case Int16Val:
+		return new(Int16Val)
+	case Int32Val:
+		return new(Int32Val)
+	case IntVal:
+		return new(IntVal)
+	case BigIntVal:
+		return new(BigIntVal)
+	case Uint8Val:
+		return new(Uint8Val)
+	case Uint16Val:
+		return new(Uint16Val)
+	case Uint32Val:
+		return new(Uint32Val)
+	case UintVal:
+		return new(UintVal)
+	case Flt32Val:
+		return new(Flt32Val)
+	case FltVal:
+		return new(FltVal)
+	case BigFltVal:
+		return new(BigFltVal)
+	case RatioVal:
+		return new(RatioVal)
+	case Imag64Val:
+		return new(Imag64Val)
+	case ImagVal:
+		return new(ImagVal)
+	case TimeVal:
+		return new(TimeVal)
+	case DuraVal:
+		return new(DuraVal)
+	case ByteVal:
+		return new(ByteVal)
+	case RuneVal:
+		return new(RuneVal)
+	case BitFlag:
+		return new(BitFlag)
+	case StrVal:
+		return new(StrVal)
+	case BytesVal:
+		return new(BytesVal)
+	case ErrorVal:
+		return new(ErrorVal)
+	}
+	return nil
+}
+
+func TestJSONVectors(t *testing.T) {
+	var vecs = []Native{
+		IntVec{1, 2, 3},
+		StrVec{"a", "b"},
+		RatioVec{big.NewRat(1, 3), big.NewRat(2, 3)},
+		DuraVec{3e9},
+		RuneVec{'ä', 'ö'},
+	}
+	for _, vec := range vecs {
+		buf, err := json.Marshal(vec)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		fmt.Printf("%s → %s\n", vec, buf)
+		var dec Native
+		switch vec.(type) {
+		case IntVec:
+			var v IntVec
+			err, dec = json.Unmarshal(buf, &v), v
+		case StrVec:
+			var v StrVec
+			err, dec = json.Unmarshal(buf, &v), v
+		case RatioVec:
+			var v RatioVec
+			err, dec = json.Unmarshal(buf, &v), v
+		case DuraVec:
+			var v DuraVec
+			err, dec = json.Unmarshal(buf, &v), v
+		case RuneVec:
+			var v RuneVec
+			err, dec = json.Unmarshal(buf, &v), v
+		}
+		if err != nil || dec.String() != vec.String() {
+			t.Log("vector mismatch", vec, dec, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestJSONDocument(t *testing.T) {
+	var doc = []byte(`{
+		"int": 42,
+		"big": 123456789012345678901234567890,
+		"float": 4.2,
+		"exp": 1e3,
+		"list": [true, null, "str", [1, 2]],
+		"nested": {"key": "value"}
+	}`)
+	nat, err := UnmarshalJSONNative(doc)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	fmt.Println(nat)
+	var m = nat.(MapString)
+	for key, typ := range map[string]TyNat{
+		"int":    Int,
+		"big":    BigInt,
+		"float":  Float,
+		"exp":    Float,
+		"list":   Slice,
+		"nested": Map,
+	} {
+		if val, ok := m.Get(StrVal(key)); !ok || val.Type() != typ {
+			t.Log("expected", key, "to decode as", typ, val)
+			t.Fail()
+		}
+	}
+	var list, _ = m.Get(StrVal("list"))
+	if list.(DataSlice)[1].Type() != Nil {
+		t.Log("null should decode as nil", list)
+		t.Fail()
+	}
+
+	buf, err := json.Marshal(MapVal{IntVal(1): NewSlice(StrVal("one"))})
+	if err != nil || string(buf) != `{"1":["one"]}` {
+		t.Log("unexpected encoding of generic map", string(buf), err)
+		t.Fail()
+	}
+	var mv MapVal
+	if err := json.Unmarshal(buf, &mv); err != nil || mv.Len() != 1 {
+		t.Log(err)
+		t.Fail()
+	}
+	for _, doc := range []string{"1 garbage", "[1] [2]", "{} }"} {
+		if nat, err := UnmarshalJSONNative([]byte(doc)); err == nil {
+			t.Log("expected trailing data to yield an error", doc, nat)
+			t.Fail()
+		}
+	}
+	if nat, err := UnmarshalJSONNative([]byte(" [1] \n")); err != nil {
+		t.Log("expected trailing white space to be accepted", nat, err)
+		t.Fail()
+	}
+}
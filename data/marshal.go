@@ -1,220 +1,892 @@
 package data
 
 import (
-	"bytes"
 	"encoding/binary"
+	"fmt"
+	"math"
 	"math/big"
+	"reflect"
 	"time"
 )
 
+//// BINARY SERIALIZATION
+///
+// scalar natives are serialized as varints, floats as their ieee 754 bit
+// pattern in little endian byte order, letters as raw bytes and big numbers,
+// as well as time, by the encoders provided by their go implementation.
+// composed natives, like pairs, slices and maps, prefix every element by it's
+// type flag and length, so that elements of mixed type can be decoded.
+
 // provide serialization for all native types.
 func (v BitFlag) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v)), nil
 }
 
 func (v FlagSlice) MarshalBinary() ([]byte, error) {
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size(v)))
-	err := binary.Write(buf, binary.LittleEndian, v)
-	if err != nil {
-		return nil, err
+	var buf = appendUvarint(nil, uint64(len(v)))
+	for _, flag := range v {
+		buf = appendUvarint(buf, uint64(flag))
 	}
-	return buf.Bytes(), err
+	return buf, nil
 }
 
 func (v PairVal) MarshalBinary() ([]byte, error) {
-	buf0, err0 := v.Left().(BinaryMarshaler).MarshalBinary()
-	if err0 != nil {
-		return nil, err0
-	}
-	buf1, err1 := v.Right().(BinaryMarshaler).MarshalBinary()
-	if err1 != nil {
-		return nil, err1
+	var buf, err = marshalElement(nil, v.Left())
+	if err != nil {
+		return nil, err
 	}
-	return append(buf0, buf1...), nil
+	return marshalElement(buf, v.Right())
 }
 
 func (v NilVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(0)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, 0), nil
 }
 
 func (v BoolVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v.GoUint())
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v.GoUint())), nil
 }
 
 func (v IntVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendVarint(nil, int64(v)), nil
 }
 
 func (v Int8Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendVarint(nil, int64(v)), nil
 }
 
 func (v Int16Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendVarint(nil, int64(v)), nil
 }
 
 func (v Int32Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendVarint(nil, int64(v)), nil
 }
 
 func (v UintVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v)), nil
 }
 
 func (v Uint8Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v)), nil
 }
 
 func (v Uint16Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v)), nil
 }
 
 func (v Uint32Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+	return appendUvarint(nil, uint64(v)), nil
 }
 
 func (v FltVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
+	var buf = make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, math.Float64bits(float64(v)))
 	return buf, nil
 }
 
 func (v Flt32Val) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
+	var buf = make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(v)))
 	return buf, nil
 }
 
 func (v ImagVal) MarshalBinary() ([]byte, error) {
-	var n, i = uint64(real(v)), uint64(imag(v))
-	var buf0 = make([]byte, 0, binary.Size(n))
-	var buf1 = make([]byte, 0, binary.Size(i))
-	binary.PutUvarint(buf0, n)
-	binary.PutUvarint(buf1, i)
-	return append(buf0, buf1...), nil
+	var buf = make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(real(v)))
+	binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(imag(v)))
+	return buf, nil
 }
 
 func (v Imag64Val) MarshalBinary() ([]byte, error) {
-	var n, i = uint64(real(v)), uint64(imag(v))
-	var buf0 = make([]byte, 0, binary.Size(n))
-	var buf1 = make([]byte, 0, binary.Size(i))
-	binary.PutUvarint(buf0, n)
-	binary.PutUvarint(buf1, i)
-	return append(buf0, buf1...), nil
+	var buf = make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf[:4], math.Float32bits(real(v)))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(imag(v)))
+	return buf, nil
 }
 
 func (v ByteVal) MarshalBinary() ([]byte, error) {
-	var buf = make([]byte, 0, binary.Size(v))
-	binary.PutUvarint(buf, uint64(v))
-	return buf, nil
+	return []byte{byte(v)}, nil
 }
 
 func (v BytesVal) MarshalBinary() ([]byte, error) {
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size(v)))
-	err := binary.Write(buf, binary.LittleEndian, v)
+	var buf = make([]byte, len(v))
+	copy(buf, v)
+	return buf, nil
+}
+
+func (v RuneVal) MarshalBinary() ([]byte, error) {
+	return appendVarint(nil, int64(v)), nil
+}
+
+func (v StrVal) MarshalBinary() ([]byte, error) {
+	return []byte(v), nil
+}
+
+func (v ErrorVal) MarshalBinary() ([]byte, error) {
+	if v.E == nil {
+		return []byte{}, nil
+	}
+	return marshalError(v.E)
+}
+
+func (v BigIntVal) MarshalBinary() ([]byte, error) {
+	return (*big.Int)(&v).GobEncode()
+}
+
+func (v BigFltVal) MarshalBinary() ([]byte, error) {
+	return (*big.Float)(&v).GobEncode()
+}
+
+func (v RatioVal) MarshalBinary() ([]byte, error) {
+	return (*big.Rat)(&v).GobEncode()
+}
+
+// decimals are encoded as signed varint scale, followed by the unscaled
+// integer.
+func (v DecimalVal) MarshalBinary() ([]byte, error) {
+	var buf, err = v.unscaled().GobEncode()
 	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), err
+	return append(appendVarint(nil, int64(v.scale)), buf...), nil
 }
 
-func (v RuneVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
+func (v TimeVal) MarshalBinary() ([]byte, error) {
+	return time.Time(v).MarshalBinary()
+}
+
+func (v DuraVal) MarshalBinary() ([]byte, error) {
+	return appendVarint(nil, int64(v)), nil
+}
+
+func (v DataSlice) MarshalBinary() ([]byte, error) {
+	var buf = appendUvarint(nil, uint64(len(v)))
+	var err error
+	for _, nat := range v {
+		if buf, err = marshalElement(buf, nat); err != nil {
+			return nil, err
+		}
+	}
 	return buf, nil
 }
 
-func (v StrVal) MarshalBinary() ([]byte, error) {
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size(v)))
-	err := binary.Write(buf, binary.LittleEndian, []byte(v))
+// maps are prefixed by the type of key they are indexed by, followed by the
+// number of fields and the tagged key & value of every field.
+func (s MapVal) MarshalBinary() ([]byte, error)    { return marshalMap(Map, s) }
+func (s MapString) MarshalBinary() ([]byte, error) { return marshalMap(String, s) }
+func (s MapInt) MarshalBinary() ([]byte, error)    { return marshalMap(Int, s) }
+func (s MapUint) MarshalBinary() ([]byte, error)   { return marshalMap(Uint, s) }
+func (s MapFloat) MarshalBinary() ([]byte, error)  { return marshalMap(Float, s) }
+func (s MapFlag) MarshalBinary() ([]byte, error)   { return marshalMap(Flag, s) }
+
+//// BINARY DESERIALIZATION
+///
+// unmarshal methods are bound to pointer receivers and replace the value
+// pointed to, by the decoded instance.
+func (v *BitFlag) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return buf.Bytes(), err
+	*v = BitFlag(u)
+	return nil
 }
 
-func (v ErrorVal) MarshalBinary() ([]byte, error) {
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size(v.String())))
-	err := binary.Write(buf, binary.LittleEndian, []byte(v.String()))
+func (v *FlagSlice) UnmarshalBinary(buf []byte) error {
+	var l, n = binary.Uvarint(buf)
+	// every flag takes at least one byte
+	if n <= 0 || l > uint64(len(buf)-n) {
+		return errMalformed(Flag | Slice)
+	}
+	buf = buf[n:]
+	var flags = make(FlagSlice, 0, l)
+	for i := uint64(0); i < l; i++ {
+		var u, n = binary.Uvarint(buf)
+		if n <= 0 {
+			return errMalformed(Flag | Slice)
+		}
+		buf = buf[n:]
+		flags = append(flags, BitFlag(u))
+	}
+	*v = flags
+	return nil
+}
+
+func (v *PairVal) UnmarshalBinary(buf []byte) error {
+	var left, right Native
+	var err error
+	if left, buf, err = unmarshalElement(buf); err != nil {
+		return err
+	}
+	if right, _, err = unmarshalElement(buf); err != nil {
+		return err
+	}
+	*v = PairVal{left, right}
+	return nil
+}
+
+func (v *NilVal) UnmarshalBinary(buf []byte) error {
+	*v = NilVal{}
+	return nil
+}
+
+func (v *BoolVal) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return buf.Bytes(), err
+	*v = BoolVal(u > 0)
+	return nil
 }
 
-func (v BigIntVal) MarshalBinary() ([]byte, error) {
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size((*big.Int)(&v).Bytes())))
-	err := binary.Write(buf, binary.LittleEndian, (*big.Int)(&v).Bytes())
+func (v *IntVal) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
+	if err != nil {
+		return err
+	}
+	*v = IntVal(i)
+	return nil
+}
+
+func (v *Int8Val) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
+	if err != nil {
+		return err
+	}
+	if i < math.MinInt8 || i > math.MaxInt8 {
+		return errOverflow(Int8, i)
+	}
+	*v = Int8Val(i)
+	return nil
+}
+
+func (v *Int16Val) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
+	if err != nil {
+		return err
+	}
+	if i < math.MinInt16 || i > math.MaxInt16 {
+		return errOverflow(Int16, i)
+	}
+	*v = Int16Val(i)
+	return nil
+}
+
+func (v *Int32Val) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
 	if err != nil {
+		return err
+	}
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return errOverflow(Int32, i)
+	}
+	*v = Int32Val(i)
+	return nil
+}
+
+func (v *UintVal) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
+	if err != nil {
+		return err
+	}
+	*v = UintVal(u)
+	return nil
+}
+
+func (v *Uint8Val) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
+	if err != nil {
+		return err
+	}
+	if u > math.MaxUint8 {
+		return errOverflow(Uint8, u)
+	}
+	*v = Uint8Val(u)
+	return nil
+}
+
+func (v *Uint16Val) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
+	if err != nil {
+		return err
+	}
+	if u > math.MaxUint16 {
+		return errOverflow(Uint16, u)
+	}
+	*v = Uint16Val(u)
+	return nil
+}
+
+func (v *Uint32Val) UnmarshalBinary(buf []byte) error {
+	var u, err = readUvarint(buf)
+	if err != nil {
+		return err
+	}
+	if u > math.MaxUint32 {
+		return errOverflow(Uint32, u)
+	}
+	*v = Uint32Val(u)
+	return nil
+}
+
+func (v *FltVal) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 8 {
+		return errMalformed(Float)
+	}
+	*v = FltVal(math.Float64frombits(binary.LittleEndian.Uint64(buf)))
+	return nil
+}
+
+func (v *Flt32Val) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 4 {
+		return errMalformed(Flt32)
+	}
+	*v = Flt32Val(math.Float32frombits(binary.LittleEndian.Uint32(buf)))
+	return nil
+}
+
+func (v *ImagVal) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 16 {
+		return errMalformed(Imag)
+	}
+	*v = ImagVal(complex(
+		math.Float64frombits(binary.LittleEndian.Uint64(buf[:8])),
+		math.Float64frombits(binary.LittleEndian.Uint64(buf[8:])),
+	))
+	return nil
+}
+
+func (v *Imag64Val) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 8 {
+		return errMalformed(Imag64)
+	}
+	*v = Imag64Val(complex(
+		math.Float32frombits(binary.LittleEndian.Uint32(buf[:4])),
+		math.Float32frombits(binary.LittleEndian.Uint32(buf[4:])),
+	))
+	return nil
+}
+
+func (v *ByteVal) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 1 {
+		return errMalformed(Byte)
+	}
+	*v = ByteVal(buf[0])
+	return nil
+}
+
+func (v *BytesVal) UnmarshalBinary(buf []byte) error {
+	var b = make([]byte, len(buf))
+	copy(b, buf)
+	*v = BytesVal(b)
+	return nil
+}
+
+func (v *RuneVal) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
+	if err != nil {
+		return err
+	}
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return errOverflow(Rune, i)
+	}
+	*v = RuneVal(i)
+	return nil
+}
+
+func (v *StrVal) UnmarshalBinary(buf []byte) error {
+	*v = StrVal(buf)
+	return nil
+}
+
+func (v *ErrorVal) UnmarshalBinary(buf []byte) error {
+	var err, e = unmarshalError(buf)
+	if e != nil {
+		return e
+	}
+	*v = ErrorVal{err}
+	return nil
+}
+
+func (v *BigIntVal) UnmarshalBinary(buf []byte) error {
+	return (*big.Int)(v).GobDecode(buf)
+}
+
+func (v *BigFltVal) UnmarshalBinary(buf []byte) error {
+	return (*big.Float)(v).GobDecode(buf)
+}
+
+func (v *RatioVal) UnmarshalBinary(buf []byte) error {
+	return (*big.Rat)(v).GobDecode(buf)
+}
+
+func (v *DecimalVal) UnmarshalBinary(buf []byte) error {
+	var scale, n = binary.Varint(buf)
+	if n <= 0 || scale < 0 || scale > MaxDecimalDigits {
+		return errMalformed(Decimal)
+	}
+	var i = new(big.Int)
+	if err := i.GobDecode(buf[n:]); err != nil {
+		return err
+	}
+	*v = DecimalVal{i, int(scale)}
+	return nil
+}
+
+func (v *TimeVal) UnmarshalBinary(buf []byte) error {
+	return (*time.Time)(v).UnmarshalBinary(buf)
+}
+
+func (v *DuraVal) UnmarshalBinary(buf []byte) error {
+	var i, err = readVarint(buf)
+	if err != nil {
+		return err
+	}
+	*v = DuraVal(i)
+	return nil
+}
+
+func (v *DataSlice) UnmarshalBinary(buf []byte) error {
+	var l, n = binary.Uvarint(buf)
+	// every element takes at least one byte
+	if n <= 0 || l > uint64(len(buf)-n) {
+		return errMalformed(Slice)
+	}
+	buf = buf[n:]
+	var slice = make(DataSlice, 0, l)
+	for i := uint64(0); i < l; i++ {
+		var nat Native
+		var err error
+		if nat, buf, err = unmarshalElement(buf); err != nil {
+			return err
+		}
+		slice = append(slice, nat)
+	}
+	*v = slice
+	return nil
+}
+
+// keys, that go can't hash, yield an error. UnmarshalNative decodes maps
+// containing them as hashed maps.
+func (s *MapVal) UnmarshalBinary(buf []byte) error {
+	var m = MapVal{}
+	if err := unmarshalMap(Map, buf, func(k, v Native) error {
+		if !reflect.TypeOf(k).Comparable() {
+			return fmt.Errorf("map key of type %s is not hashable",
+				k.Type().TypeName())
+		}
+		m[k] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+func (s *MapString) UnmarshalBinary(buf []byte) error {
+	var m = MapString{}
+	if err := unmarshalMap(String, buf, func(k, v Native) error {
+		var key, ok = k.(StrVal)
+		if !ok {
+			return errKeyType(String, k)
+		}
+		m[key] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+func (s *MapInt) UnmarshalBinary(buf []byte) error {
+	var m = MapInt{}
+	if err := unmarshalMap(Int, buf, func(k, v Native) error {
+		var key, ok = k.(IntVal)
+		if !ok {
+			return errKeyType(Int, k)
+		}
+		m[key] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+func (s *MapUint) UnmarshalBinary(buf []byte) error {
+	var m = MapUint{}
+	if err := unmarshalMap(Uint, buf, func(k, v Native) error {
+		var key, ok = k.(UintVal)
+		if !ok {
+			return errKeyType(Uint, k)
+		}
+		m[key] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+func (s *MapFloat) UnmarshalBinary(buf []byte) error {
+	var m = MapFloat{}
+	if err := unmarshalMap(Float, buf, func(k, v Native) error {
+		var key, ok = k.(FltVal)
+		if !ok {
+			return errKeyType(Float, k)
+		}
+		m[key] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+func (s *MapFlag) UnmarshalBinary(buf []byte) error {
+	var m = MapFlag{}
+	if err := unmarshalMap(Flag, buf, func(k, v Native) error {
+		var key, ok = k.(BitFlag)
+		if !ok {
+			return errKeyType(Flag, k)
+		}
+		m[key] = v
+		return nil
+	}); err != nil {
+		return err
+	}
+	*s = m
+	return nil
+}
+
+// decodes the bytes passed as native instance of the type indicated by the
+// flag.  composed types are decoded recursively.
+func UnmarshalNative(flag TyNat, buf []byte) (Native, error) {
+	var nat BinaryUnmarshaler
+	switch flag {
+	case Nil:
+		nat = new(NilVal)
+	case Bool:
+		nat = new(BoolVal)
+	case Int8:
+		nat = new(Int8Val)
+	case Int16:
+		nat = new(Int16Val)
+	case Int32:
+		nat = new(Int32Val)
+	case Int:
+		nat = new(IntVal)
+	case BigInt:
+		nat = new(BigIntVal)
+	case Uint8:
+		nat = new(Uint8Val)
+	case Uint16:
+		nat = new(Uint16Val)
+	case Uint32:
+		nat = new(Uint32Val)
+	case Uint:
+		nat = new(UintVal)
+	case Flt32:
+		nat = new(Flt32Val)
+	case Float:
+		nat = new(FltVal)
+	case BigFlt:
+		nat = new(BigFltVal)
+	case Ratio:
+		nat = new(RatioVal)
+	case Decimal:
+		nat = new(DecimalVal)
+	case Imag64:
+		nat = new(Imag64Val)
+	case Imag:
+		nat = new(ImagVal)
+	case Time:
+		nat = new(TimeVal)
+	case Duration:
+		nat = new(DuraVal)
+	case Byte:
+		nat = new(ByteVal)
+	case Rune:
+		nat = new(RuneVal)
+	case Flag:
+		nat = new(BitFlag)
+	case String:
+		nat = new(StrVal)
+	case Bytes:
+		nat = new(BytesVal)
+	case Error:
+		nat = new(ErrorVal)
+	case Pair:
+		nat = new(PairVal)
+	case Slice:
+		nat = new(DataSlice)
+	case Map:
+		return unmarshalMapNative(buf)
+	default:
+		if r, ok := RegisteredType(flag); ok {
+			return r.Unmarshal(buf)
+		}
+		return nil, fmt.Errorf(
+			"no binary decoding defined for type %s", flag.TypeName())
+	}
+	if err := nat.UnmarshalBinary(buf); err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), err
+	return derefNative(nat), nil
 }
 
-func (v BigFltVal) MarshalBinary() ([]byte, error) {
-	var u, _ = (*big.Float)(&v).Uint64()
-	var buf = bytes.NewBuffer(make([]byte, 0, binary.Size(u)))
-	err := binary.Write(buf, binary.LittleEndian, u)
+// big numbers are dereferenced as well, since the unboxed conversions expect
+// them to be passed by value.
+func derefNative(nat BinaryUnmarshaler) Native {
+	switch v := nat.(type) {
+	case *NilVal:
+		return *v
+	case *BoolVal:
+		return *v
+	case *Int8Val:
+		return *v
+	case *Int16Val:
+		return *v
+	case *Int32Val:
+		return *v
+	case *IntVal:
+		return *v
+	case *BigIntVal:
+		return *v
+	case *Uint8Val:
+		return *v
+	case *Uint16Val:
+		return *v
+	case *Uint32Val:
+		return *v
+	case *UintVal:
+		return *v
+	case *Flt32Val:
+		return *v
+	case *FltVal:
+		return *v
+	case *BigFltVal:
+		return *v
+	case *RatioVal:
+		return *v
+	case *DecimalVal:
+		return *v
+	case *Imag64Val:
+		return *v
+	case *ImagVal:
+		return *v
+	case *TimeVal:
+		return *v
+	case *DuraVal:
+		return *v
+	case *ByteVal:
+		return *v
+	case *RuneVal:
+		return *v
+	case *BitFlag:
+		return *v
+	case *StrVal:
+		return *v
+	case *BytesVal:
+		return *v
+	case *ErrorVal:
+		return *v
+	case *PairVal:
+		return *v
+	case *DataSlice:
+		return *v
+	}
+	return NilVal{}
+}
+
+// decodes a map, picking the map type by the key type it is prefixed with.
+// generic maps with keys, that go can't hash, are decoded as hashed maps.
+func unmarshalMapNative(buf []byte) (Native, error) {
+	var key, n = binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, errMalformed(Map)
+	}
+	var m interface {
+		Native
+		BinaryUnmarshaler
+	}
+	switch TyNat(key) {
+	case String:
+		m = &MapString{}
+	case Int:
+		m = &MapInt{}
+	case Uint:
+		m = &MapUint{}
+	case Float:
+		m = &MapFloat{}
+	case Flag:
+		m = &MapFlag{}
+	default:
+		var fields []Paired
+		var hashable = true
+		if err := unmarshalMap(Map, buf, func(k, v Native) error {
+			hashable = hashable && reflect.TypeOf(k).Comparable()
+			fields = append(fields, NewPair(k, v))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if !hashable {
+			return NewHashedMap(fields...), nil
+		}
+		return NewValMap(fields...), nil
+	}
+	if err := m.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	switch v := m.(type) {
+	case *MapString:
+		return *v, nil
+	case *MapInt:
+		return *v, nil
+	case *MapUint:
+		return *v, nil
+	case *MapFloat:
+		return *v, nil
+	}
+	return *m.(*MapFlag), nil
+}
+
+//// HELPERS
+///
+func appendUvarint(buf []byte, u uint64) []byte {
+	var tmp = make([]byte, binary.MaxVarintLen64)
+	return append(buf, tmp[:binary.PutUvarint(tmp, u)]...)
+}
+
+func appendVarint(buf []byte, i int64) []byte {
+	var tmp = make([]byte, binary.MaxVarintLen64)
+	return append(buf, tmp[:binary.PutVarint(tmp, i)]...)
+}
+
+func readUvarint(buf []byte) (uint64, error) {
+	var u, n = binary.Uvarint(buf)
+	if n <= 0 || n != len(buf) {
+		return 0, fmt.Errorf("malformed unsigned varint: %v", buf)
+	}
+	return u, nil
+}
+
+func readVarint(buf []byte) (int64, error) {
+	var i, n = binary.Varint(buf)
+	if n <= 0 || n != len(buf) {
+		return 0, fmt.Errorf("malformed signed varint: %v", buf)
+	}
+	return i, nil
+}
+
+// registered types may define their encoding, other natives need to
+// implement the binary marshaler interface.
+func marshalNative(nat Native) ([]byte, error) {
+	if r, ok := RegisteredType(nat.Type()); ok {
+		return r.marshal(nat)
+	}
+	var m, ok = nat.(BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf(
+			"no binary encoding defined for type %s",
+			nat.Type().TypeName())
+	}
+	return m.MarshalBinary()
+}
+
+// appends type flag, length and marshaled bytes of the native passed.
+func marshalElement(buf []byte, nat Native) ([]byte, error) {
+	var b, err = marshalNative(nat)
 	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), err
+	buf = appendUvarint(buf, uint64(nat.Type()))
+	buf = appendUvarint(buf, uint64(len(b)))
+	return append(buf, b...), nil
 }
 
-func (v RatioVal) MarshalBinary() ([]byte, error) {
-	var d, n = uint64((*big.Rat)(&v).Denom().Uint64()), uint64((*big.Rat)(&v).Num().Uint64())
-	var buf0 = make([]byte, 0, binary.Size(d))
-	var buf1 = make([]byte, 0, binary.Size(n))
-	binary.PutUvarint(buf0, d)
-	binary.PutUvarint(buf1, n)
-	return append(buf0, buf1...), nil
+// reads a single tagged element from the head of the buffer and returns the
+// decoded native, followed by the remaining bytes.
+func unmarshalElement(buf []byte) (Native, []byte, error) {
+	var flag, n = binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, nil, fmt.Errorf("malformed type flag: %v", buf)
+	}
+	buf = buf[n:]
+	var l, m = binary.Uvarint(buf)
+	if m <= 0 || uint64(len(buf[m:])) < l {
+		return nil, nil, errMalformed(TyNat(flag))
+	}
+	buf = buf[m:]
+	var nat, err = UnmarshalNative(TyNat(flag), buf[:l])
+	if err != nil {
+		return nil, nil, err
+	}
+	return nat, buf[l:], nil
 }
 
-func (v TimeVal) MarshalBinary() ([]byte, error) {
-	var buf = make([]byte, 0, binary.Size(v))
-	(*time.Time)(&v).UnmarshalBinary(buf)
+func marshalMap(key TyNat, m Mapped) ([]byte, error) {
+	var buf = appendUvarint(nil, uint64(key))
+	buf = appendUvarint(buf, uint64(m.Len()))
+	var err error
+	for _, field := range m.Fields() {
+		if buf, err = marshalElement(buf, field.Left()); err != nil {
+			return nil, err
+		}
+		if buf, err = marshalElement(buf, field.Right()); err != nil {
+			return nil, err
+		}
+	}
 	return buf, nil
 }
 
-func (v DuraVal) MarshalBinary() ([]byte, error) {
-	var u = uint64(v)
-	var buf = make([]byte, 0, binary.Size(u))
-	binary.PutUvarint(buf, u)
-	return buf, nil
+func unmarshalMap(key TyNat, buf []byte, set func(k, v Native) error) error {
+	var flag, n = binary.Uvarint(buf)
+	if n <= 0 {
+		return errMalformed(Map)
+	}
+	if TyNat(flag) != key {
+		return fmt.Errorf(
+			"map keyed by %s can not be decoded as map keyed by %s",
+			TyNat(flag).TypeName(), key.TypeName())
+	}
+	buf = buf[n:]
+	var l, m = binary.Uvarint(buf)
+	if m <= 0 {
+		return errMalformed(Map)
+	}
+	buf = buf[m:]
+	for i := uint64(0); i < l; i++ {
+		var k, v Native
+		var err error
+		if k, buf, err = unmarshalElement(buf); err != nil {
+			return err
+		}
+		if v, buf, err = unmarshalElement(buf); err != nil {
+			return err
+		}
+		if err = set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func errMalformed(flag TyNat) error {
+	return fmt.Errorf("malformed binary encoding of type %s", flag.TypeName())
+}
+
+func errOverflow(flag TyNat, val interface{}) error {
+	return fmt.Errorf("decoded value %v overflows type %s", val, flag.TypeName())
+}
+
+func errKeyType(flag TyNat, key Native) error {
+	return fmt.Errorf("expected map key of type %s, got %s",
+		flag.TypeName(), key.Type().TypeName())
 }
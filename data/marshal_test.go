@@ -0,0 +1,157 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var marshalNatives = []Native{
+	NilVal{},
+	BoolVal(true),
+	Int8Val(-8),
+	Int16Val(-1616),
+	Int32Val(-323232),
+	IntVal(-646464646464),
+	BigIntVal(*big.NewInt(-1).Lsh(big.NewInt(-1), 100)),
+	Uint8Val(8),
+	Uint16Val(1616),
+	Uint32Val(323232),
+	UintVal(646464646464),
+	Flt32Val(32.16),
+	FltVal(-64.64),
+	BigFltVal(*big.NewFloat(23.42)),
+	RatioVal(*big.NewRat(-23, 42)),
+	Imag64Val(complex(3.2, -1.6)),
+	ImagVal(complex(6.4, -3.2)),
+	TimeVal(time.Date(2019, 4, 5, 13, 12, 11, 10, time.UTC)),
+	DuraVal(time.Minute * 3),
+	ByteVal(42),
+	RuneVal('ö'),
+	BitFlag(Int | Float),
+	StrVal("test string"),
+	BytesVal([]byte("test bytes")),
+	ErrorVal{errors.New("test error")},
+	NewPair(StrVal("key"), IntVal(23)).(PairVal),
+	NewSlice(IntVal(1), StrVal("two"), FltVal(3.3), NewSlice(BoolVal(true))),
+	MapString{"one": IntVal(1), "two": StrVal("two")},
+	MapInt{1: StrVal("one"), 2: FltVal(2.2)},
+	MapUint{1: StrVal("one")},
+	MapFloat{1.1: StrVal("one point one")},
+	MapFlag{BitFlag(Int): StrVal("int")},
+	MapVal{IntVal(1): StrVal("one"), StrVal("two"): IntVal(2)},
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, nat := range marshalNatives {
+		buf, err := nat.(BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		var typ = nat.Type()
+		dec, err := UnmarshalNative(typ, buf)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		fmt.Printf("%s: %s → %v → %s\n", typ, nat, buf, dec)
+		if dec.Type() != nat.Type() {
+			t.Log("type mismatch", nat.Type(), dec.Type())
+			t.Fail()
+		}
+		if m, ok := nat.(Mapped); ok {
+			if m.Len() != dec.(Mapped).Len() {
+				t.Log("map length mismatch", nat, dec)
+				t.Fail()
+			}
+			for _, field := range m.Fields() {
+				if val, ok := dec.(Mapped).Get(field.Left()); !ok ||
+					val.String() != field.Right().String() {
+					t.Log("map field mismatch", field, val)
+					t.Fail()
+				}
+			}
+			continue
+		}
+		if dec.String() != nat.String() {
+			t.Log("value mismatch", nat, dec)
+			t.Fail()
+		}
+	}
+}
+
+func TestUnmarshalInto(t *testing.T) {
+	var i Int8Val
+	buf, _ := IntVal(300).MarshalBinary()
+	if err := i.UnmarshalBinary(buf); err == nil {
+		t.Log("decoding 300 as Int8 should overflow")
+		t.Fail()
+	}
+	var f FlagSlice
+	buf, _ = FlagSlice{BitFlag(Int), BitFlag(String)}.MarshalBinary()
+	if err := f.UnmarshalBinary(buf); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	fmt.Println(f)
+	if len(f) != 2 || f[1] != BitFlag(String) {
+		t.Fail()
+	}
+	var m MapString
+	buf, _ = MapInt{1: IntVal(1)}.MarshalBinary()
+	if err := m.UnmarshalBinary(buf); err == nil {
+		t.Log("int keyed map should not decode as string keyed map")
+		t.Fail()
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	// length prefixes exceeding the buffer, truncated elements and buffers
+	var huge = []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}
+	var buf, _ = DataSlice{IntVal(1), StrVal("two")}.MarshalBinary()
+	for _, b := range [][]byte{huge, append(huge, 0, 0), buf[:len(buf)-2], {3, 1}, {}} {
+		var s DataSlice
+		if err := s.UnmarshalBinary(b); err == nil {
+			t.Log("expected malformed slice to fail", b)
+			t.Fail()
+		}
+	}
+	for _, b := range [][]byte{huge, append(huge, 0, 0), {3, 1}, {2, 0x80}, {}} {
+		var f FlagSlice
+		if err := f.UnmarshalBinary(b); err == nil {
+			t.Log("expected malformed flag slice to fail", b)
+			t.Fail()
+		}
+	}
+}
+
+func TestUnmarshalUnhashableKeys(t *testing.T) {
+	var buf, err = marshalMap(Map, NewHashedMap(
+		NewPair(BytesVal("key"), IntVal(1)),
+		NewPair(NewSlice(IntVal(2)), IntVal(2))))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var m MapVal
+	err = m.UnmarshalBinary(buf)
+	fmt.Println(err)
+	if err == nil {
+		t.Log("expected generic map to reject unhashable keys")
+		t.Fail()
+	}
+	var nat Native
+	if nat, err = UnmarshalNative(Map, buf); err != nil || nat.(Mapped).Len() != 2 {
+		t.Log("expected map with unhashable keys to decode as hashed map", nat, err)
+		t.FailNow()
+	}
+	if v, ok := nat.(Mapped).Get(BytesVal("key")); !ok || v != IntVal(1) {
+		t.Log("expected field keyed by bytes", nat)
+		t.Fail()
+	}
+}
@@ -3,6 +3,8 @@ package data
 import (
 	"fmt"
 	"math/big"
+	"reflect"
+	"strconv"
 	"time"
 )
 
@@ -14,7 +16,7 @@ func NewCompoundError(errs ...error) CompoundError {
 func (e CompoundError) Error() string {
 	var str string
 	for n, e := range e() {
-		str = str + string(n) + ": " + e.Error() + "\n"
+		str = str + strconv.Itoa(n) + ": " + e.Error() + "\n"
 	}
 	return str
 }
@@ -104,8 +106,10 @@ func NewData(args ...Native) Native {
 			// back to return slice of native instances if not.
 			return SliceToNatives(NewSlice(args...))
 		}
-		if args[0].Type() == Slice {
-			return SliceToNatives(args[0].(DataSlice))
+		// persistent collections reporting the slice type are
+		// unboxed by their elements.
+		if s, ok := args[0].(Sliced); ok && args[0].Type() == Slice {
+			return SliceToNatives(NewSlice(s.Slice()...))
 		}
 		// a single native argument has been passed, return unchanged
 		return args[0]
@@ -172,20 +176,24 @@ func newWithTypeInfo(args ...interface{}) (rval Native, flag BitFlag) {
 	switch temp.(type) {
 	case bool:
 		rval = BoolVal(temp.(bool))
-	case int, int64:
+	case int:
 		rval = IntVal(temp.(int))
+	case int64:
+		rval = IntVal(temp.(int64))
 	case int8:
 		rval = Int8Val(temp.(int8))
 	case int16:
 		rval = Int16Val(temp.(int16))
 	case int32:
 		rval = Int32Val(temp.(int32))
-	case uint, uint64:
+	case uint:
 		rval = UintVal(temp.(uint))
+	case uint64:
+		rval = UintVal(temp.(uint64))
 	case uint16:
 		rval = Uint16Val(temp.(uint16))
 	case uint32:
-		rval = Int32Val(temp.(int32))
+		rval = Uint32Val(temp.(uint32))
 	case float32:
 		rval = Flt32Val(temp.(float32))
 	case float64:
@@ -200,6 +208,8 @@ func newWithTypeInfo(args ...interface{}) (rval Native, flag BitFlag) {
 		rval = BytesVal(temp.([]byte))
 	case string:
 		rval = StrVal(temp.(string))
+	case ErrorVal:
+		rval = temp.(ErrorVal)
 	case error:
 		rval = ErrorVal{temp.(error)}
 	case time.Time:
@@ -225,6 +235,10 @@ func newWithTypeInfo(args ...interface{}) (rval Native, flag BitFlag) {
 		rval = temp.(Native)
 	case []Native:
 		rval = DataSlice(temp.([]Native))
+	default:
+		// structs, maps, slices and pointers are converted by
+		// reflection
+		rval = newReflected(reflect.ValueOf(temp))
 	}
 	// return typed native instance and corresponding type flag
 	return rval, rval.Type().Flag()
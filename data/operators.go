@@ -1,25 +1,716 @@
 package data
 
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
 type OpStr string
 
 const (
 	Not       OpStr = "¬"
-	And             = "&&"
-	Or              = "||"
-	Xor             = "^"
-	AndNot          = "&^"
-	ShiftL          = "<<"
-	ShiftR          = ">>"
-	Add             = "+"
-	Substract       = "-"
-	Multiply        = "×"
-	QuoRatio        = "÷"
-	QuoModul        = "%"
-	Quotient        = "/"
-	Power           = "^"
-	Greater         = ">"
-	Lesser          = "<"
-	Equal           = "="
-	Leq             = "≤"
-	Geq             = "≥"
+	And       OpStr = "&&"
+	Or        OpStr = "||"
+	Xor       OpStr = "⊻"
+	AndNot    OpStr = "&^"
+	ShiftL    OpStr = "<<"
+	ShiftR    OpStr = ">>"
+	Add       OpStr = "+"
+	Substract OpStr = "-"
+	Multiply  OpStr = "×"
+	QuoRatio  OpStr = "÷"
+	QuoModul  OpStr = "%"
+	Quotient  OpStr = "/"
+	Power     OpStr = "^"
+	Greater   OpStr = ">"
+	Lesser    OpStr = "<"
+	Equal     OpStr = "="
+	Leq       OpStr = "≤"
+	Geq       OpStr = "≥"
 )
+
+func (o OpStr) String() string { return string(o) }
+
+//// OPERATOR EVALUATION
+///
+// applies the operator to both operands and returns the result. operands of
+// different number types are promoted to a common type by TypePrec. the
+// second operand is ignored by the unary negation, shifts expect it to be a
+// non negative integer. big floats and ratios are computed by the zero
+// numeric context, the Eval method of other contexts computes them
+// according to that context.
+func Eval(op OpStr, a, b Native) (Native, error) {
+	return NumContext{}.Eval(op, a, b)
+}
+
+func (c NumContext) eval(op OpStr, a, b Native) (Native, error) {
+	var operands = []Native{a, b}
+	if op == Not {
+		operands = operands[:1]
+	}
+	for _, operand := range operands {
+		if operand == nil || operand.Type() == Nil {
+			return nil, errOperator(op, NilVal{})
+		}
+	}
+	switch op {
+	case Not:
+		return evalNot(a)
+	case ShiftL, ShiftR:
+		return evalShift(op, a, b)
+	}
+	var x, y = TypePrec(a, b)
+	if x.Type() != y.Type() {
+		return nil, fmt.Errorf(
+			"operands of type %s and %s can not be promoted to a common type",
+			a.Type().TypeName(), b.Type().TypeName())
+	}
+	switch op {
+	case Quotient, QuoModul, QuoRatio:
+		if isZero(y) && !y.Type().Match(Flt32|Float|Imaginarys) {
+			return nil, fmt.Errorf("division by zero: %s %s %s", x, op, y)
+		}
+	}
+	switch x := x.(type) {
+	case BoolVal:
+		var y = y.(BoolVal)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return BoolVal(x != y), nil
+		case AndNot:
+			return x.And(y.Not()), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case ByteVal:
+		var y = y.(ByteVal)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Uint8Val:
+		var y = y.(Uint8Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return *x.QuoRatio(y), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Uint16Val:
+		var y = y.(Uint16Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return *x.QuoRatio(y), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Uint32Val:
+		var y = y.(Uint32Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return *x.QuoRatio(y), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case UintVal:
+		var y = y.(UintVal)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return *x.QuoRatio(y), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Int8Val:
+		var y = y.(Int8Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return RatioVal(*big.NewRat(int64(x), int64(y))), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Int16Val:
+		var y = y.(Int16Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return RatioVal(*big.NewRat(int64(x), int64(y))), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case Int32Val:
+		var y = y.(Int32Val)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return RatioVal(*big.NewRat(int64(x), int64(y))), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case IntVal:
+		var y = y.(IntVal)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x % y, nil
+		case QuoRatio:
+			return RatioVal(*big.NewRat(int64(x), int64(y))), nil
+		case Power:
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case BigIntVal:
+		var y = y.(BigIntVal)
+		switch op {
+		case And:
+			return x.And(y), nil
+		case Or:
+			return x.Or(y), nil
+		case Xor:
+			return x.Xor(y), nil
+		case AndNot:
+			return x.AndNot(y), nil
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return x.Modulo(y), nil
+		case QuoRatio:
+			return RatioVal(*new(big.Rat).SetFrac(x.GoBigInt(), y.GoBigInt())), nil
+		case Power:
+			if y.GoBigInt().Sign() < 0 {
+				return powRat(new(big.Rat).SetInt(x.GoBigInt()), y.GoBigInt())
+			}
+			if err := checkPowBits(x.GoBigInt(), y.GoBigInt()); err != nil {
+				return nil, err
+			}
+			return x.Power(y), nil
+		}
+		return evalCmp(op, x, x.Cmp(y))
+	case RatioVal:
+		var y = y.(RatioVal)
+		switch op {
+		case Add:
+			return *x.Add(&y), nil
+		case Substract:
+			return *x.Substract(&y), nil
+		case Multiply:
+			return *x.Multipy(&y), nil
+		case Quotient, QuoRatio:
+			return *x.Quotient(&y), nil
+		case Power:
+			if !y.GoRat().IsInt() {
+				break
+			}
+			return powRat(x.GoRat(), y.GoRat().Num())
+		}
+		return evalCmp(op, x, x.Cmp(&y))
+	case DecimalVal:
+		var y = y.(DecimalVal)
+		switch op {
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			var scale = DecimalScale
+			if x.Scale() > scale {
+				scale = x.Scale()
+			}
+			return x.Quotient(y, scale, DecimalRounding)
+		case QuoModul:
+			return x.Modulo(y)
+		case QuoRatio:
+			return RatioVal(*new(big.Rat).Quo(x.GoRat(), y.GoRat())), nil
+		case Power:
+			if !y.GoRat().IsInt() {
+				break
+			}
+			var exp = y.GoBigInt()
+			var abs = new(big.Int).Abs(exp)
+			if !abs.IsUint64() {
+				return nil, fmt.Errorf("%s ^ %s exceeds %d digits", x, exp, MaxDecimalDigits)
+			}
+			var pow, err = x.Power(uint(abs.Uint64()))
+			if err != nil {
+				return nil, err
+			}
+			if exp.Sign() >= 0 {
+				return pow, nil
+			}
+			return powRat(pow.GoRat(), big.NewInt(-1))
+		}
+		return evalCmp(op, x, x.Cmp(y))
+	case Flt32Val:
+		var y = y.(Flt32Val)
+		switch op {
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return Flt32Val(math.Mod(float64(x), float64(y))), nil
+		case Power:
+			return Flt32Val(math.Pow(float64(x), float64(y))), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case FltVal:
+		var y = y.(FltVal)
+		switch op {
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case QuoModul:
+			return FltVal(math.Mod(float64(x), float64(y))), nil
+		case Power:
+			return FltVal(math.Pow(float64(x), float64(y))), nil
+		}
+		return evalCmp(op, x, cmpOf(x.Lesser(y), x.Greater(y)))
+	case BigFltVal:
+		var y = y.(BigFltVal)
+		var z = c.newFloat()
+		switch op {
+		case Add:
+			return BigFltVal(*z.Add(x.GoBigFlt(), y.GoBigFlt())), nil
+		case Substract:
+			return BigFltVal(*z.Sub(x.GoBigFlt(), y.GoBigFlt())), nil
+		case Multiply:
+			return BigFltVal(*z.Mul(x.GoBigFlt(), y.GoBigFlt())), nil
+		case Quotient:
+			return BigFltVal(*z.Quo(x.GoBigFlt(), y.GoBigFlt())), nil
+		case Power:
+			return c.powBigFlt(x.GoBigFlt(), y.GoBigFlt())
+		}
+		return evalCmp(op, x, x.Cmp(y))
+	case Imag64Val:
+		var y = y.(Imag64Val)
+		switch op {
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case Power:
+			return Imag64Val(cmplx.Pow(complex128(x), complex128(y))), nil
+		case Equal:
+			return BoolVal(x.Equal(y)), nil
+		}
+	case ImagVal:
+		var y = y.(ImagVal)
+		switch op {
+		case Add:
+			return x.Add(y), nil
+		case Substract:
+			return x.Substract(y), nil
+		case Multiply:
+			return x.Multipy(y), nil
+		case Quotient:
+			return x.Quotient(y), nil
+		case Power:
+			return ImagVal(cmplx.Pow(complex128(x), complex128(y))), nil
+		case Equal:
+			return BoolVal(x.Equal(y)), nil
+		}
+	}
+	return nil, errOperator(op, x)
+}
+
+func errOperator(op OpStr, nat Native) error {
+	return fmt.Errorf("operator %s is not defined for type %s",
+		op, nat.Type().TypeName())
+}
+
+// yields the result of comparing two operands, derived from their lesser and
+// greater comparators.
+func cmpOf(lesser, greater bool) int {
+	if lesser {
+		return -1
+	}
+	if greater {
+		return 1
+	}
+	return 0
+}
+
+// evaluates comparison operators based on the comparison of both operands
+func evalCmp(op OpStr, x Native, cmp int) (Native, error) {
+	switch op {
+	case Equal:
+		return BoolVal(cmp == 0), nil
+	case Lesser:
+		return BoolVal(cmp < 0), nil
+	case Greater:
+		return BoolVal(cmp > 0), nil
+	case Leq:
+		return BoolVal(cmp <= 0), nil
+	case Geq:
+		return BoolVal(cmp >= 0), nil
+	}
+	return nil, errOperator(op, x)
+}
+
+func evalNot(a Native) (Native, error) {
+	switch x := a.(type) {
+	case BoolVal:
+		return x.Not(), nil
+	case ByteVal:
+		return x.Not(), nil
+	case Uint8Val:
+		return x.Not(), nil
+	case Uint16Val:
+		return x.Not(), nil
+	case Uint32Val:
+		return x.Not(), nil
+	case UintVal:
+		return x.Not(), nil
+	case Int8Val:
+		return x.Not(), nil
+	case Int16Val:
+		return x.Not(), nil
+	case Int32Val:
+		return x.Not(), nil
+	case IntVal:
+		return x.Not(), nil
+	case BigIntVal:
+		return x.Not(), nil
+	}
+	return nil, errOperator(Not, a)
+}
+
+// shifts the first operand by the number of bits given by the second one,
+// without promoting the first operand.
+func evalShift(op OpStr, a, b Native) (Native, error) {
+	if _, ok := b.(Integer); !ok || !b.Type().Match(Naturals|Integers) {
+		return nil, fmt.Errorf("operator %s is not defined for shift count of type %s",
+			op, b.Type().TypeName())
+	}
+	if b.(Integer).GoInt() < 0 {
+		return nil, fmt.Errorf("negative shift count %s", b)
+	}
+	var n = b.(Natural).GoUint()
+	if op == ShiftR {
+		switch x := a.(type) {
+		case ByteVal:
+			return x >> n, nil
+		case Uint8Val:
+			return x >> n, nil
+		case Uint16Val:
+			return x >> n, nil
+		case Uint32Val:
+			return x >> n, nil
+		case UintVal:
+			return x >> n, nil
+		case Int8Val:
+			return x >> n, nil
+		case Int16Val:
+			return x >> n, nil
+		case Int32Val:
+			return x >> n, nil
+		case IntVal:
+			return x >> n, nil
+		case BigIntVal:
+			return BigIntVal(*new(big.Int).Rsh(x.GoBigInt(), n)), nil
+		}
+		return nil, errOperator(op, a)
+	}
+	switch x := a.(type) {
+	case ByteVal:
+		return x << n, nil
+	case Uint8Val:
+		return x << n, nil
+	case Uint16Val:
+		return x << n, nil
+	case Uint32Val:
+		return x << n, nil
+	case UintVal:
+		return x << n, nil
+	case Int8Val:
+		return x << n, nil
+	case Int16Val:
+		return x << n, nil
+	case Int32Val:
+		return x << n, nil
+	case IntVal:
+		return x << n, nil
+	case BigIntVal:
+		return BigIntVal(*new(big.Int).Lsh(x.GoBigInt(), n)), nil
+	}
+	return nil, errOperator(op, a)
+}
+
+func isZero(n Native) bool {
+	switch x := n.(type) {
+	case BoolVal:
+		return !bool(x)
+	case BigIntVal:
+		return x.GoBigInt().Sign() == 0
+	case BigFltVal:
+		return x.GoBigFlt().Sign() == 0
+	case RatioVal:
+		return x.GoRat().Sign() == 0
+	case DecimalVal:
+		return x.Sign() == 0
+	case Imag64Val:
+		return x == 0
+	case ImagVal:
+		return x == 0
+	case ByteVal:
+		return x == 0
+	case Real:
+		return x.GoFlt() == 0
+	}
+	return false
+}
+
+// largest number of bits of integral powers of big integers and of the
+// numerators and denominators of powers of ratios. powers estimated to
+// exceed it yield an error, instead of being computed.
+const MaxPowerBits = 1 << 24
+
+// estimates the bits of the power as the bit length of the base times the
+// absolute exponent. powers of zero and one don't grow and are exempt.
+func checkPowBits(x, exp *big.Int) error {
+	if x.CmpAbs(big.NewInt(1)) <= 0 {
+		return nil
+	}
+	var bits = new(big.Int).Mul(big.NewInt(int64(x.BitLen())), new(big.Int).Abs(exp))
+	if bits.Cmp(big.NewInt(MaxPowerBits)) > 0 {
+		return fmt.Errorf("%s ^ %s exceeds %d bits", x, exp, MaxPowerBits)
+	}
+	return nil
+}
+
+// raises a ratio to an integer power, negative exponents invert the result
+func powRat(r *big.Rat, exp *big.Int) (Native, error) {
+	if err := checkPowBits(r.Num(), exp); err != nil {
+		return nil, err
+	}
+	if err := checkPowBits(r.Denom(), exp); err != nil {
+		return nil, err
+	}
+	var e = new(big.Int).Abs(exp)
+	var num = new(big.Int).Exp(r.Num(), e, nil)
+	var den = new(big.Int).Exp(r.Denom(), e, nil)
+	if exp.Sign() < 0 {
+		if num.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero: %s ^ %s", r.RatString(), exp)
+		}
+		num, den = den, num
+	}
+	return RatioVal(*new(big.Rat).SetFrac(num, den)), nil
+}
+
+// raises a big float to a power. integral exponents are computed by
+// squaring at the precision of the context, or of the base, if the context
+// keeps the precision. others by float64 approximation.
+func (c NumContext) powBigFlt(x, y *big.Float) (Native, error) {
+	var e, acc = y.Int64()
+	if !y.IsInt() || acc != big.Exact {
+		var fx, _ = x.Float64()
+		var fy, _ = y.Float64()
+		var f = math.Pow(fx, fy)
+		if math.IsNaN(f) {
+			return nil, fmt.Errorf("%s ^ %s is not a number", x, y)
+		}
+		return BigFltVal(*c.newFloat().SetFloat64(f)), nil
+	}
+	var neg = e < 0
+	if neg {
+		e = -e
+	}
+	var prec = c.Prec
+	if prec == 0 {
+		prec = x.Prec()
+	}
+	var base = new(big.Float).SetMode(c.Mode).SetPrec(prec).Set(x)
+	var res = new(big.Float).SetMode(c.Mode).SetPrec(prec).SetInt64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			res.Mul(res, base)
+		}
+		base.Mul(base, base)
+	}
+	if neg {
+		if res.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero: %s ^ %s", x, y)
+		}
+		res.Quo(new(big.Float).SetPrec(prec).SetInt64(1), res)
+	}
+	return BigFltVal(*res), nil
+}
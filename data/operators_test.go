@@ -0,0 +1,102 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var evalCases = []struct {
+	op     OpStr
+	a, b   Native
+	result string
+	typ    TyNat
+}{
+	{Add, IntVal(2), IntVal(3), "5", Int},
+	{Add, Int8Val(2), Uint8Val(3), "5", Int16},
+	{Substract, Uint32Val(2), Int8Val(3), "-1", Int},
+	{Multiply, IntVal(3), FltVal(1.5), "4.5", Float},
+	{Quotient, IntVal(7), IntVal(2), "3", Int},
+	{QuoModul, IntVal(7), IntVal(2), "1", Int},
+	{QuoRatio, IntVal(6), IntVal(4), "3/2", Ratio},
+	{Add, RatioVal(*big.NewRat(1, 3)), IntVal(1), "4/3", Ratio},
+	{Power, RatioVal(*big.NewRat(2, 3)), IntVal(-2), "9/4", Ratio},
+	{Multiply, BigIntVal(*big.NewInt(1).Lsh(big.NewInt(1), 70)), UintVal(2),
+		"2361183241434822606848", BigInt},
+	{Power, BigIntVal(*big.NewInt(2)), BigIntVal(*big.NewInt(10)), "1024", BigInt},
+	{Add, BigFltVal(*big.NewFloat(1.5)), IntVal(1), "2.5", BigFlt},
+	{Power, BigFltVal(*big.NewFloat(1.5)), IntVal(2), "2.25", BigFlt},
+	{Add, ImagVal(complex(1, 1)), FltVal(1), ImagVal(complex(2, 1)).String(), Imag},
+	{Multiply, Imag64Val(complex(0, 1)), Imag64Val(complex(0, 1)),
+		Imag64Val(complex(-1, 0)).String(), Imag64},
+	{Lesser, IntVal(-1), UintVal(1), "true", Bool},
+	{Geq, BigIntVal(*big.NewInt(3)), Flt32Val(3), "true", Bool},
+	{Equal, RatioVal(*big.NewRat(1, 2)), FltVal(0.5), "true", Bool},
+	{Xor, BoolVal(true), BoolVal(false), "true", Bool},
+	{AndNot, UintVal(7), UintVal(2), "5", Uint},
+	{ShiftL, Int8Val(1), UintVal(3), "8", Int8},
+	{ShiftR, BigIntVal(*big.NewInt(16)), IntVal(2), "4", BigInt},
+	{Not, BoolVal(true), nil, "false", Bool},
+}
+
+func TestEval(t *testing.T) {
+	for _, c := range evalCases {
+		var result, err = Eval(c.op, c.a, c.b)
+		fmt.Printf("%s %s %v = %v\n", c.a, c.op, c.b, result)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if result.String() != c.result || result.Type() != c.typ {
+			t.Log("expected", c.result, c.typ, "got", result, result.Type())
+			t.Fail()
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	if _, err := Eval(Quotient, IntVal(1), IntVal(0)); err == nil {
+		t.Log("integer division by zero should yield an error")
+		t.Fail()
+	}
+	if _, err := Eval(Lesser, ImagVal(1), ImagVal(2)); err == nil {
+		t.Log("imaginary numbers should not be ordered")
+		t.Fail()
+	}
+	if _, err := Eval(ShiftL, IntVal(1), IntVal(-1)); err == nil {
+		t.Log("negative shift count should yield an error")
+		t.Fail()
+	}
+	if _, err := Eval(Add, StrVal("a"), IntVal(1)); err == nil {
+		t.Log("adding string and int should yield an error")
+		t.Fail()
+	}
+	var huge = BigIntVal(*big.NewInt(1 << 34))
+	if _, err := Eval(Power, BigIntVal(*big.NewInt(3)), huge); err == nil {
+		t.Log("power of big integer exceeding the limit should yield an error")
+		t.Fail()
+	}
+	if _, err := Eval(Power, RatioVal(*big.NewRat(2, 3)), RatioVal(*big.NewRat(-1<<34, 1))); err == nil {
+		t.Log("power of ratio exceeding the limit should yield an error")
+		t.Fail()
+	}
+	if r, err := Eval(Power, BigIntVal(*big.NewInt(-1)), huge); err != nil || r.String() != "1" {
+		t.Log("powers of one should not be limited", r, err)
+		t.Fail()
+	}
+	for _, operands := range [][2]Native{
+		{NilVal{}, IntVal(1)}, {IntVal(1), NilVal{}}, {IntVal(1), nil},
+	} {
+		if _, err := Eval(Add, operands[0], operands[1]); err == nil {
+			t.Log("nil operands should yield an error", operands)
+			t.Fail()
+		}
+	}
+	var _, err = Eval(ShiftL, ByteVal(1), ByteVal(2))
+	fmt.Println(err)
+	if err == nil || err.Error() != "operator << is not defined for shift count of type Byte" {
+		t.Log("shift by byte should yield an error naming its type", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,509 @@
+package data
+
+import "sort"
+
+//// ORDERED MAP
+///
+// persistent b-tree of fields ordered by key. like the hash map, set and
+// delete return new versions sharing all unchanged nodes with the version
+// they have been derived from. keys, fields, slice and string yield fields
+// in ascending order of their keys, as defined by compare.
+type OrderedMap struct {
+	root *btreeNode
+	size int
+}
+
+const (
+	btreeDegree   = 16
+	btreeMinItems = btreeDegree - 1
+	btreeMaxItems = btreeDegree*2 - 1
+)
+
+type btreeItem struct{ key, val Native }
+
+type btreeNode struct {
+	items    []btreeItem
+	children []*btreeNode
+}
+
+func NewOrderedMap(acc ...Paired) Mapped {
+	var m = OrderedMap{}
+	for _, pair := range acc {
+		m = m.set(pair.Left(), pair.Right())
+	}
+	return m
+}
+
+func (m OrderedMap) Type() TyNat { return Map }
+func (m OrderedMap) Len() int    { return m.size }
+func (m OrderedMap) First() Paired {
+	if m.size > 0 {
+		var n = m.root
+		for len(n.children) > 0 {
+			n = n.children[0]
+		}
+		return n.items[0].pair()
+	}
+	return NewPair(NewNil(), NewNil())
+}
+func (m OrderedMap) Last() Paired {
+	if m.size > 0 {
+		var n = m.root
+		for len(n.children) > 0 {
+			n = n.children[len(n.children)-1]
+		}
+		return n.items[len(n.items)-1].pair()
+	}
+	return NewPair(NewNil(), NewNil())
+}
+func (m OrderedMap) TypeKey() Typed   { return m.First().Left().Type() }
+func (m OrderedMap) TypeValue() Typed { return m.First().Right().Type() }
+
+func (m OrderedMap) Keys() []Native {
+	var keys = make([]Native, 0, m.size)
+	m.root.ascend(nil, nil, func(i btreeItem) bool {
+		keys = append(keys, i.key)
+		return true
+	})
+	return keys
+}
+
+func (m OrderedMap) Data() []Native {
+	var dat = make([]Native, 0, m.size)
+	m.root.ascend(nil, nil, func(i btreeItem) bool {
+		dat = append(dat, i.val)
+		return true
+	})
+	return dat
+}
+
+func (m OrderedMap) Slice() []Native {
+	var native = make([]Native, 0, m.size)
+	m.root.ascend(nil, nil, func(i btreeItem) bool {
+		native = append(native, i.pair())
+		return true
+	})
+	return native
+}
+
+func (m OrderedMap) Fields() []Paired { return m.Range(nil, nil) }
+
+func (m OrderedMap) Has(acc Native) bool {
+	var _, ok = m.Get(acc)
+	return ok
+}
+
+func (m OrderedMap) Get(acc Native) (Native, bool) {
+	for n := m.root; n != nil; {
+		var i, found = n.find(acc)
+		if found {
+			return n.items[i].val, true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return nil, false
+}
+
+// returns a new version of the map, containing the field passed
+func (m OrderedMap) Set(acc Native, dat Native) Mapped { return m.set(acc, dat) }
+
+func (m OrderedMap) set(acc Native, dat Native) OrderedMap {
+	var item = btreeItem{acc, dat}
+	if m.root == nil {
+		return OrderedMap{&btreeNode{items: []btreeItem{item}}, 1}
+	}
+	var root = m.root.clone()
+	if len(root.items) >= btreeMaxItems {
+		var mid, right = root.split(btreeMaxItems / 2)
+		root = &btreeNode{
+			items:    []btreeItem{mid},
+			children: []*btreeNode{root, right},
+		}
+	}
+	if root.insert(item) {
+		return OrderedMap{root, m.size + 1}
+	}
+	return OrderedMap{root, m.size}
+}
+
+// persistent maps are never changed in place. delete removes nothing and
+// returns false, to satisfy Mapped. use Without to derive a version of the
+// map without the field.
+func (m OrderedMap) Delete(acc Native) bool { return false }
+
+// returns a new version of the map without the field indexed by the key
+// passed and true, or the unchanged map and false, if there is no such field.
+func (m OrderedMap) Without(acc Native) (OrderedMap, bool) {
+	if m.root == nil {
+		return m, false
+	}
+	var root = m.root.clone()
+	if _, ok := root.remove(acc, false); !ok {
+		return m, false
+	}
+	if len(root.items) == 0 {
+		if len(root.children) > 0 {
+			root = root.children[0]
+		} else {
+			root = nil
+		}
+	}
+	return OrderedMap{root, m.size - 1}, true
+}
+
+// returns the field with the greatest key lesser, or equal to the key passed
+func (m OrderedMap) Floor(acc Native) (Paired, bool) {
+	var res *btreeItem
+	for n := m.root; n != nil; {
+		var i, found = n.find(acc)
+		if found {
+			return n.items[i].pair(), true
+		}
+		if i > 0 {
+			res = &n.items[i-1]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	if res == nil {
+		return NewPair(NewNil(), NewNil()), false
+	}
+	return res.pair(), true
+}
+
+// returns the field with the least key greater, or equal to the key passed
+func (m OrderedMap) Ceiling(acc Native) (Paired, bool) {
+	var res *btreeItem
+	for n := m.root; n != nil; {
+		var i, found = n.find(acc)
+		if found {
+			return n.items[i].pair(), true
+		}
+		if i < len(n.items) {
+			res = &n.items[i]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	if res == nil {
+		return NewPair(NewNil(), NewNil()), false
+	}
+	return res.pair(), true
+}
+
+// returns fields with keys greater, or equal to lo and lesser than hi in
+// ascending order. nil bounds are not limiting the range.
+func (m OrderedMap) Range(lo, hi Native) []Paired {
+	var pairs = []Paired{}
+	m.root.ascend(lo, hi, func(i btreeItem) bool {
+		pairs = append(pairs, i.pair())
+		return true
+	})
+	return pairs
+}
+
+// calls the function passed for every field in ascending order of keys,
+// until it returns false.
+func (m OrderedMap) Ascend(fn func(Paired) bool) {
+	m.root.ascend(nil, nil, func(i btreeItem) bool { return fn(i.pair()) })
+}
+
+// calls the function passed for every field in descending order of keys,
+// until it returns false.
+func (m OrderedMap) Descend(fn func(Paired) bool) {
+	m.root.descend(func(i btreeItem) bool { return fn(i.pair()) })
+}
+
+//// B-TREE NODES
+///
+// nodes are never mutated after they have been reachable from a map. set
+// and delete clone every node along the path they modify.
+func (i btreeItem) pair() Paired { return PairVal{i.key, i.val} }
+
+func (n *btreeNode) clone() *btreeNode {
+	var c = &btreeNode{items: make([]btreeItem, len(n.items), btreeMaxItems)}
+	copy(c.items, n.items)
+	if len(n.children) > 0 {
+		c.children = make([]*btreeNode, len(n.children), btreeMaxItems+1)
+		copy(c.children, n.children)
+	}
+	return c
+}
+
+func (n *btreeNode) mutableChild(i int) *btreeNode {
+	var c = n.children[i].clone()
+	n.children[i] = c
+	return c
+}
+
+// returns the index of the first item with a key not lesser than the key
+// passed and true, if the keys are equal.
+func (n *btreeNode) find(key Native) (int, bool) {
+	var i = sort.Search(len(n.items), func(i int) bool {
+		return Compare(n.items[i].key, key) >= 0
+	})
+	return i, i < len(n.items) && Compare(n.items[i].key, key) == 0
+}
+
+// splits the node at the index passed, returns the item at that index and
+// a new node containing all following items and children.
+func (n *btreeNode) split(i int) (btreeItem, *btreeNode) {
+	var item = n.items[i]
+	var right = &btreeNode{items: make([]btreeItem, 0, btreeMaxItems)}
+	right.items = append(right.items, n.items[i+1:]...)
+	n.items = n.items[:i]
+	if len(n.children) > 0 {
+		right.children = make([]*btreeNode, 0, btreeMaxItems+1)
+		right.children = append(right.children, n.children[i+1:]...)
+		n.children = n.children[:i+1]
+	}
+	return item, right
+}
+
+// inserts, or replaces the item in the sub tree of a node, that has already
+// been cloned. returns true, if the item has been added.
+func (n *btreeNode) insert(item btreeItem) bool {
+	var i, found = n.find(item.key)
+	if found {
+		n.items[i] = item
+		return false
+	}
+	if len(n.children) == 0 {
+		n.items = append(n.items, btreeItem{})
+		copy(n.items[i+1:], n.items[i:])
+		n.items[i] = item
+		return true
+	}
+	if len(n.children[i].items) >= btreeMaxItems {
+		var mid, right = n.mutableChild(i).split(btreeMaxItems / 2)
+		n.items = append(n.items, btreeItem{})
+		copy(n.items[i+1:], n.items[i:])
+		n.items[i] = mid
+		n.children = append(n.children, nil)
+		copy(n.children[i+2:], n.children[i+1:])
+		n.children[i+1] = right
+		switch c := Compare(item.key, mid.key); {
+		case c == 0:
+			n.items[i] = item
+			return false
+		case c > 0:
+			i += 1
+		}
+	}
+	return n.mutableChild(i).insert(item)
+}
+
+// removes the item indexed by key, or the greatest item of the sub tree, if
+// max is true, from a node that has already been cloned.
+func (n *btreeNode) remove(key Native, max bool) (btreeItem, bool) {
+	var i int
+	var found bool
+	if max {
+		if len(n.children) == 0 {
+			var item = n.items[len(n.items)-1]
+			n.items = n.items[:len(n.items)-1]
+			return item, true
+		}
+		i = len(n.items)
+	} else {
+		i, found = n.find(key)
+		if len(n.children) == 0 {
+			if !found {
+				return btreeItem{}, false
+			}
+			var item = n.items[i]
+			n.items = append(n.items[:i], n.items[i+1:]...)
+			return item, true
+		}
+	}
+	if len(n.children[i].items) <= btreeMinItems {
+		n.grow(i)
+		return n.remove(key, max)
+	}
+	var child = n.mutableChild(i)
+	if found {
+		var item = n.items[i]
+		n.items[i], _ = child.remove(nil, true)
+		return item, true
+	}
+	return child.remove(key, max)
+}
+
+// ensures the child at index i holds more than the minimum number of items,
+// by moving an item from one of its siblings, or merging it with one.
+func (n *btreeNode) grow(i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > btreeMinItems:
+		var child, left = n.mutableChild(i), n.mutableChild(i - 1)
+		child.items = append([]btreeItem{n.items[i-1]}, child.items...)
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = left.items[:len(left.items)-1]
+		if len(left.children) > 0 {
+			var last = left.children[len(left.children)-1]
+			left.children = left.children[:len(left.children)-1]
+			child.children = append([]*btreeNode{last}, child.children...)
+		}
+	case i < len(n.items) && len(n.children[i+1].items) > btreeMinItems:
+		var child, right = n.mutableChild(i), n.mutableChild(i + 1)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = append(right.items[:0], right.items[1:]...)
+		if len(right.children) > 0 {
+			child.children = append(child.children, right.children[0])
+			right.children = append(right.children[:0], right.children[1:]...)
+		}
+	default:
+		if i >= len(n.items) {
+			i -= 1
+		}
+		var child, merge = n.mutableChild(i), n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, merge.items...)
+		child.children = append(child.children, merge.children...)
+		n.items = append(n.items[:i], n.items[i+1:]...)
+		n.children = append(n.children[:i+1], n.children[i+2:]...)
+	}
+}
+
+// visits items with keys in the range [lo, hi) in ascending order, until
+// the function returns false.
+func (n *btreeNode) ascend(lo, hi Native, fn func(btreeItem) bool) bool {
+	if n == nil {
+		return true
+	}
+	var start int
+	if lo != nil {
+		start, _ = n.find(lo)
+	}
+	for i := start; i <= len(n.items); i++ {
+		if len(n.children) > 0 {
+			if !n.children[i].ascend(lo, hi, fn) {
+				return false
+			}
+		}
+		if i == len(n.items) {
+			break
+		}
+		if hi != nil && Compare(n.items[i].key, hi) >= 0 {
+			return false
+		}
+		if !fn(n.items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *btreeNode) descend(fn func(btreeItem) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := len(n.items); i >= 0; i-- {
+		if len(n.children) > 0 {
+			if !n.children[i].descend(fn) {
+				return false
+			}
+		}
+		if i > 0 && !fn(n.items[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+//// SORTED SET
+///
+// persistent set of natives in ascending order, backed by an ordered map.
+type SortedSet struct{ m OrderedMap }
+
+func NewSortedSet(elems ...Native) SortedSet {
+	var s = SortedSet{}
+	for _, elem := range elems {
+		s = s.Add(elem)
+	}
+	return s
+}
+
+func (s SortedSet) Type() TyNat     { return Slice }
+func (s SortedSet) TypeElem() Typed { return s.First().Type() }
+func (s SortedSet) Len() int        { return s.m.Len() }
+func (s SortedSet) Empty() bool     { return s.m.Len() == 0 }
+func (s SortedSet) First() Native   { return s.m.First().Left() }
+func (s SortedSet) Last() Native    { return s.m.Last().Left() }
+func (s SortedSet) Slice() []Native { return s.m.Keys() }
+
+func (s SortedSet) Has(elem Native) bool { return s.m.Has(elem) }
+
+// returns a new version of the set, containing the element
+func (s SortedSet) Add(elem Native) SortedSet {
+	return SortedSet{s.m.set(elem, NilVal{})}
+}
+
+// returns a new version of the set without the element passed and true, or
+// the unchanged set and false, if it did not contain the element.
+func (s SortedSet) Remove(elem Native) (SortedSet, bool) {
+	var m, ok = s.m.Without(elem)
+	return SortedSet{m}, ok
+}
+
+func (s SortedSet) Floor(elem Native) (Native, bool) {
+	var p, ok = s.m.Floor(elem)
+	return p.Left(), ok
+}
+
+func (s SortedSet) Ceiling(elem Native) (Native, bool) {
+	var p, ok = s.m.Ceiling(elem)
+	return p.Left(), ok
+}
+
+// returns elements greater, or equal to lo and lesser than hi
+func (s SortedSet) Range(lo, hi Native) []Native {
+	var elems = []Native{}
+	s.m.root.ascend(lo, hi, func(i btreeItem) bool {
+		elems = append(elems, i.key)
+		return true
+	})
+	return elems
+}
+
+func (s SortedSet) Ascend(fn func(Native) bool) {
+	s.m.root.ascend(nil, nil, func(i btreeItem) bool { return fn(i.key) })
+}
+
+func (s SortedSet) Descend(fn func(Native) bool) {
+	s.m.root.descend(func(i btreeItem) bool { return fn(i.key) })
+}
+
+func (s SortedSet) Union(t SortedSet) SortedSet {
+	t.Ascend(func(elem Native) bool {
+		s = s.Add(elem)
+		return true
+	})
+	return s
+}
+
+func (s SortedSet) Intersection(t SortedSet) SortedSet {
+	var res = SortedSet{}
+	s.Ascend(func(elem Native) bool {
+		if t.Has(elem) {
+			res = res.Add(elem)
+		}
+		return true
+	})
+	return res
+}
+
+func (s SortedSet) Difference(t SortedSet) SortedSet {
+	t.Ascend(func(elem Native) bool {
+		s, _ = s.Remove(elem)
+		return true
+	})
+	return s
+}
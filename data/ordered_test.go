@@ -0,0 +1,222 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestOrderedMapOrder(t *testing.T) {
+	var m = NewOrderedMap(
+		NewPair(StrVal("cherry"), IntVal(3)),
+		NewPair(StrVal("apple"), IntVal(1)),
+		NewPair(StrVal("banana"), IntVal(2)),
+	)
+	fmt.Println(m)
+	if m.String() != "[(apple, 1), (banana, 2), (cherry, 3)]" {
+		t.Log("fields should be ordered by key", m)
+		t.Fail()
+	}
+	var n = m.Set(StrVal("apricot"), IntVal(4))
+	if m.Len() != 3 || n.Len() != 4 || m.Has(StrVal("apricot")) {
+		t.Log("set must not change the version it derived from", m, n)
+		t.Fail()
+	}
+	var o, ok = n.(OrderedMap).Without(StrVal("apple"))
+	if !ok || o.Len() != 3 || !n.Has(StrVal("apple")) {
+		t.Log("delete must not change the version it derived from", n, o)
+		t.Fail()
+	}
+	if k := o.First().Left(); k != StrVal("apricot") {
+		t.Log("expected apricot to be the least key", k)
+		t.Fail()
+	}
+}
+
+func TestOrderedMapRandom(t *testing.T) {
+	var r = rand.New(rand.NewSource(1))
+	var ref = map[int]int{}
+	var m Mapped = NewOrderedMap()
+	var versions = []Mapped{}
+	for i := 0; i < 5000; i++ {
+		var k = r.Intn(1000)
+		if r.Intn(3) == 0 {
+			var ok bool
+			m, ok = m.(OrderedMap).Without(IntVal(k))
+			if _, found := ref[k]; found != ok {
+				t.Log("delete reported wrong result for", k)
+				t.Fail()
+			}
+			delete(ref, k)
+		} else {
+			m = m.Set(IntVal(k), IntVal(i))
+			ref[k] = i
+		}
+		if i%500 == 0 {
+			versions = append(versions, m)
+		}
+	}
+	if m.Len() != len(ref) {
+		t.Log("length mismatch", m.Len(), len(ref))
+		t.Fail()
+	}
+	var last = -1
+	for _, p := range m.Fields() {
+		var k = int(p.Left().(IntVal))
+		if k <= last || IntVal(ref[k]) != p.Right() {
+			t.Log("fields out of order, or wrong value", p)
+			t.Fail()
+			return
+		}
+		last = k
+	}
+	for _, v := range versions {
+		if len(v.Fields()) != v.Len() {
+			t.Log("previous version changed", v.Len(), len(v.Fields()))
+			t.Fail()
+		}
+	}
+	for k := range ref {
+		m, _ = m.(OrderedMap).Without(IntVal(k))
+	}
+	if m.Len() != 0 || len(m.Keys()) != 0 {
+		t.Log("map should be empty", m)
+		t.Fail()
+	}
+}
+
+func TestOrderedMapRange(t *testing.T) {
+	var m = OrderedMap{}
+	for i := 0; i < 100; i += 10 {
+		m = m.set(IntVal(i), IntVal(i*i))
+	}
+	if p, ok := m.Floor(IntVal(35)); !ok || p.Left() != IntVal(30) {
+		t.Log("floor of 35 should be 30", p)
+		t.Fail()
+	}
+	if p, ok := m.Ceiling(IntVal(35)); !ok || p.Left() != IntVal(40) {
+		t.Log("ceiling of 35 should be 40", p)
+		t.Fail()
+	}
+	if p, ok := m.Floor(IntVal(40)); !ok || p.Left() != IntVal(40) {
+		t.Log("floor of 40 should be 40", p)
+		t.Fail()
+	}
+	if _, ok := m.Floor(IntVal(-1)); ok {
+		t.Log("expected no floor for -1")
+		t.Fail()
+	}
+	if _, ok := m.Ceiling(IntVal(91)); ok {
+		t.Log("expected no ceiling for 91")
+		t.Fail()
+	}
+	var r = m.Range(IntVal(20), FltVal(50.5))
+	fmt.Println(r)
+	if len(r) != 4 || r[0].Left() != IntVal(20) || r[3].Left() != IntVal(50) {
+		t.Log("range should contain 20, 30, 40 and 50", r)
+		t.Fail()
+	}
+	var desc = []Native{}
+	m.Descend(func(p Paired) bool {
+		desc = append(desc, p.Left())
+		return len(desc) < 3
+	})
+	if len(desc) != 3 || desc[0] != IntVal(90) || desc[2] != IntVal(70) {
+		t.Log("expected descending iteration to stop after three keys", desc)
+		t.Fail()
+	}
+	var now = time.Now()
+	var tm = NewOrderedMap(
+		NewPair(TimeVal(now.Add(time.Hour)), StrVal("later")),
+		NewPair(TimeVal(now), StrVal("now")),
+	)
+	if tm.First().Right() != StrVal("now") {
+		t.Log("times should be ordered chronologically", tm)
+		t.Fail()
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	var s = NewSortedSet(IntVal(5), IntVal(1), IntVal(3), IntVal(1))
+	fmt.Println(s)
+	if s.Len() != 3 || s.String() != "[1, 3, 5]" {
+		t.Log("expected ordered set without duplicates", s)
+		t.Fail()
+	}
+	var u = s.Union(NewSortedSet(IntVal(2), IntVal(3)))
+	if u.String() != "[1, 2, 3, 5]" || s.Len() != 3 {
+		t.Log("unexpected union", u)
+		t.Fail()
+	}
+	if i := u.Intersection(NewSortedSet(IntVal(2), IntVal(5), IntVal(7))); i.String() != "[2, 5]" {
+		t.Log("unexpected intersection", i)
+		t.Fail()
+	}
+	if d := u.Difference(NewSortedSet(IntVal(1), IntVal(5))); d.String() != "[2, 3]" {
+		t.Log("unexpected difference", d)
+		t.Fail()
+	}
+	if e, ok := u.Floor(IntVal(4)); !ok || e != IntVal(3) {
+		t.Log("floor of 4 should be 3", e)
+		t.Fail()
+	}
+	if r := u.Range(IntVal(2), IntVal(5)); len(r) != 2 {
+		t.Log("range should contain 2 and 3", r)
+		t.Fail()
+	}
+}
+
+func TestOrderedSerialization(t *testing.T) {
+	var m = NewOrderedMap(
+		NewPair(StrVal("b"), IntVal(2)),
+		NewPair(StrVal("a"), NewSlice(StrVal("x"))),
+	).(OrderedMap)
+	var s = NewSortedSet(IntVal(3), IntVal(1), IntVal(2))
+	var buf, err = json.Marshal(NewSlice(m, s))
+	fmt.Println(string(buf))
+	if err != nil || string(buf) != `[{"a":["x"],"b":2},[1,2,3]]` {
+		t.Log("unexpected json encoding", string(buf), err)
+		t.Fail()
+	}
+	var om OrderedMap
+	if err = json.Unmarshal([]byte(`{"b":2,"a":["x"]}`), &om); err != nil || !DeepEqual(m, om) {
+		t.Log("expected ordered map to survive json round trip", om, err)
+		t.Fail()
+	}
+	var ss SortedSet
+	if err = json.Unmarshal([]byte(`[3,1,2,1]`), &ss); err != nil || !DeepEqual(s, ss) {
+		t.Log("expected sorted set to survive json round trip", ss, err)
+		t.Fail()
+	}
+	var stream = bytes.NewBuffer([]byte{})
+	var enc = NewEncoder(stream)
+	if err = enc.Encode(m); err == nil {
+		err = enc.Encode(s)
+	}
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var dec = NewDecoder(stream)
+	if nat, err := dec.Decode(); err != nil || !DeepEqual(m, nat) {
+		t.Log("expected ordered map to be decoded as ordered map", nat, err)
+		t.Fail()
+	} else if _, ok := nat.(OrderedMap); !ok {
+		t.Log("expected ordered map, got", nat)
+		t.Fail()
+	}
+	if nat, err := dec.Decode(); err != nil || !DeepEqual(s, nat) {
+		t.Log("expected sorted set to be decoded as sorted set", nat, err)
+		t.Fail()
+	} else if _, ok := nat.(SortedSet); !ok {
+		t.Log("expected sorted set, got", nat)
+		t.Fail()
+	}
+	if vec, ok := NewData(s).(IntVec); !ok || vec[2] != 3 {
+		t.Log("expected sorted set to be unboxed by NewData", NewData(s))
+		t.Fail()
+	}
+}
@@ -0,0 +1,171 @@
+package data
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//// PRETTY PRINTER
+///
+// prints trees of natives. collections, that fit into the remaining line
+// width, are printed on a single line, otherwise every element is printed
+// on a line of its own, indented by the nesting level. slices print in
+// brackets, maps in braces and pairs in parens. strings are quoted.
+type Printer struct {
+	Indent   string // indentation per nesting level
+	Width    int    // maximum line width, unlimited if < 1
+	Depth    int    // collections nested deeper are elided, unlimited if < 1
+	Length   int    // elements per collection printed, unlimited if < 1
+	SortKeys bool   // print map fields ordered by key
+	Types    bool   // annotate values with their type, like 3::Int8
+}
+
+func NewPrinter() *Printer {
+	return &Printer{Indent: "  ", Width: 80, SortKeys: true}
+}
+
+// prints the native passed with the default printer
+func Pretty(nat Native) string { return NewPrinter().Sprint(nat) }
+
+func (p *Printer) Sprint(nat Native) string { return p.print(nat, 0, 0) }
+
+func (p *Printer) Fprint(w io.Writer, nat Native) error {
+	var _, err = io.WriteString(w, p.Sprint(nat)+"\n")
+	return err
+}
+
+// a collection, decomposed to its delimiters and elements
+type prettyNode struct {
+	open, close, annotation string
+	keys, elems             []Native
+	more                    int // number of elements elided
+}
+
+// returns the delimiters and elements of collections, false for scalars
+func (p *Printer) node(nat Native) (prettyNode, bool) {
+	var n prettyNode
+	switch nat.Type() {
+	case Pair:
+		if v, ok := nat.(Paired); ok {
+			n.open, n.close = "(", ")"
+			n.elems = []Native{v.Left(), v.Right()}
+			return n, true
+		}
+	case Map:
+		if v, ok := nat.(Mapped); ok {
+			var fields = v.Fields()
+			if p.SortKeys {
+				sort.SliceStable(fields, func(i, j int) bool {
+					return Compare(fields[i].Left(), fields[j].Left()) < 0
+				})
+			}
+			n.open, n.close = "{", "}"
+			for _, field := range fields {
+				n.keys = append(n.keys, field.Left())
+				n.elems = append(n.elems, field.Right())
+			}
+			return p.truncate(n), true
+		}
+	case Slice, Unboxed:
+		if v, ok := nat.(Sliced); ok {
+			n.open, n.close = "[", "]"
+			n.elems = v.Slice()
+			if nat.Type() == Unboxed && p.Types {
+				n.annotation = "::[" + typeElem(nat).TypeName() + "]"
+			}
+			return p.truncate(n), true
+		}
+	}
+	return n, false
+}
+
+func (p *Printer) truncate(n prettyNode) prettyNode {
+	if p.Length > 0 && len(n.elems) > p.Length {
+		n.more = len(n.elems) - p.Length
+		n.elems = n.elems[:p.Length]
+		if n.keys != nil {
+			n.keys = n.keys[:p.Length]
+		}
+	}
+	return n
+}
+
+// prints scalars, annotated by their type, if the printer annotates types
+// and the scalar is not an element of an annotated unboxed vector.
+func (p *Printer) scalar(nat Native, annotate bool) string {
+	var str string
+	switch v := nat.(type) {
+	case nil:
+		return "nil"
+	case StrVal:
+		str = strconv.Quote(string(v))
+	case RuneVal:
+		str = strconv.QuoteRune(rune(v))
+	default:
+		str = nat.String()
+	}
+	if annotate && p.Types {
+		return str + "::" + nat.Type().TypeName()
+	}
+	return str
+}
+
+// prints the native on a single line
+func (p *Printer) flat(nat Native, depth int, annotate bool) string {
+	var n, ok = p.node(nat)
+	if !ok {
+		return p.scalar(nat, annotate)
+	}
+	if p.Depth > 0 && depth >= p.Depth {
+		return n.open + "…" + n.close + n.annotation
+	}
+	var elems = make([]string, 0, len(n.elems)+1)
+	for i, elem := range n.elems {
+		var str = p.flat(elem, depth+1, n.annotation == "")
+		if n.keys != nil {
+			str = p.flat(n.keys[i], depth+1, true) + ": " + str
+		}
+		elems = append(elems, str)
+	}
+	if n.more > 0 {
+		elems = append(elems, "…+"+strconv.Itoa(n.more))
+	}
+	return n.open + strings.Join(elems, ", ") + n.close + n.annotation
+}
+
+// prints the native starting at the column passed, breaks collections that
+// exceed the line width to multiple lines.
+func (p *Printer) print(nat Native, depth, col int) string {
+	var flat = p.flat(nat, depth, true)
+	var n, ok = p.node(nat)
+	if !ok || p.Width < 1 || col+utf8.RuneCountInString(flat) <= p.Width ||
+		len(n.elems) == 0 || p.Depth > 0 && depth >= p.Depth {
+		return flat
+	}
+	var indent = strings.Repeat(p.Indent, depth+1)
+	var lines = make([]string, 0, len(n.elems)+2)
+	lines = append(lines, n.open)
+	for i, elem := range n.elems {
+		var prefix = indent
+		if n.keys != nil {
+			prefix = prefix + p.print(n.keys[i], depth+1, len(indent)) + ": "
+		}
+		var col = utf8.RuneCountInString(prefix)
+		var str string
+		if n.annotation != "" {
+			str = p.flat(elem, depth+1, false)
+		} else {
+			str = p.print(elem, depth+1, col)
+		}
+		lines = append(lines, prefix+str+",")
+	}
+	if n.more > 0 {
+		lines = append(lines, indent+"…+"+strconv.Itoa(n.more))
+	}
+	lines = append(lines,
+		strings.Repeat(p.Indent, depth)+n.close+n.annotation)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func newPrettyTestTree() Native {
+	return NewSlice(
+		IntVal(1),
+		StrVal("two"),
+		NewPair(Int8Val(3), FltVal(4.5)),
+		NewHashMap(
+			NewPair(StrVal("b"), IntVec{1, 2, 3}),
+			NewPair(StrVal("a"), NewSlice(BoolVal(true), NewNil())),
+		),
+	)
+}
+
+func TestPrettyFlat(t *testing.T) {
+	var str = Pretty(newPrettyTestTree())
+	fmt.Println(str)
+	var expect = `[1, "two", (3, 4.5), {"a": [true, Nil], "b": [1, 2, 3]}]`
+	if str != expect {
+		t.Log("expected", expect, "got", str)
+		t.Fail()
+	}
+	var p = NewPrinter()
+	p.Types = true
+	str = p.Sprint(NewSlice(Int8Val(3), NewPair(StrVal("x"), IntVec{1, 2})))
+	fmt.Println(str)
+	expect = `[3::Int8, ("x"::String, [1, 2]::[Int])]`
+	if str != expect {
+		t.Log("expected", expect, "got", str)
+		t.Fail()
+	}
+}
+
+func TestPrettyBreakLines(t *testing.T) {
+	var p = NewPrinter()
+	p.Width = 30
+	var str = p.Sprint(newPrettyTestTree())
+	fmt.Println(str)
+	var expect = `[
+  1,
+  "two",
+  (3, 4.5),
+  {
+    "a": [true, Nil],
+    "b": [1, 2, 3],
+  },
+]`
+	if str != expect {
+		t.Log("expected\n" + expect + "\ngot\n" + str)
+		t.Fail()
+	}
+}
+
+func TestPrettyTruncate(t *testing.T) {
+	var p = NewPrinter()
+	p.Depth, p.Length = 2, 2
+	var tree = NewSlice(
+		NewSlice(NewSlice(IntVal(1)), IntVal(2)),
+		IntVec{1, 2, 3, 4, 5},
+		IntVal(3),
+	)
+	var str = p.Sprint(tree)
+	fmt.Println(str)
+	var expect = `[[[…], 2], [1, 2, …+3], …+1]`
+	if str != expect {
+		t.Log("expected", expect, "got", str)
+		t.Fail()
+	}
+	var buf = &bytes.Buffer{}
+	p.Width = 10
+	if err := p.Fprint(buf, tree); err != nil || buf.Len() == 0 {
+		t.Log("printing to writer failed", err)
+		t.Fail()
+	}
+	fmt.Print(buf.String())
+}
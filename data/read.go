@@ -74,6 +74,18 @@ func (v StrVal) ReadFloatVal() Native {
 	return FltVal(f)
 }
 
+// DECIMAL
+func (v StrVal) ReadDecimal() (DecimalVal, error) {
+	return ParseDecimal(string(v))
+}
+func (v StrVal) ReadDecimalVal() Native {
+	var d, err = v.ReadDecimal()
+	if err != nil {
+		return NilVal{}
+	}
+	return d
+}
+
 // DURATION
 func (v StrVal) ReadDuration() (time.Duration, error) {
 	var d, err = time.ParseDuration(v.String())
@@ -0,0 +1,497 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//// REFLECTION
+///
+// converts go values, that are not natives, nor one of the go types
+// recognized by New, by reflection. structs convert to string maps of
+// their exported fields, maps with string keys to string maps, other maps
+// to generic maps, or to hashed maps, if their keys convert to natives go
+// can't hash, slices and arrays to data slices and pointers to the native
+// of the value they point to, or nil. big numbers and time stamps, that are
+// not referenced by pointer, convert to their natives, like pointers to them
+// do.
+//
+// struct fields are named by the 'data' tag, or the 'json' tag, if there
+// is no data tag. like with encoding/json, a name of "-" omits the field
+// and the 'omitempty' option omits fields set to their zero value. values
+// that reference themselves convert to an error, where the cycle starts.
+func newReflected(val reflect.Value) Native {
+	return reflectNative(val, make(map[visit]bool))
+}
+
+// identifies pointers, maps and slices that are being converted. the type
+// tells a struct apart from its first field, the length a slice from its
+// sub slices.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// converts nested values. natives, errors, functions and values of basic
+// kind are passed to New, values that may reference others are converted
+// by reflection, sharing the set of visited references.
+func reflectNested(val reflect.Value, visited map[visit]bool) Native {
+	if !val.IsValid() {
+		return NilVal{}
+	}
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice,
+		reflect.Array, reflect.Struct:
+		if !val.Type().Implements(nativeType) &&
+			!val.Type().Implements(errorType) {
+			return reflectNative(val, visited)
+		}
+	}
+	return New(val.Interface())
+}
+
+func reflectNative(val reflect.Value, visited map[visit]bool) Native {
+	if !val.IsValid() {
+		return NilVal{}
+	}
+	switch val.Type() {
+	case timeType:
+		return TimeVal(val.Interface().(time.Time))
+	case bigIntType:
+		var v = val.Interface().(big.Int)
+		return BigIntVal(*new(big.Int).Set(&v))
+	case bigFltType:
+		var v = val.Interface().(big.Float)
+		return BigFltVal(*new(big.Float).Copy(&v))
+	case ratioType:
+		var v = val.Interface().(big.Rat)
+		return RatioVal(*new(big.Rat).Set(&v))
+	}
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if val.IsNil() {
+			break
+		}
+		var v = visit{val.Pointer(), val.Type(), 0}
+		if val.Kind() == reflect.Slice {
+			v.len = val.Len()
+		}
+		if visited[v] {
+			return NewError(fmt.Errorf(
+				"can't convert cyclic value of type %s to native", val.Type()))
+		}
+		visited[v] = true
+		defer delete(visited, v)
+	}
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return NilVal{}
+		}
+		return reflectNested(val.Elem(), visited)
+	case reflect.Bool:
+		return BoolVal(val.Bool())
+	case reflect.Int, reflect.Int64:
+		return IntVal(val.Int())
+	case reflect.Int8:
+		return Int8Val(val.Int())
+	case reflect.Int16:
+		return Int16Val(val.Int())
+	case reflect.Int32:
+		return Int32Val(val.Int())
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return UintVal(val.Uint())
+	case reflect.Uint8:
+		return ByteVal(val.Uint())
+	case reflect.Uint16:
+		return Uint16Val(val.Uint())
+	case reflect.Uint32:
+		return Uint32Val(val.Uint())
+	case reflect.Float32:
+		return Flt32Val(val.Float())
+	case reflect.Float64:
+		return FltVal(val.Float())
+	case reflect.Complex64:
+		return Imag64Val(val.Complex())
+	case reflect.Complex128:
+		return ImagVal(val.Complex())
+	case reflect.String:
+		return StrVal(val.String())
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			var buf = make([]byte, val.Len())
+			reflect.Copy(reflect.ValueOf(buf), val)
+			return BytesVal(buf)
+		}
+		var slice = make(DataSlice, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			slice = append(slice, reflectNested(val.Index(i), visited))
+		}
+		return slice
+	case reflect.Map:
+		if val.Type().Key().Kind() == reflect.String {
+			var m = make(MapString, val.Len())
+			for _, key := range val.MapKeys() {
+				m[StrVal(key.String())] = reflectNested(val.MapIndex(key), visited)
+			}
+			return m
+		}
+		var fields = make([]Paired, 0, val.Len())
+		var hashable = true
+		for _, key := range val.MapKeys() {
+			var k = reflectNested(key, visited)
+			hashable = hashable && reflect.TypeOf(k).Comparable()
+			fields = append(fields,
+				NewPair(k, reflectNested(val.MapIndex(key), visited)))
+		}
+		if !hashable {
+			return NewHashedMap(fields...)
+		}
+		return NewValMap(fields...)
+	case reflect.Struct:
+		var m = make(MapString, val.NumField())
+		for i := 0; i < val.NumField(); i++ {
+			var name, omitEmpty, ok = fieldName(val.Type().Field(i))
+			if !ok || (omitEmpty && isEmptyValue(val.Field(i))) {
+				continue
+			}
+			m[StrVal(name)] = reflectNested(val.Field(i), visited)
+		}
+		return m
+	}
+	return NewError(fmt.Errorf(
+		"can't convert value of type %s to native", val.Type()))
+}
+
+// returns the name of a struct field and if it's to be omitted, when
+// empty. returns false for unexported fields and fields tagged "-".
+func fieldName(field reflect.StructField) (string, bool, bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+	var tag, ok = field.Tag.Lookup("data")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false, false
+	}
+	var opts = strings.Split(tag, ",")
+	var name = opts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range opts[1:] {
+		if opt == "omitempty" {
+			return name, true, true
+		}
+	}
+	return name, false, true
+}
+
+func isEmptyValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return val.Len() == 0
+	case reflect.Bool:
+		return !val.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return val.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return val.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return val.Complex() == 0
+	case reflect.Interface, reflect.Ptr:
+		return val.IsNil()
+	}
+	return false
+}
+
+//// DECODING
+///
+// fills the go value, the pointer passed points to, from a tree of
+// natives, as returned by New. struct fields are looked up in maps by the
+// same names New assigns, fields missing in the map are left unchanged.
+// numbers convert to any go number type they fit into without loss,
+// targets of interface type are assigned the native, if it implements the
+// interface. nil values set the target to its zero value. type mismatches
+// are reported along with the path of the mismatching element, like
+// 'value.Items[2].Name'.
+func Decode(nat Native, ptr interface{}) error {
+	var val = reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("decoding requires a non nil pointer, got %T", ptr)
+	}
+	return decode(nat, val.Elem(), "value")
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFltType   = reflect.TypeOf(big.Float{})
+	ratioType    = reflect.TypeOf(big.Rat{})
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	nativeType   = reflect.TypeOf((*Native)(nil)).Elem()
+)
+
+func errDecode(nat Native, val reflect.Value, path string) error {
+	return fmt.Errorf("%s: can't decode %s into %s",
+		path, nat.Type().TypeName(), val.Type())
+}
+
+func decode(nat Native, val reflect.Value, path string) error {
+	if nat == nil {
+		nat = NilVal{}
+	}
+	if e, ok := nat.(ErrorVal); ok && val.Type() == errorType {
+		if e.E != nil {
+			val.Set(reflect.ValueOf(e.E))
+		}
+		return nil
+	}
+	if reflect.TypeOf(nat).AssignableTo(val.Type()) {
+		val.Set(reflect.ValueOf(nat))
+		return nil
+	}
+	if nat = indirect(nat); nat == nil {
+		nat = NilVal{}
+	}
+	if reflect.TypeOf(nat).AssignableTo(val.Type()) {
+		val.Set(reflect.ValueOf(nat))
+		return nil
+	}
+	if _, ok := nat.(NilVal); ok {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+	switch val.Type() {
+	case timeType:
+		if v, ok := nat.(TimeVal); ok {
+			val.Set(reflect.ValueOf(time.Time(v)))
+			return nil
+		}
+		return errDecode(nat, val, path)
+	case durationType:
+		if v, ok := nat.(DuraVal); ok {
+			val.SetInt(int64(v))
+			return nil
+		}
+	case bigIntType, bigFltType, ratioType:
+		return decodeBig(nat, val, path)
+	}
+	switch val.Kind() {
+	case reflect.Ptr:
+		var elem = reflect.New(val.Type().Elem())
+		if err := decode(nat, elem.Elem(), path); err != nil {
+			return err
+		}
+		val.Set(elem)
+		return nil
+	case reflect.Bool:
+		if v, ok := nat.(BoolVal); ok {
+			val.SetBool(bool(v))
+			return nil
+		}
+	case reflect.String:
+		switch v := nat.(type) {
+		case StrVal:
+			val.SetString(string(v))
+			return nil
+		case RuneVal:
+			val.SetString(string(v))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decodeInteger(nat, val, path)
+	case reflect.Float32, reflect.Float64, reflect.Complex64,
+		reflect.Complex128:
+		return decodeFloat(nat, val, path)
+	case reflect.Slice, reflect.Array:
+		return decodeSlice(nat, val, path)
+	case reflect.Map:
+		return decodeMap(nat, val, path)
+	case reflect.Struct:
+		return decodeStruct(nat, val, path)
+	}
+	return errDecode(nat, val, path)
+}
+
+// integers accept numbers, that convert to an integer without loss and
+// don't overflow the targets size.
+func decodeInteger(nat Native, val reflect.Value, path string) error {
+	if !nat.Type().Match(Rationals | Decimal | Reals | Byte | Rune) {
+		return errDecode(nat, val, path)
+	}
+	var res, err = ConvertExact(nat, BigInt)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	var i = res.(BigIntVal).GoBigInt()
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		if !i.IsInt64() || val.OverflowInt(i.Int64()) {
+			return fmt.Errorf("%s: %s overflows %s", path, i, val.Type())
+		}
+		val.SetInt(i.Int64())
+	default:
+		if !i.IsUint64() || val.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("%s: %s overflows %s", path, i, val.Type())
+		}
+		val.SetUint(i.Uint64())
+	}
+	return nil
+}
+
+// floats accept all real numbers, rounded to the nearest float, complex
+// numbers accept imaginary numbers as well.
+func decodeFloat(nat Native, val reflect.Value, path string) error {
+	var class = Rationals | Decimal | Reals
+	var to = Float
+	if val.Kind() == reflect.Complex64 || val.Kind() == reflect.Complex128 {
+		class, to = class|Imaginarys, Imag
+	}
+	if !nat.Type().Match(class) {
+		return errDecode(nat, val, path)
+	}
+	var res, err = Convert(nat, to)
+	if res == nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	if to == Imag {
+		var c = complex128(res.(ImagVal))
+		if val.OverflowComplex(c) {
+			return fmt.Errorf("%s: %v overflows %s", path, c, val.Type())
+		}
+		val.SetComplex(c)
+		return nil
+	}
+	var f = float64(res.(FltVal))
+	if val.OverflowFloat(f) {
+		return fmt.Errorf("%s: %v overflows %s", path, f, val.Type())
+	}
+	val.SetFloat(f)
+	return nil
+}
+
+func decodeBig(nat Native, val reflect.Value, path string) error {
+	if !nat.Type().Match(Rationals | Decimal | Reals) {
+		return errDecode(nat, val, path)
+	}
+	var to = map[reflect.Type]TyNat{
+		bigIntType: BigInt, bigFltType: BigFlt, ratioType: Ratio,
+	}[val.Type()]
+	var res, err = ConvertExact(nat, to)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	switch v := res.(type) {
+	case BigIntVal:
+		val.Addr().Interface().(*big.Int).Set(v.GoBigInt())
+	case BigFltVal:
+		val.Addr().Interface().(*big.Float).Set(v.GoBigFlt())
+	case RatioVal:
+		val.Addr().Interface().(*big.Rat).Set(v.GoRat())
+	}
+	return nil
+}
+
+// slices are reallocated to the length of the native slice, arrays need
+// to be of the same length. byte slices and arrays accept bytes values.
+func decodeSlice(nat Native, val reflect.Value, path string) error {
+	var elems []Native
+	switch v := nat.(type) {
+	case BytesVal:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			if val.Kind() == reflect.Array && val.Len() != len(v) {
+				return fmt.Errorf("%s: can't decode %d bytes into %s",
+					path, len(v), val.Type())
+			}
+			if val.Kind() == reflect.Slice {
+				val.Set(reflect.MakeSlice(val.Type(), len(v), len(v)))
+			}
+			reflect.Copy(val, reflect.ValueOf([]byte(v)))
+			return nil
+		}
+		return errDecode(nat, val, path)
+	case Sliced:
+		if !nat.Type().Match(Slice | Unboxed) {
+			return errDecode(nat, val, path)
+		}
+		elems = v.Slice()
+	default:
+		return errDecode(nat, val, path)
+	}
+	if val.Kind() == reflect.Array {
+		if val.Len() != len(elems) {
+			return fmt.Errorf("%s: can't decode %d elements into %s",
+				path, len(elems), val.Type())
+		}
+	} else {
+		val.Set(reflect.MakeSlice(val.Type(), len(elems), len(elems)))
+	}
+	for i, elem := range elems {
+		var err = decode(elem, val.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeMap(nat Native, val reflect.Value, path string) error {
+	var m, ok = nat.(Mapped)
+	if !ok {
+		return errDecode(nat, val, path)
+	}
+	if val.IsNil() {
+		val.Set(reflect.MakeMapWithSize(val.Type(), m.Len()))
+	}
+	for _, field := range m.Fields() {
+		var key = reflect.New(val.Type().Key()).Elem()
+		var elem = reflect.New(val.Type().Elem()).Elem()
+		var err = decode(field.Left(), key, path+"[key]")
+		if err != nil {
+			return err
+		}
+		err = decode(field.Right(), elem,
+			fmt.Sprintf("%s[%s]", path, stringOf(field.Left())))
+		if err != nil {
+			return err
+		}
+		val.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+func decodeStruct(nat Native, val reflect.Value, path string) error {
+	var m, ok = nat.(Mapped)
+	if !ok {
+		return errDecode(nat, val, path)
+	}
+	for i := 0; i < val.NumField(); i++ {
+		var field = val.Type().Field(i)
+		var name, _, ok = fieldName(field)
+		if !ok {
+			continue
+		}
+		var elem, found = m.Get(StrVal(name))
+		if !found {
+			continue
+		}
+		if err := decode(elem, val.Field(i), path+"."+field.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
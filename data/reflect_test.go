@@ -0,0 +1,223 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type reflectTestItem struct {
+	Name  string  `data:"name"`
+	Price float32 `json:"price,omitempty"`
+	Tags  []string
+}
+
+type reflectTestOrder struct {
+	ID       int64 `data:"id"`
+	Customer *string
+	Items    []reflectTestItem
+	Counts   map[string]uint8
+	Created  time.Time
+	Total    *big.Rat
+	Raw      []byte
+	Ignored  string `data:"-"`
+	hidden   bool
+}
+
+func newReflectTestOrder() reflectTestOrder {
+	var customer = "joe"
+	return reflectTestOrder{
+		ID:       7,
+		Customer: &customer,
+		Items: []reflectTestItem{
+			{Name: "apple", Price: 0.5, Tags: []string{"fruit"}},
+			{Name: "gift"},
+		},
+		Counts:  map[string]uint8{"apple": 3},
+		Created: time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+		Total:   big.NewRat(3, 2),
+		Raw:     []byte{1, 2},
+		Ignored: "ignored",
+		hidden:  true,
+	}
+}
+
+func TestNewReflected(t *testing.T) {
+	var nat = New(newReflectTestOrder())
+	fmt.Println(Pretty(nat))
+	var m, ok = nat.(MapString)
+	if !ok {
+		t.Log("structs should convert to string maps", nat.Type().TypeName())
+		t.FailNow()
+	}
+	if _, ok := m["Ignored"]; ok || len(m) != 7 {
+		t.Log("unexpected fields", m.Keys())
+		t.Fail()
+	}
+	if id, ok := m["id"].(IntVal); !ok || id != 7 {
+		t.Log("expected tagged id field", m["id"])
+		t.Fail()
+	}
+	var items = m["Items"].(DataSlice)
+	var gift = items[1].(MapString)
+	if _, ok := gift["price"]; ok || gift["name"] != StrVal("gift") {
+		t.Log("empty price should be omitted", gift)
+		t.Fail()
+	}
+	if m["Customer"] != StrVal("joe") || m["Raw"].Type() != Bytes {
+		t.Log("unexpected pointer, or bytes conversion", m["Customer"], m["Raw"])
+		t.Fail()
+	}
+	if n := New(map[int]bool{1: true}); n.Type() != Map {
+		t.Log("maps with non string keys should convert to generic maps", n)
+		t.Fail()
+	}
+	if n := New(int64(3), uint64(4)); n.Type() != Slice {
+		t.Log("expected slice of mixed natives", n)
+		t.Fail()
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var order = newReflectTestOrder()
+	var decoded reflectTestOrder
+	if err := Decode(New(order), &decoded); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	fmt.Println(decoded)
+	if decoded.ID != 7 || *decoded.Customer != "joe" ||
+		len(decoded.Items) != 2 || decoded.Items[0].Price != 0.5 ||
+		decoded.Items[0].Tags[0] != "fruit" || decoded.Counts["apple"] != 3 ||
+		!decoded.Created.Equal(order.Created) ||
+		decoded.Total.Cmp(order.Total) != 0 || len(decoded.Raw) != 2 ||
+		decoded.Ignored != "" || decoded.hidden {
+		t.Log("decoded value differs from original", decoded)
+		t.Fail()
+	}
+	var generic map[string]Native
+	if err := Decode(New(order.Items[0]), &generic); err != nil ||
+		generic["name"] != StrVal("apple") {
+		t.Log("expected natives to be assigned to native targets", generic, err)
+		t.Fail()
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var cases = []struct {
+		nat    Native
+		target interface{}
+		expect string
+	}{
+		{NewStringMap(NewPair(StrVal("id"), StrVal("x"))), &reflectTestOrder{},
+			"value.ID: can't decode String into int64"},
+		{NewStringMap(NewPair(StrVal("Items"), NewSlice(
+			NewStringMap(NewPair(StrVal("name"), IntVal(1)))))), &reflectTestOrder{},
+			"value.Items[0].Name: can't decode Int into string"},
+		{IntVal(300), new(uint8), "value: 300 overflows uint8"},
+		{FltVal(1.5), new(int), "lossy"},
+		{IntVec{1, 2}, new([3]int), "can't decode 2 elements into [3]int"},
+		{IntVal(1), reflectTestOrder{}, "non nil pointer"},
+	}
+	for _, c := range cases {
+		var err = Decode(c.nat, c.target)
+		fmt.Println(err)
+		if err == nil || !strings.Contains(err.Error(), c.expect) {
+			t.Log("expected error containing", c.expect, "got", err)
+			t.Fail()
+		}
+	}
+}
+
+type reflectTestAmounts struct {
+	Int   big.Int
+	Flt   big.Float
+	Rat   big.Rat
+	Stamp time.Time
+}
+
+func TestReflectBigValues(t *testing.T) {
+	var amounts = reflectTestAmounts{Stamp: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+	amounts.Int.SetInt64(-42)
+	amounts.Flt.SetFloat64(1.25)
+	amounts.Rat.SetFrac64(2, 3)
+	var nat = New(amounts)
+	fmt.Println(nat)
+	if f, _ := nat.(MapString).Get(StrVal("Int")); f.Type() != BigInt {
+		t.Log("expected big integer held by value to convert to BigInt", f)
+		t.Fail()
+	}
+	var decoded reflectTestAmounts
+	if err := Decode(nat, &decoded); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if decoded.Int.Cmp(&amounts.Int) != 0 || decoded.Flt.Cmp(&amounts.Flt) != 0 ||
+		decoded.Rat.Cmp(&amounts.Rat) != 0 || !decoded.Stamp.Equal(amounts.Stamp) {
+		t.Log("decoded big values differ from original", decoded)
+		t.Fail()
+	}
+}
+
+func TestReflectUnhashableKeys(t *testing.T) {
+	var arrays = map[[2]int]string{{1, 2}: "a", {3, 4}: "b"}
+	var structs = map[struct{ A int }]string{{1}: "a", {2}: "b"}
+	var m, ok = New(arrays).(Mapped)
+	if !ok || m.Len() != 2 {
+		t.Log("expected map keyed by arrays to convert to hashed map", m)
+		t.FailNow()
+	}
+	if v, found := m.Get(NewSlice(IntVal(3), IntVal(4))); !found || v != StrVal("b") {
+		t.Log("expected lookup by data slice key", v)
+		t.Fail()
+	}
+	var decoded map[[2]int]string
+	if err := Decode(m, &decoded); err != nil || decoded[[2]int{1, 2}] != "a" {
+		t.Log("expected map keyed by arrays to decode", decoded, err)
+		t.Fail()
+	}
+	var byStruct map[struct{ A int }]string
+	if err := Decode(New(structs), &byStruct); err != nil ||
+		len(byStruct) != 2 || byStruct[struct{ A int }{2}] != "b" {
+		t.Log("expected map keyed by structs to decode", byStruct, err)
+		t.Fail()
+	}
+}
+
+type reflectNode struct {
+	Name string
+	Next *reflectNode
+}
+
+func TestReflectCycles(t *testing.T) {
+	var x = reflectNode{Name: "x"}
+	x.Next = &x
+	var m, ok = New(&x).(MapString)
+	fmt.Println(m)
+	if !ok {
+		t.Log("expected self referencing struct to convert to map", m)
+		t.FailNow()
+	}
+	if _, ok := m[StrVal("Next")].(ErrorVal); !ok {
+		t.Log("expected error where the cycle starts", m[StrVal("Next")])
+		t.Fail()
+	}
+	var list = []interface{}{nil}
+	list[0] = list
+	if s, ok := New(list).(DataSlice); !ok || len(s) != 1 {
+		t.Log("expected self containing slice to convert", s)
+		t.Fail()
+	} else if _, ok := s[0].(ErrorVal); !ok {
+		t.Log("expected error for the slice containing itself", s[0])
+		t.Fail()
+	}
+	// shared, but acyclic references are no cycle
+	var y = reflectNode{Name: "y"}
+	var shared = []*reflectNode{&y, &y}
+	if s := New(shared).(DataSlice); s[1].(MapString)[StrVal("Name")] != StrVal("y") {
+		t.Log("expected shared reference to convert twice", s)
+		t.Fail()
+	}
+}
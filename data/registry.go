@@ -0,0 +1,191 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+)
+
+//// TYPE REGISTRY
+///
+// natives defined outside of this package register their type to get one
+// of the flags, that are not taken by predefined types assigned. registered
+// types are named, fetched, parsed, encoded and converted like predefined
+// natives. types should be registered during initialization, since
+// conversions are looked up without locking.
+type NativeType struct {
+	Name      string
+	Zero      Native                        // zero value
+	Read      func(string) (Native, error)  // parses the string representation
+	Unmarshal func([]byte) (Native, error)  // decodes the binary encoding
+	Marshal   func(Native) ([]byte, error)  // optional, defaults to MarshalBinary
+	Equal     func(a, b Native) bool        // optional, defaults to equal strings
+	To        map[TyNat]func(Native) Native // conversions to other types
+	From      map[TyNat]func(Native) Native // conversions from other types
+}
+
+type typeRegistry struct {
+	sync.RWMutex
+	types map[TyNat]NativeType
+	names map[string]TyNat
+	next  TyNat
+}
+
+// the most significant bit is reserved, flag decomposition ignores it
+const lastRegistrable TyNat = 1 << 62
+
+var registry = newTypeRegistry()
+
+func newTypeRegistry() *typeRegistry {
+	return &typeRegistry{
+		types: map[TyNat]NativeType{},
+		names: map[string]TyNat{},
+		next:  lastPredefined << 1,
+	}
+}
+
+// registers the native type and returns the flag assigned to it. names need
+// to be unique among predefined and registered types, registration fails,
+// when all flags are taken.
+func RegisterType(t NativeType) (TyNat, error) {
+	if t.Name == "" || t.Zero == nil || t.Read == nil || t.Unmarshal == nil {
+		return 0, fmt.Errorf(
+			"type %q needs a name, zero value, reader and decoder", t.Name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.names[t.Name]; ok {
+		return 0, fmt.Errorf("type name %s is already registered", t.Name)
+	}
+	for _, flag := range predefinedTypes() {
+		if flag.String() == t.Name {
+			return 0, fmt.Errorf("type name %s is predefined", t.Name)
+		}
+	}
+	if registry.next > lastRegistrable {
+		return 0, fmt.Errorf("no flag left to register type %s", t.Name)
+	}
+	var flag = registry.next
+	registry.next = registry.next << 1
+	registry.types[flag] = t
+	registry.names[t.Name] = flag
+	registerConversions(flag, t)
+	return flag, nil
+}
+
+// like register type, panics if registration fails
+func MustRegisterType(t NativeType) TyNat {
+	var flag, err = RegisterType(t)
+	if err != nil {
+		panic(err)
+	}
+	return flag
+}
+
+// returns the type registered for the flag passed
+func RegisteredType(flag TyNat) (NativeType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	var t, ok = registry.types[flag]
+	return t, ok
+}
+
+// returns flags of all registered types in order of registration
+func RegisteredTypes() []TyNat {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.flags()
+}
+
+func (r *typeRegistry) flags() []TyNat {
+	var flags = []TyNat{}
+	for flag := lastPredefined << 1; flag < r.next; flag = flag << 1 {
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+func isRegistered(flag TyNat) bool {
+	var _, ok = RegisteredType(flag)
+	return ok
+}
+
+// predefined and registered types are convertable
+func convertable(t TyNat) bool {
+	return t.Match(Convertables) || isRegistered(t)
+}
+
+// sets conversions from and to all convertable types, including the
+// registered type itself. expects the registry to be locked.
+func registerConversions(flag TyNat, t NativeType) {
+	var types = append(predefinedTypes(), registry.flags()...)
+	for _, other := range types {
+		if !other.Match(Convertables) && registry.types[other].Name == "" {
+			continue
+		}
+		TypeConversionTable.Set(
+			flag.Flag().Index(),
+			other.Flag().Index(),
+			t.conversionTo(flag, other),
+		)
+		if other != flag {
+			TypeConversionTable.Set(
+				other.Flag().Index(),
+				flag.Flag().Index(),
+				t.conversionFrom(other, flag),
+			)
+		}
+	}
+}
+
+// converts to nil, strings, bytes and errors like predefined natives do,
+// other conversions need to be defined by the type.
+func (t NativeType) conversionTo(flag, to TyNat) func(Native) Native {
+	if fnc, ok := t.To[to]; ok {
+		return fnc
+	}
+	switch to {
+	case flag, Nil, String, Bytes, Error:
+		return conversion(flag, to)
+	}
+	return func(arg Native) Native { return errConversion(arg, to) }
+}
+
+// nil converts to the zero value, strings, bytes and errors are read
+func (t NativeType) conversionFrom(from, flag TyNat) func(Native) Native {
+	if fnc, ok := t.From[from]; ok {
+		return fnc
+	}
+	switch from {
+	case Nil:
+		return func(Native) Native { return t.Zero }
+	case String, Bytes, Error:
+		return func(arg Native) Native { return t.read(stringOf(arg)) }
+	}
+	return func(arg Native) Native { return errConversion(arg, flag) }
+}
+
+// returns the parsed native, or an error value
+func (t NativeType) read(str string) Native {
+	var nat, err = t.Read(str)
+	if err != nil {
+		return ErrorVal{err}
+	}
+	return nat
+}
+
+func (t NativeType) equal(a, b Native) bool {
+	if t.Equal != nil {
+		return t.Equal(a, b)
+	}
+	return a.String() == b.String()
+}
+
+func (t NativeType) marshal(nat Native) ([]byte, error) {
+	if t.Marshal != nil {
+		return t.Marshal(nat)
+	}
+	if m, ok := nat.(BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+	return nil, fmt.Errorf("no binary encoding defined for type %s", t.Name)
+}
@@ -0,0 +1,186 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// semantic version as example of a native defined outside of the package
+type testVersion struct{ major, minor, patch int }
+
+var testVersionType TyNat
+
+func (v testVersion) Type() TyNat { return testVersionType }
+func (v testVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+func (v testVersion) MarshalBinary() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func readTestVersion(str string) (Native, error) {
+	var v testVersion
+	if _, err := fmt.Sscanf(str, "%d.%d.%d", &v.major, &v.minor, &v.patch); err != nil {
+		return nil, fmt.Errorf("can not parse %q as version: %s", str, err)
+	}
+	return v, nil
+}
+
+func registerTestVersion(t *testing.T) {
+	var err error
+	testVersionType, err = RegisterType(NativeType{
+		Name:      "Version",
+		Zero:      testVersion{},
+		Read:      readTestVersion,
+		Unmarshal: func(buf []byte) (Native, error) { return readTestVersion(string(buf)) },
+		To: map[TyNat]func(Native) Native{
+			Int: func(v Native) Native { return IntVal(v.(testVersion).major) },
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// removes registered types and their conversions, so that other tests see
+// predefined types only.
+func resetRegistry() {
+	for _, flag := range RegisteredTypes() {
+		var idx = flag.Flag().Index()
+		for i := range TypeConversionTable {
+			TypeConversionTable[i][idx] = nil
+			TypeConversionTable[idx][i] = nil
+		}
+	}
+	registry = newTypeRegistry()
+}
+
+func TestRegisterType(t *testing.T) {
+	defer resetRegistry()
+	registerTestVersion(t)
+	fmt.Println(testVersionType, testVersionType.TypeName())
+	if testVersionType != lastPredefined<<1 || testVersionType.TypeName() != "Version" {
+		t.Log("unexpected flag, or name", uint64(testVersionType))
+		t.Fail()
+	}
+	var types = FetchTypes()
+	if types[len(types)-1] != testVersionType {
+		t.Log("registered type should be fetched last", types)
+		t.Fail()
+	}
+	if name := (testVersionType | String).TypeName(); name != "String|Version" {
+		t.Log("unexpected name of concatenated flags", name)
+		t.Fail()
+	}
+	if f, ok := parseFlag("Version∙Int"); !ok || f != (testVersionType|Int).Flag() {
+		t.Log("registered type name should parse as flag", f)
+		t.Fail()
+	}
+	if _, err := RegisterType(NativeType{
+		Name: "Version", Zero: testVersion{},
+		Read: readTestVersion, Unmarshal: func([]byte) (Native, error) { return nil, nil },
+	}); err == nil {
+		t.Log("registering a taken name should fail")
+		t.Fail()
+	}
+	if _, err := RegisterType(NativeType{
+		Name: "Ratio", Zero: testVersion{},
+		Read: readTestVersion, Unmarshal: func([]byte) (Native, error) { return nil, nil },
+	}); err == nil {
+		t.Log("registering a predefined name should fail")
+		t.Fail()
+	}
+	if _, err := RegisterType(NativeType{Name: "Incomplete"}); err == nil {
+		t.Log("registering an incomplete type should fail")
+		t.Fail()
+	}
+	for i := 0; ; i++ {
+		var _, err = RegisterType(NativeType{
+			Name: fmt.Sprintf("Filler%d", i), Zero: testVersion{},
+			Read: readTestVersion, Unmarshal: func([]byte) (Native, error) { return nil, nil },
+		})
+		if err != nil {
+			fmt.Println(err)
+			if len(RegisteredTypes()) != 29 {
+				t.Log("unexpected number of registrable types", len(RegisteredTypes()))
+				t.Fail()
+			}
+			break
+		}
+	}
+}
+
+func TestRegisteredTypeConversion(t *testing.T) {
+	defer resetRegistry()
+	registerTestVersion(t)
+	var v, err = Convert(StrVal("1.2.3"), testVersionType)
+	if err != nil || v != (testVersion{1, 2, 3}) {
+		t.Log("conversion from string failed", v, err)
+		t.Fail()
+	}
+	if s, err := Convert(v, String); err != nil || s != StrVal("1.2.3") {
+		t.Log("conversion to string failed", s, err)
+		t.Fail()
+	}
+	if i, _ := Convert(v, Int); i != IntVal(1) {
+		t.Log("type defined conversion failed", i)
+		t.Fail()
+	}
+	if _, err := Convert(v, Float); err == nil {
+		t.Log("undefined conversion should fail")
+		t.Fail()
+	}
+	if z, _ := Convert(NilVal{}, testVersionType); z != (testVersion{}) {
+		t.Log("nil should convert to the zero value", z)
+		t.Fail()
+	}
+	if _, err := Convert(StrVal("one"), testVersionType); err == nil {
+		t.Log("conversion of invalid string should fail")
+		t.Fail()
+	}
+	var r = NewCSVReader(strings.NewReader("name,version\ngatwd,0.1.0\n"))
+	r.Types["version"] = testVersionType
+	var _, rows, _ = r.ReadRows()
+	if rows[0].(DataSlice)[1] != (testVersion{0, 1, 0}) {
+		t.Log("csv reader should parse registered types", rows)
+		t.Fail()
+	}
+}
+
+func TestRegisteredTypeData(t *testing.T) {
+	defer resetRegistry()
+	registerTestVersion(t)
+	var data = NewData(testVersion{1, 0, 0}, testVersion{2, 1, 0})
+	fmt.Println(data)
+	if data.Type() != Slice || data.(DataSlice).Len() != 2 {
+		t.Log("natives of registered types should be sliced", data)
+		t.Fail()
+	}
+	var buf, err = data.(DataSlice).MarshalBinary()
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	back, err := UnmarshalNative(Slice, buf)
+	if err != nil || !DeepEqual(back, data) {
+		t.Log("binary round trip failed", back, err)
+		t.Fail()
+	}
+	var stream = &bytes.Buffer{}
+	if err := NewEncoder(stream).Encode(data); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	if back, err = NewDecoder(stream).Decode(); err != nil || !DeepEqual(back, data) {
+		t.Log("encoding round trip failed", back, err)
+		t.Fail()
+	}
+	if Hash(testVersion{1, 0, 0}) != Hash(testVersion{1, 0, 0}) ||
+		Compare(testVersion{1, 0, 0}, testVersion{2, 0, 0}) != -1 {
+		t.Log("registered natives should hash and compare")
+		t.Fail()
+	}
+}
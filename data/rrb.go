@@ -0,0 +1,343 @@
+package data
+
+//// PERSISTENT VECTOR
+///
+// relaxed radix balanced tree of natives. get, set, append, concat and
+// range descend a single path of the tree, which is at most log32(n) levels
+// deep, and return new versions, that share all unchanged nodes with the
+// version they have been derived from, which stays valid and unchanged.
+//
+// leafs hold up to 32 natives, branches up to 32 sub trees and the
+// cumulative number of natives contained by their sub trees. trees built by
+// appending are dense, the radix of an index yields its sub tree directly.
+// concatenation and slicing may leave sub trees partially filled, which
+// makes the radix a lower bound of the sub tree index, that is corrected by
+// scanning the cumulative sizes.
+type Vector struct {
+	root  *rrbNode
+	size  int
+	shift uint // shift of the roots level, zero if the root is a leaf
+}
+
+const (
+	rrbBits  = 5
+	rrbWidth = 1 << rrbBits
+)
+
+type rrbNode struct {
+	elems []Native   // elements of a leaf
+	nodes []*rrbNode // sub trees of a branch
+	sizes []int      // cumulative number of elements per sub tree
+}
+
+func NewVector(elems ...Native) Vector {
+	if len(elems) == 0 {
+		return Vector{}
+	}
+	var nodes = make([]*rrbNode, 0, (len(elems)+rrbWidth-1)/rrbWidth)
+	for i := 0; i < len(elems); i += rrbWidth {
+		var j = i + rrbWidth
+		if j > len(elems) {
+			j = len(elems)
+		}
+		nodes = append(nodes, &rrbNode{elems: append([]Native{}, elems[i:j]...)})
+	}
+	var shift uint
+	for len(nodes) > 1 {
+		nodes, shift = packNodes(nodes), shift+rrbBits
+	}
+	return Vector{nodes[0], len(elems), shift}
+}
+
+// packs nodes into branches of up to 32 sub trees each
+func packNodes(nodes []*rrbNode) []*rrbNode {
+	var packed = make([]*rrbNode, 0, (len(nodes)+rrbWidth-1)/rrbWidth)
+	for i := 0; i < len(nodes); i += rrbWidth {
+		var j = i + rrbWidth
+		if j > len(nodes) {
+			j = len(nodes)
+		}
+		packed = append(packed, newBranch(append([]*rrbNode{}, nodes[i:j]...)))
+	}
+	return packed
+}
+
+func newBranch(nodes []*rrbNode) *rrbNode {
+	var sizes = make([]int, len(nodes))
+	var sum int
+	for i, n := range nodes {
+		sum = sum + n.len()
+		sizes[i] = sum
+	}
+	return &rrbNode{nodes: nodes, sizes: sizes}
+}
+
+func (n *rrbNode) len() int {
+	if n.nodes == nil {
+		return len(n.elems)
+	}
+	return n.sizes[len(n.sizes)-1]
+}
+
+// index of the sub tree containing the element at index i and the index of
+// the element within that sub tree.
+func (n *rrbNode) index(i int, shift uint) (int, int) {
+	var idx = i >> shift
+	if idx >= len(n.nodes) {
+		idx = len(n.nodes) - 1
+	}
+	for n.sizes[idx] <= i {
+		idx++
+	}
+	if idx > 0 {
+		i = i - n.sizes[idx-1]
+	}
+	return idx, i
+}
+
+func (v Vector) Type() TyNat     { return Slice }
+func (v Vector) TypeElem() Typed { return TyNat(sliceContainsTypes(v.Slice())) }
+func (v Vector) Len() int        { return v.size }
+func (v Vector) Empty() bool     { return v.size == 0 }
+func (v Vector) Null() Native    { return Vector{} }
+
+func (v Vector) Slice() []Native {
+	var elems = make([]Native, 0, v.size)
+	v.Each(func(_ int, nat Native) bool {
+		elems = append(elems, nat)
+		return true
+	})
+	return elems
+}
+
+// calls the function for every element in ascending order of its index,
+// until it returns false.
+func (v Vector) Each(fn func(int, Native) bool) {
+	if v.root != nil {
+		var i int
+		v.root.each(&i, fn)
+	}
+}
+
+func (n *rrbNode) each(i *int, fn func(int, Native) bool) bool {
+	for _, nat := range n.elems {
+		if !fn(*i, nat) {
+			return false
+		}
+		*i = *i + 1
+	}
+	for _, sub := range n.nodes {
+		if !sub.each(i, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the element at index i, or nil, if the index is out of range
+func (v Vector) GetInt(i int) Native {
+	if i < 0 || i >= v.size {
+		return nil
+	}
+	var n, shift = v.root, v.shift
+	for shift > 0 {
+		var idx int
+		idx, i = n.index(i, shift)
+		n, shift = n.nodes[idx], shift-rrbBits
+	}
+	return n.elems[i]
+}
+
+func (v Vector) Get(i Native) Native {
+	if idx, ok := i.(IntVal); ok {
+		return v.GetInt(idx.GoInt())
+	}
+	return nil
+}
+
+func (v Vector) Head() Native {
+	return v.GetInt(0)
+}
+
+// tail and shift return data slices, as defined by sequential. rest returns
+// the tail as vector.
+func (v Vector) Tail() DataSlice { return DataSlice(v.Rest().Slice()) }
+func (v Vector) Rest() Vector    { return v.Drop(1) }
+func (v Vector) Shift() (Native, DataSlice) {
+	return v.Head(), v.Tail()
+}
+
+// returns a new version with the element at index i replaced. indices out
+// of range return the vector unchanged.
+func (v Vector) SetInt(i int, nat Native) Vector {
+	if i < 0 || i >= v.size {
+		return v
+	}
+	return Vector{v.root.set(i, v.shift, nat), v.size, v.shift}
+}
+
+func (n *rrbNode) set(i int, shift uint, nat Native) *rrbNode {
+	if shift == 0 {
+		var elems = append([]Native{}, n.elems...)
+		elems[i] = nat
+		return &rrbNode{elems: elems}
+	}
+	var idx, j = n.index(i, shift)
+	var nodes = append([]*rrbNode{}, n.nodes...)
+	nodes[idx] = nodes[idx].set(j, shift-rrbBits, nat)
+	return &rrbNode{nodes: nodes, sizes: n.sizes}
+}
+
+// returns a new version with the elements appended
+func (v Vector) Append(elems ...Native) Vector {
+	for _, nat := range elems {
+		if v.root == nil {
+			v = Vector{&rrbNode{elems: []Native{nat}}, 1, 0}
+			continue
+		}
+		var root, overflow = v.root.push(v.shift, nat)
+		if overflow != nil {
+			root = newBranch([]*rrbNode{root, overflow})
+			v.shift = v.shift + rrbBits
+		}
+		v = Vector{root, v.size + 1, v.shift}
+	}
+	return v
+}
+
+// appends to the rightmost path. full nodes return a new path of the same
+// level as overflow, to be appended to their parent.
+func (n *rrbNode) push(shift uint, nat Native) (*rrbNode, *rrbNode) {
+	if shift == 0 {
+		if len(n.elems) < rrbWidth {
+			var elems = make([]Native, len(n.elems), len(n.elems)+1)
+			copy(elems, n.elems)
+			return &rrbNode{elems: append(elems, nat)}, nil
+		}
+		return n, &rrbNode{elems: []Native{nat}}
+	}
+	var last = len(n.nodes) - 1
+	var sub, overflow = n.nodes[last].push(shift-rrbBits, nat)
+	var nodes = append([]*rrbNode{}, n.nodes...)
+	nodes[last] = sub
+	if overflow == nil {
+		return newBranch(nodes), nil
+	}
+	if len(nodes) < rrbWidth {
+		return newBranch(append(nodes, overflow)), nil
+	}
+	return newBranch(nodes), newBranch([]*rrbNode{overflow})
+}
+
+// returns a new vector of the elements of both vectors. the trees are
+// joined along the right edge of the first and the left edge of the second
+// tree, the nodes along the seam are repacked, all other nodes are shared.
+func (v Vector) Concat(w Vector) Vector {
+	if w.size == 0 {
+		return v
+	}
+	if v.size == 0 {
+		return w
+	}
+	var root = concatNodes(v.root, v.shift, w.root, w.shift)
+	var shift = v.shift
+	if w.shift > shift {
+		shift = w.shift
+	}
+	shift = shift + rrbBits
+	for len(root.nodes) == 1 && shift > 0 {
+		root, shift = root.nodes[0], shift-rrbBits
+	}
+	return Vector{root, v.size + w.size, shift}
+}
+
+// joins two trees and returns a branch one level above the higher tree,
+// containing the joined sub trees.
+func concatNodes(a *rrbNode, ashift uint, b *rrbNode, bshift uint) *rrbNode {
+	switch {
+	case ashift > bshift:
+		var last = len(a.nodes) - 1
+		var mid = concatNodes(a.nodes[last], ashift-rrbBits, b, bshift)
+		return repack(a.nodes[:last], mid.nodes, nil)
+	case ashift < bshift:
+		var mid = concatNodes(a, ashift, b.nodes[0], bshift-rrbBits)
+		return repack(nil, mid.nodes, b.nodes[1:])
+	case ashift == 0:
+		var elems = make([]Native, 0, len(a.elems)+len(b.elems))
+		elems = append(append(elems, a.elems...), b.elems...)
+		var leafs = make([]*rrbNode, 0, 2)
+		for i := 0; i < len(elems); i += rrbWidth {
+			var j = i + rrbWidth
+			if j > len(elems) {
+				j = len(elems)
+			}
+			leafs = append(leafs, &rrbNode{elems: elems[i:j:j]})
+		}
+		return newBranch(leafs)
+	}
+	var last = len(a.nodes) - 1
+	var mid = concatNodes(a.nodes[last], ashift-rrbBits, b.nodes[0], bshift-rrbBits)
+	return repack(a.nodes[:last], mid.nodes, b.nodes[1:])
+}
+
+// packs the sub trees into branches of up to 32 sub trees and returns a
+// branch containing those.
+func repack(left, mid, right []*rrbNode) *rrbNode {
+	var nodes = make([]*rrbNode, 0, len(left)+len(mid)+len(right))
+	nodes = append(append(append(nodes, left...), mid...), right...)
+	return newBranch(packNodes(nodes))
+}
+
+// returns the first n elements as vector
+func (v Vector) Take(n int) Vector {
+	if n >= v.size {
+		return v
+	}
+	if n <= 0 {
+		return Vector{}
+	}
+	return Vector{v.root.take(n, v.shift), n, v.shift}.collapse()
+}
+
+// returns the vector without its first n elements
+func (v Vector) Drop(n int) Vector {
+	if n <= 0 {
+		return v
+	}
+	if n >= v.size {
+		return Vector{}
+	}
+	return Vector{v.root.drop(n, v.shift), v.size - n, v.shift}.collapse()
+}
+
+// returns the elements from index s, up to, but not including index e
+func (v Vector) Sub(s, e int) Vector { return v.Take(e).Drop(s) }
+
+func (v Vector) Range(s, e int) Sliceable { return v.Sub(s, e) }
+
+// removes branches with a single sub tree from the top of the tree
+func (v Vector) collapse() Vector {
+	for v.shift > 0 && len(v.root.nodes) == 1 {
+		v.root, v.shift = v.root.nodes[0], v.shift-rrbBits
+	}
+	return v
+}
+
+func (n *rrbNode) take(k int, shift uint) *rrbNode {
+	if shift == 0 {
+		return &rrbNode{elems: n.elems[:k:k]}
+	}
+	var idx, j = n.index(k-1, shift)
+	var nodes = append([]*rrbNode{}, n.nodes[:idx]...)
+	return newBranch(append(nodes, n.nodes[idx].take(j+1, shift-rrbBits)))
+}
+
+func (n *rrbNode) drop(k int, shift uint) *rrbNode {
+	if shift == 0 {
+		return &rrbNode{elems: n.elems[k:]}
+	}
+	var idx, j = n.index(k, shift)
+	var nodes = make([]*rrbNode, 0, len(n.nodes)-idx)
+	nodes = append(nodes, n.nodes[idx].drop(j, shift-rrbBits))
+	return newBranch(append(nodes, n.nodes[idx+1:]...))
+}
@@ -0,0 +1,193 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var (
+	_ Sliceable  = Vector{}
+	_ Sequential = Vector{}
+)
+
+// checks, that all leafs are at the same level, nodes hold no more than
+// 32 elements, or sub trees, sub trees don't exceed the capacity of their
+// level and cumulative sizes are correct.
+func checkRRBNode(n *rrbNode, shift uint) error {
+	if shift == 0 {
+		if n.nodes != nil || len(n.elems) == 0 || len(n.elems) > rrbWidth {
+			return fmt.Errorf("malformed leaf of %d elements", len(n.elems))
+		}
+		return nil
+	}
+	if len(n.nodes) == 0 || len(n.nodes) > rrbWidth || len(n.sizes) != len(n.nodes) {
+		return fmt.Errorf("malformed branch of %d sub trees", len(n.nodes))
+	}
+	var sum int
+	for i, sub := range n.nodes {
+		if err := checkRRBNode(sub, shift-rrbBits); err != nil {
+			return err
+		}
+		if sub.len() > 1<<shift {
+			return fmt.Errorf("sub tree exceeds capacity: %d", sub.len())
+		}
+		sum = sum + sub.len()
+		if n.sizes[i] != sum {
+			return fmt.Errorf("size %d at %d, expected %d", n.sizes[i], i, sum)
+		}
+	}
+	return nil
+}
+
+func checkVector(t *testing.T, v Vector, model []Native) {
+	if v.Len() != len(model) {
+		t.Log("expected length", len(model), "got", v.Len())
+		t.FailNow()
+	}
+	if v.root != nil {
+		if err := checkRRBNode(v.root, v.shift); err != nil || v.root.len() != v.size {
+			t.Log("malformed tree", err)
+			t.FailNow()
+		}
+	}
+	for i, nat := range model {
+		if v.GetInt(i) != nat {
+			t.Log("expected", nat, "at", i, "got", v.GetInt(i))
+			t.FailNow()
+		}
+	}
+	if !equalSlices(v.Slice(), model) {
+		t.Log("unexpected elements", v.Slice())
+		t.FailNow()
+	}
+}
+
+func newIntVector(from, to int) (Vector, []Native) {
+	var model = []Native{}
+	for i := from; i < to; i++ {
+		model = append(model, IntVal(i))
+	}
+	return NewVector(model...), model
+}
+
+func TestVectorBasics(t *testing.T) {
+	var v, model = newIntVector(0, 1500)
+	checkVector(t, v, model)
+	var w = v.SetInt(1000, StrVal("x"))
+	if v.GetInt(1000) != IntVal(1000) || w.GetInt(1000) != StrVal("x") {
+		t.Log("set should not change the original version")
+		t.Fail()
+	}
+	var a = Vector{}
+	for i := 0; i < 1100; i++ {
+		a = a.Append(IntVal(i))
+	}
+	checkVector(t, a, model[:1100])
+	if a.shift != 2*rrbBits || a.Append(IntVal(0)).Len() != 1101 || a.Len() != 1100 {
+		t.Log("unexpected shift, or append changed the original", a.shift)
+		t.Fail()
+	}
+	var head, tail = v.Shift()
+	if head != IntVal(0) || len(tail) != 1499 || v.Rest().Head() != IntVal(1) {
+		t.Log("unexpected head, or tail", head, len(tail))
+		t.Fail()
+	}
+	checkVector(t, v.Sub(33, 1070), model[33:1070])
+	checkVector(t, v.Range(0, 0).(Vector), nil)
+	if v.Get(IntVal(7)) != IntVal(7) || v.GetInt(1500) != nil || !(Vector{}).Empty() {
+		t.Log("unexpected get, or empty")
+		t.Fail()
+	}
+	fmt.Println(NewVector(IntVal(1), StrVal("two")))
+}
+
+func TestVectorConcat(t *testing.T) {
+	var v, vm = newIntVector(0, 1025)
+	var w, wm = newIntVector(1025, 1030)
+	var c = v.Concat(w)
+	checkVector(t, c, append(append([]Native{}, vm...), wm...))
+	checkVector(t, v, vm)
+	c = w.Concat(v)
+	checkVector(t, c, append(append([]Native{}, wm...), vm...))
+	// building by concatenating small vectors needs to keep the tree flat
+	var b = Vector{}
+	var model = []Native{}
+	for i := 0; i < 2000; i++ {
+		b = b.Concat(NewVector(IntVal(i), IntVal(-i)))
+		model = append(model, IntVal(i), IntVal(-i))
+	}
+	checkVector(t, b, model)
+	if b.shift > 3*rrbBits {
+		t.Log("tree too deep", b.shift/rrbBits)
+		t.Fail()
+	}
+}
+
+func TestVectorRandomized(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(42))
+	var v, model = Vector{}, []Native{}
+	for step := 0; step < 3000; step++ {
+		var prev, pm = v, append([]Native{}, model...)
+		switch op := rnd.Intn(6); {
+		case op == 0:
+			var n = rnd.Intn(100)
+			var w, wm = newIntVector(step*100, step*100+n)
+			v, model = v.Concat(w), append(model, wm...)
+		case op == 1:
+			var n = rnd.Intn(100)
+			var w, wm = newIntVector(step*100, step*100+n)
+			v, model = w.Concat(v), append(wm, model...)
+		case op == 2 && len(model) > 0:
+			var s = rnd.Intn(len(model))
+			var e = s + rnd.Intn(len(model)-s+1)
+			v, model = v.Sub(s, e), append([]Native{}, model[s:e]...)
+		case op == 3 && len(model) > 0:
+			var i = rnd.Intn(len(model))
+			v = v.SetInt(i, StrVal(fmt.Sprint(step)))
+			model[i] = StrVal(fmt.Sprint(step))
+		default:
+			v, model = v.Append(IntVal(step)), append(model, IntVal(step))
+		}
+		checkVector(t, v, model)
+		// previous version stays valid
+		if step%100 == 0 {
+			checkVector(t, prev, pm)
+		}
+		if len(model) > 5000 {
+			v, model = v.Drop(len(model)-1000), model[len(model)-1000:]
+		}
+	}
+}
+
+func TestVectorSerialization(t *testing.T) {
+	var v, _ = newIntVector(0, 100)
+	v = v.Append(StrVal("x"), NewSlice(IntVal(1)))
+	var buf, err = json.Marshal(v)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var decoded Vector
+	if err = json.Unmarshal(buf, &decoded); err != nil || !DeepEqual(v, decoded) {
+		t.Log("expected vector to survive json round trip", decoded, err)
+		t.Fail()
+	}
+	var stream = bytes.NewBuffer([]byte{})
+	if err = NewEncoder(stream).Encode(v); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var nat Native
+	nat, err = NewDecoder(stream).Decode()
+	if _, ok := nat.(Vector); !ok || err != nil || !DeepEqual(v, nat) {
+		t.Log("expected vector to be decoded as vector", nat, err)
+		t.Fail()
+	}
+	if vec, ok := NewData(NewVector(IntVal(1), IntVal(2))).(IntVec); !ok || len(vec) != 2 {
+		t.Log("expected vector to be unboxed by NewData", vec)
+		t.Fail()
+	}
+}
@@ -17,7 +17,7 @@ func (p PairVal) TypeValue() TyNat       { return p.R.Type() }
 func NewValMap(acc ...Paired) Mapped {
 	var m = make(map[Native]Native)
 	for _, pair := range acc {
-		m[pair.Left().(StrVal)] = pair.Right()
+		m[pair.Left()] = pair.Right()
 	}
 	return MapVal(m)
 }
@@ -74,20 +74,20 @@ func (s MapVal) Has(acc Native) bool {
 }
 
 func (s MapVal) Get(acc Native) (Native, bool) {
-	if dat, ok := s[acc.(StrVal)]; ok {
+	if dat, ok := s[acc]; ok {
 		return dat, ok
 	}
 	return nil, false
 }
 
 func (s MapVal) Set(acc Native, dat Native) Mapped {
-	s[acc.(StrVal)] = dat
+	s[acc] = dat
 	return s
 }
 
 func (s MapVal) Delete(acc Native) bool {
-	if _, ok := s[acc.(StrVal)]; ok {
-		delete(s, s[acc.(StrVal)])
+	if _, ok := s[acc]; ok {
+		delete(s, acc)
 		return ok
 	}
 	return false
@@ -576,3 +576,115 @@ func (s MapFlag) Set(acc Native, dat Native) Mapped {
 	s[acc.(BitFlag)] = dat
 	return s
 }
+
+// implements Mapped keyed by hash of any native, fields with colliding
+// hashes are kept in the same bucket and told apart by deep equality.
+func NewHashedMap(acc ...Paired) Mapped {
+	var m = make(map[uint64][]PairVal)
+	for _, pair := range acc {
+		MapHash(m).Set(pair.Left(), pair.Right())
+	}
+	return MapHash(m)
+}
+
+func (s MapHash) First() Paired {
+	if s.Len() > 0 {
+		return s.Fields()[0]
+	}
+	return NewPair(NewNil(), NewNil())
+}
+
+func (s MapHash) Type() TyNat      { return Map }
+func (s MapHash) TypeKey() Typed   { return s.First().Left().Type() }
+func (s MapHash) TypeValue() Typed { return s.First().Right().Type() }
+
+func (s MapHash) Len() int {
+	var l int
+	for _, bucket := range s {
+		l += len(bucket)
+	}
+	return l
+}
+
+func (s MapHash) Keys() []Native {
+	var keys = []Native{}
+	for _, bucket := range s {
+		for _, pair := range bucket {
+			keys = append(keys, pair.L)
+		}
+	}
+	return keys
+}
+
+func (s MapHash) Data() []Native {
+	var dat = []Native{}
+	for _, bucket := range s {
+		for _, pair := range bucket {
+			dat = append(dat, pair.R)
+		}
+	}
+	return dat
+}
+
+func (s MapHash) Slice() []Native {
+	var native = []Native{}
+	for _, bucket := range s {
+		for _, pair := range bucket {
+			native = append(native, pair)
+		}
+	}
+	return native
+}
+
+func (s MapHash) Fields() []Paired {
+	var pairs = []Paired{}
+	for _, bucket := range s {
+		for _, pair := range bucket {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+func (s MapHash) Has(acc Native) bool {
+	var _, ok = s.Get(acc)
+	return ok
+}
+
+func (s MapHash) Get(acc Native) (Native, bool) {
+	for _, pair := range s[Hash(acc)] {
+		if DeepEqual(pair.L, acc) {
+			return pair.R, true
+		}
+	}
+	return nil, false
+}
+
+func (s MapHash) Delete(acc Native) bool {
+	var key = Hash(acc)
+	var bucket = s[key]
+	for i, pair := range bucket {
+		if DeepEqual(pair.L, acc) {
+			if len(bucket) == 1 {
+				delete(s, key)
+				return true
+			}
+			s[key] = append(bucket[:i:i], bucket[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s MapHash) Set(acc Native, dat Native) Mapped {
+	var key = Hash(acc)
+	var bucket = s[key]
+	for i, pair := range bucket {
+		if DeepEqual(pair.L, acc) {
+			bucket[i] = PairVal{acc, dat}
+			return s
+		}
+	}
+	s[key] = append(bucket, PairVal{acc, dat})
+	return s
+}
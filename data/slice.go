@@ -36,7 +36,7 @@ func (c DataSlice) Copy() Native {
 	// range over slice elements
 	for _, dat := range c {
 		// append deep-copy of every element to the freshly allocated slice
-		ds = append(ds, dat.(Reproduceable).Copy())
+		ds = append(ds, DeepCopy(dat))
 	}
 	// return copyed slice
 	return ds
@@ -94,8 +94,8 @@ func (s DataSlice) Shift() (head Native, tail DataSlice) {
 func SliceClear(s DataSlice) {
 	if len(s) > 0 {
 		for _, v := range s {
-			if d, ok := v.(Destructable); ok {
-				d.Clear()
+			if v != nil {
+				clearNative(v)
 			}
 		}
 	}
@@ -153,8 +153,10 @@ func SliceToNatives(c DataSlice) Sliceable {
 		return c.Type().Match(flag)
 	}) {
 		// if all elements yield the same type, convert to unboxed
-		// slice of natives
-		return NewUnboxed(flag, c.Slice()...)
+		// slice of natives, if an unboxed vector of that type exists
+		if vec := NewUnboxed(flag, c.Slice()...); vec != nil {
+			return vec
+		}
 	}
 	// return unconverted slice, since elment types are impure
 	return c
@@ -365,6 +367,9 @@ func SliceSwap(c DataSlice, i, j int) DataSlice {
 	return c
 }
 
+// letters are sorted by their string representation, type flags by their
+// value. all other types sort natives of any type by the total order
+// defined by compare.
 func newSliceLess(c DataSlice, compT TyNat) func(i, j int) bool {
 	chain := c
 	var fn func(i, j int) bool
@@ -375,7 +380,7 @@ func newSliceLess(c DataSlice, compT TyNat) func(i, j int) bool {
 			if strings.Compare(
 				string(chain[i].String()),
 				string(chain[j].String()),
-			) <= 0 {
+			) < 0 {
 				return true
 			}
 			return false
@@ -388,21 +393,9 @@ func newSliceLess(c DataSlice, compT TyNat) func(i, j int) bool {
 			}
 			return false
 		}
-	case FlagMatch(f, Naturals.Type().Flag()):
-		fn = func(i, j int) bool {
-			if uint(chain[i].(Natural).GoUint()) <
-				uint(chain[j].(Natural).GoUint()) {
-				return true
-			}
-			return false
-		}
-	case FlagMatch(f, Integers.Type().Flag()):
+	default:
 		fn = func(i, j int) bool {
-			if int(chain[i].(Integer).Idx()) <
-				int(chain[j].(Integer).Idx()) {
-				return true
-			}
-			return false
+			return Compare(chain[i], chain[j]) < 0
 		}
 	}
 	return fn
@@ -417,6 +410,8 @@ func (c DataSlice) Sort(compT TyNat) {
 	c = SliceSort(c, compT)
 }
 
+// search function matching the ordering of a slice sorted by the type of
+// the native searched for.
 func newSliceSearchFnc(c DataSlice, comp Native) func(i int) bool {
 	var fn func(i int) bool
 	f := comp.Type().Flag()
@@ -431,15 +426,9 @@ func newSliceSearchFnc(c DataSlice, comp Native) func(i int) bool {
 			return c[i].Type() >=
 				comp.Type()
 		}
-	case FlagMatch(f, Naturals.Type().Flag()):
+	default:
 		fn = func(i int) bool {
-			return uint(c[i].(Natural).GoUint()) >=
-				uint(comp.(Natural).GoUint())
-		}
-	case FlagMatch(f, Integers.Type().Flag()):
-		fn = func(i int) bool {
-			return int(c[i].(Integer).Idx()) >=
-				int(comp.(Integer).Idx())
+			return Compare(c[i], comp) >= 0
 		}
 	}
 	return fn
@@ -447,15 +436,20 @@ func newSliceSearchFnc(c DataSlice, comp Native) func(i int) bool {
 
 func SliceSearch(c DataSlice, comp Native) Native {
 	idx := sort.Search(c.Len(), newSliceSearchFnc(c, comp))
+	if idx == c.Len() {
+		return NewNil()
+	}
 	var dat = SliceGet(c, idx)
 	return dat
 }
 
+// returns all elements equal to the native searched for
 func SliceSearchRange(c DataSlice, comp Native) []Native {
 	var idx = sort.Search(c.Len(), newSliceSearchFnc(c, comp))
 	var dat = []Native{}
-	for SliceGet(c, idx).Type().Flag().Match(comp.Type().Flag()) {
+	for idx < c.Len() && DeepEqual(SliceGet(c, idx), comp) {
 		dat = append(dat, SliceGet(c, idx))
+		idx += 1
 	}
 	return dat
 }
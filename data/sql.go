@@ -0,0 +1,227 @@
+package data
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//// SQL
+///
+// scalar natives implement driver.Valuer to be passed as query arguments
+// and sql.Scanner to be scanned from query results. big integers are
+// passed as text, to not lose precision. NULL scans to the nil value only,
+// columns that may be NULL need to be scanned by ScanRows, ScanColumns, or
+// an instance of NullNative.
+func (v NilVal) Value() (driver.Value, error)    { return nil, nil }
+func (v BoolVal) Value() (driver.Value, error)   { return bool(v), nil }
+func (v IntVal) Value() (driver.Value, error)    { return int64(v), nil }
+func (v FltVal) Value() (driver.Value, error)    { return float64(v), nil }
+func (v StrVal) Value() (driver.Value, error)    { return string(v), nil }
+func (v BytesVal) Value() (driver.Value, error)  { return []byte(v), nil }
+func (v TimeVal) Value() (driver.Value, error)   { return time.Time(v), nil }
+func (v BigIntVal) Value() (driver.Value, error) { return v.String(), nil }
+
+func (v *NilVal) Scan(src interface{}) error {
+	if src != nil {
+		return fmt.Errorf("can't scan %T into Nil", src)
+	}
+	return nil
+}
+func (v *BoolVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, Bool)
+	if err == nil {
+		*v = nat.(BoolVal)
+	}
+	return err
+}
+func (v *IntVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, Int)
+	if err == nil {
+		*v = nat.(IntVal)
+	}
+	return err
+}
+func (v *FltVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, Float)
+	if err == nil {
+		*v = nat.(FltVal)
+	}
+	return err
+}
+func (v *StrVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, String)
+	if err == nil {
+		*v = nat.(StrVal)
+	}
+	return err
+}
+func (v *BytesVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, Bytes)
+	if err == nil {
+		*v = nat.(BytesVal)
+	}
+	return err
+}
+func (v *TimeVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, Time)
+	if err == nil {
+		*v = nat.(TimeVal)
+	}
+	return err
+}
+func (v *BigIntVal) Scan(src interface{}) error {
+	var nat, err = scanNative(src, BigInt)
+	if err == nil {
+		*v = nat.(BigIntVal)
+	}
+	return err
+}
+
+// converts a value returned by a driver to the native type passed.
+// conversions that lose information fail, byte slices are copied, since
+// drivers may reuse them for the next row.
+func scanNative(src interface{}, to TyNat) (Native, error) {
+	var nat = newScanned(src)
+	if _, ok := nat.(NilVal); ok {
+		return nil, fmt.Errorf("can't scan NULL into %s", to.TypeName())
+	}
+	var res, err = ConvertExact(nat, to)
+	if err != nil {
+		return nil, fmt.Errorf("can't scan %T into %s: %s",
+			src, to.TypeName(), err)
+	}
+	return res, nil
+}
+
+func newScanned(src interface{}) Native {
+	switch v := src.(type) {
+	case nil:
+		return NilVal{}
+	case []byte:
+		return BytesVal(append([]byte{}, v...))
+	}
+	return New(src)
+}
+
+// scans values of any type a driver returns, NULL scans to the nil value
+type NullNative struct{ Native Native }
+
+func (n *NullNative) Scan(src interface{}) error {
+	n.Native = newScanned(src)
+	return nil
+}
+
+// text columns are told apart from binary columns by their scan type, or
+// the database type name reported by the driver. drivers that report
+// neither, yield text as bytes.
+func textColumns(rows *sql.Rows) ([]bool, error) {
+	var types, err = rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	var text = make([]bool, len(types))
+	for i, typ := range types {
+		text[i] = isTextColumn(typ)
+	}
+	return text, nil
+}
+
+var textTypeNames = []string{"CHAR", "TEXT", "CLOB", "STRING", "JSON", "UUID", "XML"}
+
+func isTextColumn(typ *sql.ColumnType) bool {
+	if scan := typ.ScanType(); scan != nil && (scan.Kind() == reflect.String ||
+		scan == reflect.TypeOf(sql.NullString{})) {
+		return true
+	}
+	var name = strings.ToUpper(typ.DatabaseTypeName())
+	for _, text := range textTypeNames {
+		if strings.Contains(name, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// yields the scanned value of a column, text passed as bytes is converted
+// to a string.
+func (n NullNative) column(text bool) Native {
+	if b, ok := n.Native.(BytesVal); ok && text {
+		return StrVal(b)
+	}
+	return n.Native
+}
+
+// reads all rows and returns a slice of string maps, mapping column names
+// to values. text columns yield strings, even if the driver returns them
+// as bytes. closes the rows when done.
+func ScanRows(rows *sql.Rows) (DataSlice, error) {
+	defer rows.Close()
+	var cols, err = rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var text []bool
+	if text, err = textColumns(rows); err != nil {
+		return nil, err
+	}
+	var slice = DataSlice{}
+	var vals = make([]NullNative, len(cols))
+	var dest = make([]interface{}, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		var row = make(MapString, len(cols))
+		for i, col := range cols {
+			row[StrVal(col)] = vals[i].column(text[i])
+		}
+		slice = append(slice, row)
+	}
+	return slice, rows.Err()
+}
+
+// reads all rows and returns a string map, mapping column names to
+// vectors of column values. columns yielding values of a single type are
+// returned as unboxed vectors, others, like columns containing NULL, as
+// data slices. text columns are scanned like ScanRows does. closes the
+// rows when done.
+func ScanColumns(rows *sql.Rows) (MapString, error) {
+	defer rows.Close()
+	var cols, err = rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var text []bool
+	if text, err = textColumns(rows); err != nil {
+		return nil, err
+	}
+	var columns = make([]DataSlice, len(cols))
+	var vals = make([]NullNative, len(cols))
+	var dest = make([]interface{}, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i := range cols {
+			columns[i] = append(columns[i], vals[i].column(text[i]))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	var m = make(MapString, len(cols))
+	for i, col := range cols {
+		m[StrVal(col)] = SliceToNatives(columns[i])
+	}
+	return m, nil
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// in process driver, that returns a fixed table for every query and
+// records the arguments passed to exec.
+type fakeDriver struct{ args []driver.Value }
+type fakeConn struct{ *fakeDriver }
+type fakeStmt struct{ *fakeDriver }
+type fakeRows struct{ row int }
+
+var fakeDB = &fakeDriver{}
+
+func init() { sql.Register("natives-fake", fakeDB) }
+
+var fakeColumns = []string{"id", "name", "score", "born", "big", "raw"}
+var fakeTypes = []string{"INTEGER", "VARCHAR", "REAL", "TIMESTAMP", "DECIMAL", "BLOB"}
+var fakeTable = [][]driver.Value{
+	{int64(1), []byte("ann"), 1.5, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "123456789012345678901234567890", []byte{1}},
+	{int64(2), []byte("bob"), nil, time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC), "2", []byte{2}},
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{c.fakeDriver}, nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, fmt.Errorf("no transactions") }
+func (s fakeStmt) Close() error                        { return nil }
+func (s fakeStmt) NumInput() int                       { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.args = args
+	return driver.RowsAffected(1), nil
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) { return &fakeRows{}, nil }
+func (r *fakeRows) Columns() []string                        { return fakeColumns }
+func (r *fakeRows) Close() error                             { return nil }
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string  { return fakeTypes[i] }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.row >= len(fakeTable) {
+		return io.EOF
+	}
+	copy(dest, fakeTable[r.row])
+	r.row++
+	return nil
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	var db, err = sql.Open("natives-fake", "")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	return db
+}
+
+func TestSQLValuer(t *testing.T) {
+	var db = openFakeDB(t)
+	defer db.Close()
+	var bi = BigIntVal(*big.NewInt(42))
+	var _, err = db.Exec("insert", IntVal(1), StrVal("x"), FltVal(0.5),
+		BoolVal(true), BytesVal{1}, NilVal{}, bi)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	fmt.Println(fakeDB.args)
+	if fmt.Sprint(fakeDB.args) != "[1 x 0.5 true [1] <nil> 42]" {
+		t.Log("unexpected driver values", fakeDB.args)
+		t.Fail()
+	}
+}
+
+func TestSQLScanner(t *testing.T) {
+	var db = openFakeDB(t)
+	defer db.Close()
+	var id IntVal
+	var name StrVal
+	var score FltVal
+	var born TimeVal
+	var bi BigIntVal
+	var raw BytesVal
+	var err = db.QueryRow("select").Scan(&id, &name, &score, &born, &bi, &raw)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	fmt.Println(id, name, score, born, bi, raw)
+	if id != 1 || name != "ann" || score != 1.5 || bi.String() != "123456789012345678901234567890" ||
+		time.Time(born).Year() != 1990 || len(raw) != 1 {
+		t.Log("unexpected scanned values")
+		t.Fail()
+	}
+	var rows, _ = db.Query("select")
+	defer rows.Close()
+	rows.Next()
+	rows.Next()
+	if err = rows.Scan(&id, &name, &score, &born, &bi, &raw); err == nil {
+		t.Log("scanning NULL into float should fail")
+		t.Fail()
+	}
+	fmt.Println(err)
+	var b BoolVal
+	if err = b.Scan("maybe"); err == nil {
+		t.Log("scanning invalid bool should fail", b)
+		t.Fail()
+	}
+	var i IntVal
+	if err = i.Scan([]byte("010")); err != nil || i != 10 {
+		t.Log("zero padded integers should scan in base ten", i, err)
+		t.Fail()
+	}
+}
+
+func TestScanRows(t *testing.T) {
+	var db = openFakeDB(t)
+	defer db.Close()
+	var rows, err = db.Query("select")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var slice DataSlice
+	if slice, err = ScanRows(rows); err != nil || len(slice) != 2 {
+		t.Log("unexpected rows", slice, err)
+		t.FailNow()
+	}
+	fmt.Println(slice)
+	var row = slice[1].(MapString)
+	if row["id"] != IntVal(2) || row["score"] != (NilVal{}) ||
+		row["name"] != StrVal("bob") || row["raw"].(BytesVal)[0] != 2 {
+		t.Log("unexpected row", row)
+		t.Fail()
+	}
+	rows, _ = db.Query("select")
+	var cols MapString
+	if cols, err = ScanColumns(rows); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	fmt.Println(cols)
+	if ids, ok := cols["id"].(IntVec); !ok || len(ids) != 2 || ids[1] != 2 {
+		t.Log("expected unboxed id column", cols["id"])
+		t.Fail()
+	}
+	if names, ok := cols["name"].(StrVec); !ok || names[0] != "ann" {
+		t.Log("expected text column to be scanned as strings", cols["name"])
+		t.Fail()
+	}
+	if _, ok := cols["score"].(DataSlice); !ok {
+		t.Log("column containing NULL should be a data slice", cols["score"])
+		t.Fail()
+	}
+}
@@ -0,0 +1,302 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+//// DESCRIPTIVE STATISTICS
+///
+// statistics over numeric vectors, slices of numerals and any other
+// sliceable collection of numbers. samples of naturals, integers, ratios
+// and decimals are exact, their mean, variance, covariance and quantiles
+// are computed on rationals and returned as RatioVal. samples containing
+// reals yield FltVal. standard deviation and correlation involve a square
+// root and always yield FltVal. both result types implement Real. elements,
+// that are no real numbers, yield an error.
+type sample struct {
+	flts []float64
+	rats []*big.Rat // nil, unless all elements are exact
+}
+
+func (s sample) len() int    { return len(s.flts) }
+func (s sample) exact() bool { return s.rats != nil }
+
+func newSample(nat Native) (sample, error) {
+	var elems []Native
+	switch v := nat.(type) {
+	case FltVec: // skips boxing the most common vector
+		return sample{flts: append([]float64{}, v...)}, nil
+	case Sliced:
+		elems = v.Slice()
+	default:
+		return sample{}, fmt.Errorf("statistics of non sliceable %s", nat.Type().TypeName())
+	}
+	var s = sample{
+		flts: make([]float64, 0, len(elems)),
+		rats: make([]*big.Rat, 0, len(elems)),
+	}
+	for i, elem := range elems {
+		if elem == nil || elem.Type()&(Rationals|Decimal|Reals) == 0 {
+			return sample{}, fmt.Errorf("statistics of non numeric element %v at %d", elem, i)
+		}
+		if r, ok := exactRat(elem); ok && s.rats != nil {
+			s.rats = append(s.rats, r)
+			var f, _ = r.Float64()
+			s.flts = append(s.flts, f)
+			continue
+		}
+		s.rats = nil
+		s.flts = append(s.flts, elem.(Real).GoFlt())
+	}
+	return s, nil
+}
+
+// yields a copy of the elements value as rational, if it has an exact
+// rational representation.
+func exactRat(nat Native) (*big.Rat, bool) {
+	switch v := nat.(type) {
+	case BigIntVal:
+		return new(big.Rat).SetInt(v.GoBigInt()), true
+	case *BigIntVal:
+		return new(big.Rat).SetInt(v.GoBigInt()), true
+	case RatioVal:
+		return new(big.Rat).Set(v.GoRat()), true
+	case *RatioVal:
+		return new(big.Rat).Set(v.GoRat()), true
+	case DecimalVal:
+		return v.GoRat(), true
+	}
+	if nat.Type()&Naturals != 0 {
+		if n, ok := nat.(Natural); ok {
+			return new(big.Rat).SetUint64(uint64(n.GoUint())), true
+		}
+	}
+	if nat.Type()&Integers != 0 {
+		if i, ok := nat.(Integer); ok {
+			return new(big.Rat).SetInt64(int64(i.GoInt())), true
+		}
+	}
+	return nil, false
+}
+
+func errSampleSize(fn string, n, min int) error {
+	return fmt.Errorf("%s of sample with %d elements, needs at least %d", fn, n, min)
+}
+
+func newPairedSamples(fn string, a, b Native) (sample, sample, error) {
+	var x, err = newSample(a)
+	if err != nil {
+		return x, x, err
+	}
+	var y sample
+	if y, err = newSample(b); err != nil {
+		return x, y, err
+	}
+	if x.len() != y.len() {
+		return x, y, fmt.Errorf("%s of samples with %d and %d elements", fn, x.len(), y.len())
+	}
+	if x.len() < 2 {
+		return x, y, errSampleSize(fn, x.len(), 2)
+	}
+	return x, y, nil
+}
+
+// arithmetic mean of the elements
+func Mean(nat Native) (Native, error) {
+	var s, err = newSample(nat)
+	if err != nil {
+		return nil, err
+	}
+	if s.len() == 0 {
+		return nil, errSampleSize("mean", 0, 1)
+	}
+	if s.exact() {
+		return RatioVal(*s.ratMean()), nil
+	}
+	return FltVal(s.fltMean()), nil
+}
+
+func (s sample) ratMean() *big.Rat {
+	var sum = new(big.Rat)
+	for _, r := range s.rats {
+		sum.Add(sum, r)
+	}
+	return sum.Quo(sum, new(big.Rat).SetInt64(int64(len(s.rats))))
+}
+
+func (s sample) fltMean() float64 {
+	var sum float64
+	for _, f := range s.flts {
+		sum = sum + f
+	}
+	return sum / float64(len(s.flts))
+}
+
+// sample variance, the sum of squared deviations from the mean divided by
+// the number of elements minus one.
+func Variance(nat Native) (Native, error) {
+	var s, err = newSample(nat)
+	if err != nil {
+		return nil, err
+	}
+	if s.len() < 2 {
+		return nil, errSampleSize("variance", s.len(), 2)
+	}
+	if s.exact() {
+		return RatioVal(*ratCovariance(s, s)), nil
+	}
+	return FltVal(fltCovariance(s, s)), nil
+}
+
+// square root of the sample variance
+func StdDev(nat Native) (FltVal, error) {
+	var v, err = Variance(nat)
+	if err != nil {
+		return 0, err
+	}
+	return FltVal(math.Sqrt(v.(Real).GoFlt())), nil
+}
+
+// sample covariance of two samples of equal length. exact, if both samples
+// are exact.
+func Covariance(a, b Native) (Native, error) {
+	var x, y, err = newPairedSamples("covariance", a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x.exact() && y.exact() {
+		return RatioVal(*ratCovariance(x, y)), nil
+	}
+	return FltVal(fltCovariance(x, y)), nil
+}
+
+// pearson correlation coefficient of two samples of equal length. samples
+// without variance yield an error.
+func Correlation(a, b Native) (FltVal, error) {
+	var x, y, err = newPairedSamples("correlation", a, b)
+	if err != nil {
+		return 0, err
+	}
+	var cov, vx, vy float64
+	if x.exact() && y.exact() {
+		cov, _ = ratCovariance(x, y).Float64()
+		vx, _ = ratCovariance(x, x).Float64()
+		vy, _ = ratCovariance(y, y).Float64()
+	} else {
+		cov, vx, vy = fltCovariance(x, y), fltCovariance(x, x), fltCovariance(y, y)
+	}
+	if vx == 0 || vy == 0 {
+		return 0, fmt.Errorf("correlation of sample without variance")
+	}
+	return FltVal(cov / math.Sqrt(vx) / math.Sqrt(vy)), nil
+}
+
+// sums products of deviations from the means in a second pass, which is
+// numerically more stable than summing squares.
+func fltCovariance(x, y sample) float64 {
+	var mx, my, sum = x.fltMean(), y.fltMean(), 0.0
+	for i := range x.flts {
+		sum = sum + (x.flts[i]-mx)*(y.flts[i]-my)
+	}
+	return sum / float64(len(x.flts)-1)
+}
+
+func ratCovariance(x, y sample) *big.Rat {
+	var mx, my = x.ratMean(), y.ratMean()
+	var sum, dx, dy = new(big.Rat), new(big.Rat), new(big.Rat)
+	for i := range x.rats {
+		dx.Sub(x.rats[i], mx)
+		dy.Sub(y.rats[i], my)
+		sum.Add(sum, dx.Mul(dx, dy))
+	}
+	return sum.Quo(sum, new(big.Rat).SetInt64(int64(len(x.rats)-1)))
+}
+
+// median is the quantile at one half
+func Median(nat Native) (Native, error) { return Quantile(nat, 0.5) }
+
+// quantile q in the range from zero to one, linearly interpolated between
+// the two closest ranks of the sorted elements, at rank q times the number
+// of elements minus one. exact samples yield an exact quantile of the
+// binary value of q.
+func Quantile(nat Native, q float64) (Native, error) {
+	if !(q >= 0 && q <= 1) {
+		return nil, fmt.Errorf("quantile %v out of range from 0 to 1", q)
+	}
+	var s, err = newSample(nat)
+	if err != nil {
+		return nil, err
+	}
+	if s.len() == 0 {
+		return nil, errSampleSize("quantile", 0, 1)
+	}
+	var rank = new(big.Rat).SetFloat64(q)
+	rank.Mul(rank, new(big.Rat).SetInt64(int64(s.len()-1)))
+	var lo = new(big.Int).Quo(rank.Num(), rank.Denom()).Int64()
+	var frac = rank.Sub(rank, new(big.Rat).SetInt64(lo))
+	var hi = lo
+	if frac.Sign() > 0 {
+		hi = lo + 1
+	}
+	if s.exact() {
+		var rats = append([]*big.Rat{}, s.rats...)
+		sort.Slice(rats, func(i, j int) bool { return rats[i].Cmp(rats[j]) < 0 })
+		var d = new(big.Rat).Sub(rats[hi], rats[lo])
+		return RatioVal(*d.Add(rats[lo], d.Mul(d, frac))), nil
+	}
+	var flts = append([]float64{}, s.flts...)
+	sort.Float64s(flts)
+	var f, _ = frac.Float64()
+	return FltVal(flts[lo] + (flts[hi]-flts[lo])*f), nil
+}
+
+// counts the elements falling into each of n bins of equal width between
+// the smallest and the largest element. edges holds the n+1 bin boundaries,
+// bins include their lower boundary, the last bin includes its upper
+// boundary too. samples of equal elements are centered in a range of one.
+func Histogram(nat Native, n int) (edges FltVec, counts IntVec, err error) {
+	if n < 1 {
+		return nil, nil, fmt.Errorf("histogram of %d bins", n)
+	}
+	var s sample
+	if s, err = newSample(nat); err != nil {
+		return nil, nil, err
+	}
+	if s.len() == 0 {
+		return nil, nil, errSampleSize("histogram", 0, 1)
+	}
+	var min, max = s.flts[0], s.flts[0]
+	for _, f := range s.flts {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, nil, fmt.Errorf("histogram of non finite element %v", f)
+		}
+		min, max = math.Min(min, f), math.Max(max, f)
+	}
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+	var width = (max - min) / float64(n)
+	edges, counts = make(FltVec, n+1), make(IntVec, n)
+	for i := range edges {
+		edges[i] = min + float64(i)*width
+	}
+	edges[n] = max
+	for _, f := range s.flts {
+		var i = int((f - min) / width)
+		if i >= n {
+			i = n - 1
+		}
+		// corrects rounding of the division at bin boundaries
+		for i > 0 && f < edges[i] {
+			i--
+		}
+		for i < n-1 && f >= edges[i+1] {
+			i++
+		}
+		counts[i]++
+	}
+	return edges, counts, nil
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func checkRatio(t *testing.T, name string, nat Native, err error, num, den int64) {
+	if err != nil {
+		t.Log(name, err)
+		t.Fail()
+		return
+	}
+	var r, ok = nat.(RatioVal)
+	if !ok || r.GoRat().Cmp(big.NewRat(num, den)) != 0 {
+		t.Log(name, "expected exact", big.NewRat(num, den), "got", nat)
+		t.Fail()
+	}
+}
+
+func checkFloat(t *testing.T, name string, nat Native, err error, expect float64) {
+	if err != nil {
+		t.Log(name, err)
+		t.Fail()
+		return
+	}
+	var f, ok = nat.(FltVal)
+	if !ok || math.Abs(float64(f)-expect) > 1e-9 {
+		t.Log(name, "expected", expect, "got", nat)
+		t.Fail()
+	}
+}
+
+func TestStatsExact(t *testing.T) {
+	var ints = IntVec{2, 4, 4, 4, 5, 5, 7, 9}
+	var nat, err = Mean(ints)
+	checkRatio(t, "mean", nat, err, 5, 1)
+	nat, err = Variance(ints)
+	checkRatio(t, "variance", nat, err, 32, 7)
+	nat, err = Median(IntVec{3, 1, 2, 4})
+	checkRatio(t, "median", nat, err, 5, 2)
+	nat, err = Quantile(ints, 0.25)
+	checkRatio(t, "quantile", nat, err, 4, 1)
+	nat, err = Quantile(ints, 0.1)
+	fmt.Println(nat)
+	if r, ok := nat.(RatioVal); !ok || r.GoFlt() <= 2 || r.GoFlt() >= 4 {
+		t.Log("quantile should interpolate between ranks", nat)
+		t.Fail()
+	}
+	// mixed integers, ratios and decimals stay exact
+	var mixed = DataSlice{
+		IntVal(1), Uint8Val(2), RatioVal(*big.NewRat(1, 3)),
+		NewDecimal(25, 1), BigIntVal(*big.NewInt(-1)),
+	}
+	nat, err = Mean(mixed)
+	checkRatio(t, "mixed mean", nat, err, 29, 30)
+	nat, err = Covariance(IntVec{1, 2, 3}, UintVec{2, 4, 7})
+	checkRatio(t, "covariance", nat, err, 5, 2)
+	var sd FltVal
+	sd, err = StdDev(ints)
+	checkFloat(t, "standard deviation", sd, err, math.Sqrt(32.0/7.0))
+}
+
+func TestStatsReal(t *testing.T) {
+	var flts = FltVec{1.5, 2.5, 3.5, 4.5}
+	var nat, err = Mean(flts)
+	checkFloat(t, "mean", nat, err, 3)
+	nat, err = Variance(DataSlice{IntVal(1), FltVal(2.5), FltVal(3.5), IntVal(5)})
+	checkFloat(t, "variance", nat, err, 2.8333333333333335)
+	nat, err = Median(flts)
+	checkFloat(t, "median", nat, err, 3)
+	nat, err = Quantile(flts, 1)
+	checkFloat(t, "maximum", nat, err, 4.5)
+	var corr FltVal
+	corr, err = Correlation(IntVec{1, 2, 3, 4}, FltVec{2, 4, 6, 8})
+	checkFloat(t, "correlation", corr, err, 1)
+	corr, err = Correlation(IntVec{1, 2, 3}, IntVec{3, 2, 1})
+	checkFloat(t, "negative correlation", corr, err, -1)
+	nat, err = Covariance(FltVec{1, 2, 3}, IntVec{2, 4, 7})
+	checkFloat(t, "covariance", nat, err, 2.5)
+}
+
+func TestHistogram(t *testing.T) {
+	var edges, counts, err = Histogram(FltVec{0, 0.1, 0.3, 0.5, 0.7, 1}, 2)
+	fmt.Println(edges, counts)
+	if err != nil || !equalSlices(edges.Slice(), FltVec{0, 0.5, 1}.Slice()) ||
+		!equalSlices(counts.Slice(), IntVec{3, 3}.Slice()) {
+		t.Log("unexpected histogram", edges, counts, err)
+		t.Fail()
+	}
+	edges, counts, err = Histogram(IntVec{3, 3}, 4)
+	if err != nil || edges[0] != 2.5 || edges[4] != 3.5 || counts[2] != 2 {
+		t.Log("unexpected histogram of equal elements", edges, counts, err)
+		t.Fail()
+	}
+}
+
+func TestStatsErrors(t *testing.T) {
+	var errs = []error{}
+	var collect = func(_ Native, err error) { errs = append(errs, err) }
+	collect(Mean(DataSlice{}))
+	collect(Mean(DataSlice{IntVal(1), StrVal("x")}))
+	collect(Mean(IntVal(1)))
+	collect(Variance(IntVec{1}))
+	collect(Quantile(IntVec{1}, 1.5))
+	collect(Quantile(IntVec{1}, math.NaN()))
+	collect(Covariance(IntVec{1, 2}, IntVec{1, 2, 3}))
+	collect(Mean(ImagVec{1}))
+	var _, err = Correlation(IntVec{1, 1}, IntVec{1, 2})
+	errs = append(errs, err)
+	_, _, err = Histogram(IntVec{1}, 0)
+	errs = append(errs, err)
+	for i, err := range errs {
+		if err == nil {
+			t.Log("expected error", i)
+			t.Fail()
+		}
+	}
+	fmt.Println(errs[1])
+}
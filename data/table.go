@@ -0,0 +1,555 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//// COLUMNAR TABLE
+///
+// table of named columns of equal length. every column is an unboxed vector,
+// its element type is the type of the column. tables are flagged tabular,
+// they are no unboxed vector themselves. tables are not mutated, all
+// operations return new tables, that may share columns with the table they
+// are derived from.
+type Table struct {
+	names []string
+	cols  []Sliceable
+	rows  int
+}
+
+// named unboxed vector
+type Column struct {
+	Name string
+	Vec  Sliceable
+}
+
+func NewTable(cols ...Column) (Table, error) {
+	var t = Table{
+		names: make([]string, 0, len(cols)),
+		cols:  make([]Sliceable, 0, len(cols)),
+	}
+	for i, col := range cols {
+		if col.Vec == nil || col.Vec.Type() != Unboxed {
+			return Table{}, fmt.Errorf(
+				"column %s is not an unboxed vector", col.Name)
+		}
+		if _, ok := t.index(col.Name); ok {
+			return Table{}, fmt.Errorf("duplicate column %s", col.Name)
+		}
+		if i == 0 {
+			t.rows = col.Vec.Len()
+		}
+		if col.Vec.Len() != t.rows {
+			return Table{}, fmt.Errorf(
+				"column %s has %d rows, expected %d",
+				col.Name, col.Vec.Len(), t.rows)
+		}
+		t.names = append(t.names, col.Name)
+		t.cols = append(t.cols, col.Vec)
+	}
+	return t, nil
+}
+
+// creates a table from the schema passed, which is a list of pairs of column
+// name and type, and rows of natives, that need to match the schema.
+func NewTableFromRows(schema []Paired, rows ...[]Native) (Table, error) {
+	var cols = make([]Column, 0, len(schema))
+	for i, field := range schema {
+		var name, typ = field.Left().String(), TyNat(field.Right().(Typed).Flag())
+		var elems = make([]Native, 0, len(rows))
+		for r, row := range rows {
+			if len(row) != len(schema) {
+				return Table{}, fmt.Errorf("row %d has %d fields, expected %d",
+					r, len(row), len(schema))
+			}
+			if row[i].Type() != typ {
+				return Table{}, fmt.Errorf("field %s of row %d is of type %s, expected %s",
+					name, r, row[i].Type().TypeName(), typ.TypeName())
+			}
+			elems = append(elems, row[i])
+		}
+		var vec, err = newColumnVec(typ, elems...)
+		if err != nil {
+			return Table{}, err
+		}
+		cols = append(cols, Column{name, vec})
+	}
+	return NewTable(cols...)
+}
+
+func newColumnVec(typ TyNat, elems ...Native) (Sliceable, error) {
+	for _, elem := range elems {
+		if elem.Type() != typ {
+			return nil, fmt.Errorf("can not store %s in vector of type %s",
+				elem.Type().TypeName(), typ.TypeName())
+		}
+	}
+	var vec = NewUnboxed(typ, elems...)
+	if vec == nil {
+		return nil, fmt.Errorf(
+			"can not create unboxed vector of type %s", typ.TypeName())
+	}
+	return vec, nil
+}
+
+func (t Table) Type() TyNat     { return Tabular }
+func (t Table) Len() int        { return t.rows }
+func (t Table) Width() int      { return len(t.cols) }
+func (t Table) Empty() bool     { return t.rows == 0 }
+func (t Table) Names() []string { return append([]string{}, t.names...) }
+
+// returns pairs of column name and element type
+func (t Table) Schema() []Paired {
+	var schema = make([]Paired, 0, len(t.cols))
+	for i, col := range t.cols {
+		schema = append(schema, NewPair(StrVal(t.names[i]), colType(col)))
+	}
+	return schema
+}
+
+func colType(col Sliceable) TyNat { return TyNat(col.TypeElem().Flag()) }
+
+func (t Table) index(name string) (int, bool) {
+	for i, n := range t.names {
+		if n == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (t Table) indices(names ...string) ([]int, error) {
+	var idx = make([]int, 0, len(names))
+	for _, name := range names {
+		var i, ok = t.index(name)
+		if !ok {
+			return nil, fmt.Errorf("no column named %s", name)
+		}
+		idx = append(idx, i)
+	}
+	return idx, nil
+}
+
+func (t Table) Column(name string) (Sliceable, bool) {
+	if i, ok := t.index(name); ok {
+		return t.cols[i], true
+	}
+	return nil, false
+}
+
+func (t Table) Row(i int) Row { return Row{t, i} }
+
+func (t Table) Rows() []Row {
+	var rows = make([]Row, 0, t.rows)
+	for i := 0; i < t.rows; i++ {
+		rows = append(rows, Row{t, i})
+	}
+	return rows
+}
+
+// returns every row as slice of natives
+func (t Table) Slice() []Native {
+	var rows = make([]Native, 0, t.rows)
+	for i := 0; i < t.rows; i++ {
+		rows = append(rows, DataSlice(t.Row(i).Slice()))
+	}
+	return rows
+}
+
+func (t Table) String() string {
+	var rows = make([][]Native, 0, t.rows+1)
+	var head = make([]Native, 0, len(t.names))
+	for _, name := range t.names {
+		head = append(head, StrVal(name))
+	}
+	rows = append(rows, head)
+	for i := 0; i < t.rows; i++ {
+		rows = append(rows, t.Row(i).Slice())
+	}
+	return StringChainTable(rows...)
+}
+
+//// ROWS
+///
+// references a row of a table by index
+type Row struct {
+	t Table
+	i int
+}
+
+func (r Row) Index() int { return r.i }
+
+// returns the value of the named column, or nil if there is no such column
+func (r Row) Get(name string) Native {
+	if c, ok := r.t.index(name); ok {
+		return r.t.cols[c].GetInt(r.i)
+	}
+	return nil
+}
+
+func (r Row) Slice() []Native {
+	var row = make([]Native, 0, len(r.t.cols))
+	for _, col := range r.t.cols {
+		row = append(row, col.GetInt(r.i))
+	}
+	return row
+}
+
+//// SELECTION
+///
+// returns a table containing the named columns in the order passed
+func (t Table) Select(names ...string) (Table, error) {
+	var idx, err = t.indices(names...)
+	if err != nil {
+		return Table{}, err
+	}
+	var s = Table{rows: t.rows}
+	for _, i := range idx {
+		s.names = append(s.names, t.names[i])
+		s.cols = append(s.cols, t.cols[i])
+	}
+	return s, nil
+}
+
+// returns a table containing the rows the predicate returns true for
+func (t Table) Filter(pred func(Row) bool) Table {
+	var idx = []int{}
+	for i := 0; i < t.rows; i++ {
+		if pred(Row{t, i}) {
+			idx = append(idx, i)
+		}
+	}
+	return t.take(idx)
+}
+
+// sorts rows by the values of the named columns, names prefixed by a minus
+// sign sort in descending order. rows with equal values keep their order.
+func (t Table) SortBy(names ...string) (Table, error) {
+	var cols = make([]Sliceable, 0, len(names))
+	var desc = make([]bool, 0, len(names))
+	for _, name := range names {
+		var d = strings.HasPrefix(name, "-")
+		var col, ok = t.Column(strings.TrimPrefix(name, "-"))
+		if !ok {
+			return Table{}, fmt.Errorf("no column named %s", name)
+		}
+		cols = append(cols, col)
+		desc = append(desc, d)
+	}
+	var idx = make([]int, t.rows)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		for k, col := range cols {
+			var c = compareAt(col, idx[i], idx[j])
+			if desc[k] {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return t.take(idx), nil
+}
+
+// compares two elements of a vector without boxing them for the common
+// column types.
+func compareAt(col Sliceable, i, j int) int {
+	switch v := col.(type) {
+	case IntVec:
+		return cmpOf(v[i] < v[j], v[i] > v[j])
+	case FltVec:
+		return compareFloats(v[i], v[j])
+	case StrVec:
+		return strings.Compare(v[i], v[j])
+	case TimeVec:
+		return cmpOf(v[i].Before(v[j]), v[i].After(v[j]))
+	case BoolVec:
+		return cmpOf(!v[i] && v[j], v[i] && !v[j])
+	}
+	return Compare(col.GetInt(i), col.GetInt(j))
+}
+
+// returns a table containing the rows at the indices passed in the order
+// passed. negative indices yield the zero value of every column.
+func (t Table) take(idx []int) Table {
+	var s = Table{names: t.names, cols: make([]Sliceable, len(t.cols)), rows: len(idx)}
+	for c, col := range t.cols {
+		s.cols[c] = gather(col, idx)
+	}
+	return s
+}
+
+func gather(col Sliceable, idx []int) Sliceable {
+	switch v := col.(type) {
+	case IntVec:
+		var res = make(IntVec, len(idx))
+		for n, i := range idx {
+			if i >= 0 {
+				res[n] = v[i]
+			}
+		}
+		return res
+	case FltVec:
+		var res = make(FltVec, len(idx))
+		for n, i := range idx {
+			if i >= 0 {
+				res[n] = v[i]
+			}
+		}
+		return res
+	case StrVec:
+		var res = make(StrVec, len(idx))
+		for n, i := range idx {
+			if i >= 0 {
+				res[n] = v[i]
+			}
+		}
+		return res
+	case TimeVec:
+		var res = make(TimeVec, len(idx))
+		for n, i := range idx {
+			if i >= 0 {
+				res[n] = v[i]
+			}
+		}
+		return res
+	case BoolVec:
+		var res = make(BoolVec, len(idx))
+		for n, i := range idx {
+			if i >= 0 {
+				res[n] = v[i]
+			}
+		}
+		return res
+	}
+	var typ = colType(col)
+	var elems = make([]Native, 0, len(idx))
+	for _, i := range idx {
+		if i < 0 {
+			elems = append(elems, zeroOf(typ))
+			continue
+		}
+		elems = append(elems, col.GetInt(i))
+	}
+	return NewUnboxed(typ, elems...)
+}
+
+// values of the key columns of a row
+func (t Table) key(cols []int, i int) DataSlice {
+	var key = make(DataSlice, 0, len(cols))
+	for _, c := range cols {
+		key = append(key, t.cols[c].GetInt(i))
+	}
+	return key
+}
+
+//// GROUPING
+///
+// aggregation reduces the values of a column of every group to a single
+// native, all natives returned for a column need to be of the same type.
+type Aggregation struct {
+	Name   string
+	Column string
+	Fn     func(Sliceable) Native
+}
+
+// groups rows by the values of the key columns and yields a table with the
+// key columns followed by a column for every aggregation. groups are in the
+// order of their first appearance.
+func (t Table) GroupBy(keys []string, aggs ...Aggregation) (Table, error) {
+	var kidx, err = t.indices(keys...)
+	if err != nil {
+		return Table{}, err
+	}
+	var groups = NewHashedMap()
+	var members = [][]int{}
+	var first = []int{}
+	for i := 0; i < t.rows; i++ {
+		var key = t.key(kidx, i)
+		if g, ok := groups.Get(key); ok {
+			members[g.(IntVal)] = append(members[g.(IntVal)], i)
+			continue
+		}
+		groups.Set(key, IntVal(len(members)))
+		members = append(members, []int{i})
+		first = append(first, i)
+	}
+	var res = t.take(first)
+	res, _ = res.Select(keys...)
+	for _, agg := range aggs {
+		var c, ok = t.index(agg.Column)
+		if !ok {
+			return Table{}, fmt.Errorf("no column named %s", agg.Column)
+		}
+		var vals = make([]Native, 0, len(members))
+		for _, idx := range members {
+			vals = append(vals, agg.Fn(gather(t.cols[c], idx)))
+		}
+		var typ = Nil
+		if len(vals) > 0 {
+			typ = vals[0].Type()
+		}
+		var vec, err = newColumnVec(typ, vals...)
+		if err != nil {
+			return Table{}, fmt.Errorf("aggregation %s: %s", agg.Name, err)
+		}
+		if _, ok := res.index(agg.Name); ok {
+			return Table{}, fmt.Errorf("duplicate column %s", agg.Name)
+		}
+		res.names = append(res.names, agg.Name)
+		res.cols = append(res.cols, vec)
+	}
+	return res, nil
+}
+
+// aggregations of integer columns yield IntVal, of float columns FltVal.
+// sum, min and max of other columns are evaluated on their boxed elements.
+func AggCount(v Sliceable) Native { return IntVal(v.Len()) }
+func AggFirst(v Sliceable) Native { return v.GetInt(0) }
+
+func AggSum(v Sliceable) Native {
+	switch x := v.(type) {
+	case IntVec:
+		return x.Sum()
+	case FltVec:
+		return x.Sum()
+	}
+	var elems = v.Slice()
+	if len(elems) == 0 {
+		return IntVal(0)
+	}
+	var sum = elems[0]
+	for _, elem := range elems[1:] {
+		var res, err = Eval(Add, sum, elem)
+		if err != nil {
+			return ErrorVal{err}
+		}
+		sum = res
+	}
+	return sum
+}
+
+func AggMean(v Sliceable) Native {
+	if v.Len() == 0 {
+		return FltVal(0)
+	}
+	var sum, err = Convert(AggSum(v), Float)
+	if f, ok := sum.(FltVal); ok {
+		return f / FltVal(v.Len())
+	}
+	return ErrorVal{err}
+}
+
+func AggMin(v Sliceable) Native {
+	switch x := v.(type) {
+	case IntVec:
+		var m, _ = x.Min()
+		return m
+	case FltVec:
+		var m, _ = x.Min()
+		return m
+	}
+	return extremum(v, -1)
+}
+
+func AggMax(v Sliceable) Native {
+	switch x := v.(type) {
+	case IntVec:
+		var m, _ = x.Max()
+		return m
+	case FltVec:
+		var m, _ = x.Max()
+		return m
+	}
+	return extremum(v, 1)
+}
+
+func extremum(v Sliceable, sign int) Native {
+	if v.Len() == 0 {
+		return zeroOf(colType(v))
+	}
+	var m = v.GetInt(0)
+	for i := 1; i < v.Len(); i++ {
+		if e := v.GetInt(i); Compare(e, m) == sign {
+			m = e
+		}
+	}
+	return m
+}
+
+//// JOINS
+///
+// joins rows of both tables with equal values in the columns named by on.
+// the result contains all columns of the left table, followed by the
+// columns of the right table, that are not part of the key.
+func (t Table) InnerJoin(r Table, on ...string) (Table, error) {
+	return t.join(r, false, on...)
+}
+
+// like inner join, but keeps rows of the left table without matching rows
+// in the right table. columns of the right table are set to their zero
+// value for these rows.
+func (t Table) LeftJoin(r Table, on ...string) (Table, error) {
+	return t.join(r, true, on...)
+}
+
+func (t Table) join(r Table, left bool, on ...string) (Table, error) {
+	var lk, err = t.indices(on...)
+	if err != nil {
+		return Table{}, err
+	}
+	rk, err := r.indices(on...)
+	if err != nil {
+		return Table{}, err
+	}
+	var rcols = []int{}
+	for c, name := range r.names {
+		var isKey bool
+		for _, k := range on {
+			isKey = isKey || k == name
+		}
+		if isKey {
+			continue
+		}
+		if _, ok := t.index(name); ok {
+			return Table{}, fmt.Errorf("column %s exists in both tables", name)
+		}
+		rcols = append(rcols, c)
+	}
+	var index = NewHashedMap()
+	var matches = [][]int{}
+	for i := 0; i < r.rows; i++ {
+		var key = r.key(rk, i)
+		if m, ok := index.Get(key); ok {
+			matches[m.(IntVal)] = append(matches[m.(IntVal)], i)
+			continue
+		}
+		index.Set(key, IntVal(len(matches)))
+		matches = append(matches, []int{i})
+	}
+	var li, ri = []int{}, []int{}
+	for i := 0; i < t.rows; i++ {
+		if m, ok := index.Get(t.key(lk, i)); ok {
+			for _, j := range matches[m.(IntVal)] {
+				li, ri = append(li, i), append(ri, j)
+			}
+			continue
+		}
+		if left {
+			li, ri = append(li, i), append(ri, -1)
+		}
+	}
+	var res = t.take(li)
+	res.names = append([]string{}, res.names...)
+	for _, c := range rcols {
+		res.names = append(res.names, r.names[c])
+		res.cols = append(res.cols, gather(r.cols[c], ri))
+	}
+	return res, nil
+}
@@ -0,0 +1,168 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestTable(t *testing.T) Table {
+	var tab, err = NewTable(
+		Column{"name", StrVec{"ada", "bob", "cy", "dee", "eve"}},
+		Column{"dept", StrVec{"eng", "ops", "eng", "ops", "hr"}},
+		Column{"age", IntVec{36, 25, 41, 25, 30}},
+		Column{"pay", FltVec{120, 80, 150, 90, 70}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tab
+}
+
+func TestTableConstruct(t *testing.T) {
+	var tab = newTestTable(t)
+	fmt.Println(tab)
+	if tab.Len() != 5 || tab.Width() != 4 {
+		t.Log("unexpected dimensions", tab.Len(), tab.Width())
+		t.Fail()
+	}
+	if s := fmt.Sprint(tab.Schema()); s != "[(name, String) (dept, String) (age, Int) (pay, Float)]" {
+		t.Log("unexpected schema", s)
+		t.Fail()
+	}
+	if _, err := NewTable(Column{"a", IntVec{1}}, Column{"b", IntVec{1, 2}}); err == nil {
+		t.Log("columns of differing length should fail")
+		t.Fail()
+	}
+	var now = time.Now()
+	var rows, err = NewTableFromRows(
+		[]Paired{NewPair(StrVal("at"), Time), NewPair(StrVal("ok"), Bool)},
+		[]Native{TimeVal(now), BoolVal(true)},
+		[]Native{TimeVal(now.Add(time.Hour)), BoolVal(false)},
+	)
+	if err != nil || rows.Len() != 2 || rows.Row(1).Get("ok") != BoolVal(false) {
+		t.Log("table from rows failed", rows, err)
+		t.Fail()
+	}
+	if _, err := NewTableFromRows(
+		[]Paired{NewPair(StrVal("n"), Int)},
+		[]Native{StrVal("x")},
+	); err == nil {
+		t.Log("rows not matching the schema should fail")
+		t.Fail()
+	}
+}
+
+func TestTableNative(t *testing.T) {
+	var tab = newTestTable(t)
+	if tab.Type() != Tabular || tab.Type().Match(Unboxed) {
+		t.Log("tables should not be flagged as unboxed vector", tab.Type())
+		t.Fail()
+	}
+	var other, _ = tab.Select("name", "dept", "age")
+	if !DeepEqual(tab, tab.Copy()) || Hash(tab) != Hash(tab.Copy()) ||
+		DeepEqual(tab, other) || Compare(tab, tab.Copy()) != 0 {
+		t.Log("tables should be equal to their copies only")
+		t.Fail()
+	}
+	var buf = bytes.NewBuffer([]byte{})
+	if err := NewEncoder(buf).Encode(tab); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	var val, err = NewDecoder(buf).Decode()
+	fmt.Println(val)
+	if err != nil || !DeepEqual(val, tab) {
+		t.Log("table should survive encoding", val, err)
+		t.Fail()
+	}
+}
+
+func TestTableSelectFilterSort(t *testing.T) {
+	var tab = newTestTable(t)
+	var sel, err = tab.Select("pay", "name")
+	if err != nil || sel.Width() != 2 || sel.Names()[0] != "pay" {
+		t.Log("select failed", sel, err)
+		t.Fail()
+	}
+	if _, err := tab.Select("none"); err == nil {
+		t.Log("selecting missing column should fail")
+		t.Fail()
+	}
+	var young = tab.Filter(func(r Row) bool { return r.Get("age").(IntVal) < 35 })
+	fmt.Println(young)
+	if young.Len() != 3 {
+		t.Log("expected three rows", young)
+		t.Fail()
+	}
+	sorted, err := tab.SortBy("age", "-pay")
+	fmt.Println(sorted)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	var names, _ = sorted.Column("name")
+	if !DeepEqual(names, StrVec{"dee", "bob", "eve", "ada", "cy"}) {
+		t.Log("unexpected order", names)
+		t.Fail()
+	}
+}
+
+func TestTableGroupBy(t *testing.T) {
+	var tab = newTestTable(t)
+	var g, err = tab.GroupBy([]string{"dept"},
+		Aggregation{"n", "name", AggCount},
+		Aggregation{"total", "pay", AggSum},
+		Aggregation{"mean_age", "age", AggMean},
+		Aggregation{"oldest", "age", AggMax},
+		Aggregation{"first", "name", AggMin},
+	)
+	fmt.Println(g)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+		return
+	}
+	var expect, _ = NewTable(
+		Column{"dept", StrVec{"eng", "ops", "hr"}},
+		Column{"n", IntVec{2, 2, 1}},
+		Column{"total", FltVec{270, 170, 70}},
+		Column{"mean_age", FltVec{38.5, 25, 30}},
+		Column{"oldest", IntVec{41, 25, 30}},
+		Column{"first", StrVec{"ada", "bob", "eve"}},
+	)
+	if !DeepEqual(g, expect) {
+		t.Log("unexpected groups", g)
+		t.Fail()
+	}
+}
+
+func TestTableJoin(t *testing.T) {
+	var tab = newTestTable(t)
+	var depts, _ = NewTable(
+		Column{"dept", StrVec{"eng", "ops", "eng"}},
+		Column{"floor", IntVec{3, 1, 4}},
+	)
+	var inner, err = tab.InnerJoin(depts, "dept")
+	fmt.Println(inner)
+	if err != nil || inner.Len() != 6 || inner.Width() != 5 {
+		t.Log("unexpected inner join", inner, err)
+		t.Fail()
+	}
+	left, err := tab.LeftJoin(depts, "dept")
+	fmt.Println(left)
+	if err != nil || left.Len() != 7 {
+		t.Log("unexpected left join", left, err)
+		t.Fail()
+	}
+	var last = left.Row(left.Len() - 1)
+	if last.Get("name") != StrVal("eve") || last.Get("floor") != IntVal(0) {
+		t.Log("unmatched row should have zero values", last.Slice())
+		t.Fail()
+	}
+	if _, err := tab.InnerJoin(tab, "name"); err == nil {
+		t.Log("joining tables with common non key columns should fail")
+		t.Fail()
+	}
+}
@@ -40,10 +40,12 @@ func _() {
 	_ = x[Function-536870912]
 	_ = x[Literal-1073741824]
 	_ = x[Type-2147483648]
+	_ = x[Decimal-4294967296]
+	_ = x[Tabular-8589934592]
 	_ = x[MASK-18446744073709551615]
 }
 
-const _TyNat_name = "NilBoolInt8Int16Int32IntBigIntUint8Uint16Uint32UintFlt32FloatBigFltRatioImag64ImagTimeDurationByteRuneFlagStringBytesErrorPairSliceUnboxedMapFunctionLiteralTypeMASK"
+const _TyNat_name = "NilBoolInt8Int16Int32IntBigIntUint8Uint16Uint32UintFlt32FloatBigFltRatioImag64ImagTimeDurationByteRuneFlagStringBytesErrorPairSliceUnboxedMapFunctionLiteralTypeDecimalTabularMASK"
 
 var _TyNat_map = map[TyNat]string{
 	1:                    _TyNat_name[0:3],
@@ -78,7 +80,9 @@ var _TyNat_map = map[TyNat]string{
 	536870912:            _TyNat_name[141:149],
 	1073741824:           _TyNat_name[149:156],
 	2147483648:           _TyNat_name[156:160],
-	18446744073709551615: _TyNat_name[160:164],
+	4294967296:           _TyNat_name[160:167],
+	8589934592:           _TyNat_name[167:174],
+	18446744073709551615: _TyNat_name[174:178],
 }
 
 func (i TyNat) String() string {
@@ -52,21 +52,29 @@ func (t TyNat) TypeName() string {
 		var delim = "|"
 		var str = make([]string, 0, count)
 		for _, flag := range t.Flag().Decompose() {
-			str = append(str, TyNat(flag.Flag()).String())
+			str = append(str, TyNat(flag.Flag()).TypeName())
 		}
 		return s.Join(str, delim)
 	}
+	if r, ok := RegisteredType(t); ok {
+		return r.Name
+	}
 	return t.String()
 }
 func (v TyNat) Flag() BitFlag        { return BitFlag(v) }
 func (v TyNat) Match(arg Typed) bool { return v.Flag().Match(arg) }
 func (v TyNat) Eval() Native         { return v }
 
+// returns predefined types, followed by registered types
 func FetchTypes() []TyNat {
+	return append(predefinedTypes(), RegisteredTypes()...)
+}
+
+func predefinedTypes() []TyNat {
 	var tt = []TyNat{}
 	var i uint
 	var t TyNat = 0
-	for t < Type {
+	for t < lastPredefined {
 		t = 1 << i
 		i = i + 1
 		tt = append(tt, TyNat(t))
@@ -110,13 +118,17 @@ const (
 	Function
 	Literal
 	Type // marks most signifficant native type & data of type bitflag
+	////
+	// flags added later are appended, to keep the flags above stable
+	Decimal
+	Tabular
 
 	// TYPE CLASSES
 	// precedence type classes define argument types functions that accept
 	// a set of possible input types
 	Natives = Nil | Bool | Int8 | Int16 | Int32 | Int | BigInt | Uint8 |
-		Uint16 | Uint32 | Uint | Flt32 | Float | BigFlt | Ratio | Imag64 |
-		Imag | Time | Duration | Byte | Rune | Bytes | String | Error
+		Uint16 | Uint32 | Uint | Flt32 | Float | BigFlt | Ratio | Decimal |
+		Imag64 | Imag | Time | Duration | Byte | Rune | Bytes | String | Error
 
 	Bitwise    = Naturals | Byte | Type
 	Booleans   = Bool | Bitwise
@@ -124,13 +136,13 @@ const (
 	Integers   = Int | Int8 | Int16 | Int32 | BigInt
 	Rationals  = Naturals | Integers | Ratio
 	Reals      = Float | Flt32 | BigFlt
-	Big        = BigInt | BigFlt | Ratio
+	Big        = BigInt | BigFlt | Ratio | Decimal
 	Imaginarys = Imag | Imag64
-	Numbers    = Rationals | Reals | Imaginarys
+	Numbers    = Rationals | Decimal | Reals | Imaginarys
 	Letters    = String | Rune | Bytes
 	Equals     = Numbers | Letters
 
-	Compositions = Pair | Unboxed | Slice | Map
+	Compositions = Pair | Unboxed | Slice | Map | Tabular
 
 	Parametric = Natives | Compositions
 
@@ -144,6 +156,9 @@ const (
 	MASK_NATIVES       = MASK ^ Natives
 )
 
+// most signifficant predefined flag, registered types get the flags above
+const lastPredefined = Tabular
+
 //////// INTERNAL TYPES /////////////
 // internal types are typealiases without any wrapping, or referencing getting
 // in the way performancewise. types need to be aliased in the first place, to
@@ -186,6 +201,7 @@ type ( // NATIVE GOLANG TYPES
 	MapFloat  map[FltVal]Native
 	MapFlag   map[BitFlag]Native
 	MapVal    map[Native]Native
+	MapHash   map[uint64][]PairVal
 
 	// SLICES OF UNALIASED NATIVE GOLANG VALUES
 	InterfaceSlice []interface{}
@@ -390,6 +406,7 @@ func (v BigFltVal) Type() TyNat  { return BigFlt }
 func (v ImagVal) Type() TyNat    { return Imag }
 func (v Imag64Val) Type() TyNat  { return Imag64 }
 func (v RatioVal) Type() TyNat   { return Ratio }
+func (v DecimalVal) Type() TyNat { return Decimal }
 func (v RuneVal) Type() TyNat    { return Rune }
 func (v ByteVal) Type() TyNat    { return Byte }
 func (v BytesVal) Type() TyNat   { return Bytes }
@@ -439,22 +456,23 @@ func (v UintVal) Copy() Native    { return UintVal(v) }
 func (v Uint8Val) Copy() Native   { return Uint8Val(v) }
 func (v Uint16Val) Copy() Native  { return Uint16Val(v) }
 func (v Uint32Val) Copy() Native  { return Uint32Val(v) }
-func (v BigIntVal) Copy() Native  { return BigIntVal(v) }
+func (v BigIntVal) Copy() Native  { return BigIntVal(*new(big.Int).Set(v.GoBigInt())) }
 func (v FltVal) Copy() Native     { return FltVal(v) }
 func (v Flt32Val) Copy() Native   { return Flt32Val(v) }
-func (v BigFltVal) Copy() Native  { return BigFltVal(v) }
+func (v BigFltVal) Copy() Native  { return BigFltVal(*new(big.Float).Copy(v.GoBigFlt())) }
 func (v ImagVal) Copy() Native    { return ImagVal(v) }
 func (v Imag64Val) Copy() Native  { return Imag64Val(v) }
-func (v RatioVal) Copy() Native   { return RatioVal(v) }
+func (v RatioVal) Copy() Native   { return RatioVal(*new(big.Rat).Set(v.GoRat())) }
+func (v DecimalVal) Copy() Native { return NewBigDecimal(v.unscaled(), v.scale) }
 func (v RuneVal) Copy() Native    { return RuneVal(v) }
 func (v ByteVal) Copy() Native    { return ByteVal(v) }
-func (v BytesVal) Copy() Native   { return BytesVal(v) }
+func (v BytesVal) Copy() Native   { return BytesVal(append([]byte{}, v...)) }
 func (v StrVal) Copy() Native     { return StrVal(v) }
 func (v TimeVal) Copy() Native    { return TimeVal(v) }
 func (v DuraVal) Copy() Native    { return DuraVal(v) }
 func (v ErrorVal) Copy() Native   { return ErrorVal(v) }
 func (v Expression) Copy() Native { return Expression(v) }
-func (v PairVal) Copy() Native    { return PairVal{v.L, v.R} }
+func (v PairVal) Copy() Native    { return PairVal{DeepCopy(v.L), DeepCopy(v.R)} }
 func (v FlagSlice) Copy() Native {
 	var nfs = DataSlice{}
 	for _, dat := range v {
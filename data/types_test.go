@@ -3,6 +3,7 @@ package data
 import (
 	"fmt"
 	"math/big"
+	"math/bits"
 	"testing"
 	"time"
 )
@@ -220,7 +221,7 @@ func TestTimeType(t *testing.T) {
 func TestAllTypes(t *testing.T) {
 	fmt.Println(FetchTypes())
 
-	if fmt.Sprint(FetchTypes()) != "[Nil Bool Int8 Int16 Int32 Int BigInt Uint8 Uint16 Uint32 Uint Flt32 Float BigFlt Ratio Imag64 Imag Time Duration Byte Rune Flag String Bytes Error Pair Slice Unboxed Map Function Literal Type]" {
+	if fmt.Sprint(FetchTypes()) != "[Nil Bool Int8 Int16 Int32 Int BigInt Uint8 Uint16 Uint32 Uint Flt32 Float BigFlt Ratio Imag64 Imag Time Duration Byte Rune Flag String Bytes Error Pair Slice Unboxed Map Function Literal Type Decimal Tabular]" {
 		t.Fail()
 	}
 }
@@ -249,3 +250,21 @@ func TestSearchChainString(t *testing.T) {
 	text := sl.Search(New("Int"))
 	fmt.Println(text)
 }
+
+func TestFlagLeast(t *testing.T) {
+	// conversion and comparison tables are indexed by the least
+	// significant bit of a types flag.
+	for _, typ := range []TyNat{Nil, Bool, Int, BigInt, String} {
+		var want = bits.TrailingZeros(uint(typ))
+		fmt.Println(typ, typ.Flag().Least(), typ.Flag().Index())
+		if typ.Flag().Least() != want || typ.Flag().Index() != want {
+			t.Log("unexpected least significant bit of", typ,
+				typ.Flag().Least(), "expected", want)
+			t.Fail()
+		}
+	}
+	if FlagLeast(Int|String) != FlagLeast(Int) {
+		t.Log("expected least bit of composed flag to be the lower types")
+		t.Fail()
+	}
+}
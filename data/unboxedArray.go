@@ -123,25 +123,21 @@ func NewUnboxed(flag TyNat, args ...Native) Sliceable {
 		d = BigIntVec{}
 		for _, dat := range args {
 			bi := dat.(BigIntVal)
-			d = append(d.(BigIntVec), big.NewInt(((*big.Int)(&bi)).Int64()))
+			d = append(d.(BigIntVec), new(big.Int).Set((*big.Int)(&bi)))
 		}
 
 	case BigFlt:
 		d = BigFltVec{}
 		for _, dat := range args {
 			bf := dat.(BigFltVal)
-			f, _ := ((*big.Float)(&bf)).Float64()
-			d = append(d.(BigFltVec), big.NewFloat(f))
+			d = append(d.(BigFltVec), new(big.Float).Copy((*big.Float)(&bf)))
 		}
 
 	case Ratio:
 		d = RatioVec{}
 		for _, dat := range args {
 			rat := dat.(RatioVal)
-			d = append(d.(RatioVec), big.NewRat(
-				((*big.Rat)(&rat)).Num().Int64(),
-				((*big.Rat)(&rat)).Denom().Int64(),
-			))
+			d = append(d.(RatioVec), new(big.Rat).Set((*big.Rat)(&rat)))
 		}
 
 	case Time:
@@ -161,6 +157,12 @@ func NewUnboxed(flag TyNat, args ...Native) Sliceable {
 		for _, dat := range args {
 			d = append(d.(ErrorVec), error(dat.(ErrorVal).E))
 		}
+
+	case Flag:
+		d = FlagSet{}
+		for _, dat := range args {
+			d = append(d.(FlagSet), dat.(BitFlag))
+		}
 	}
 	return d
 }
@@ -558,7 +560,7 @@ func (v RatioVec) TypeElem() Typed  { return Ratio }
 func (v TimeVec) TypeElem() Typed   { return Time }
 func (v DuraVec) TypeElem() Typed   { return Duration }
 func (v ErrorVec) TypeElem() Typed  { return Error }
-func (v FlagSet) TypeElem() Typed   { return Type }
+func (v FlagSet) TypeElem() Typed   { return Flag }
 
 func (v NilVec) Null() Native    { return NilVec([]struct{}{}) }
 func (v BoolVec) Null() Native   { return BoolVec([]bool{}) }
@@ -883,7 +885,7 @@ func (v RuneVec) Copy() Native {
 func (v BytesVec) Copy() Native {
 	var d = BytesVec{}
 	for _, val := range v {
-		d = append(d, val)
+		d = append(d, append([]byte{}, val...))
 	}
 	return d
 }
@@ -897,6 +899,9 @@ func (v StrVec) Copy() Native {
 func (v BigIntVec) Copy() Native {
 	var d = BigIntVec{}
 	for _, val := range v {
+		if val != nil {
+			val = new(big.Int).Set(val)
+		}
 		d = append(d, val)
 	}
 	return d
@@ -904,6 +909,9 @@ func (v BigIntVec) Copy() Native {
 func (v BigFltVec) Copy() Native {
 	var d = BigFltVec{}
 	for _, val := range v {
+		if val != nil {
+			val = new(big.Float).Copy(val)
+		}
 		d = append(d, val)
 	}
 	return d
@@ -911,6 +919,9 @@ func (v BigFltVec) Copy() Native {
 func (v RatioVec) Copy() Native {
 	var d = RatioVec{}
 	for _, val := range v {
+		if val != nil {
+			val = new(big.Rat).Set(val)
+		}
 		d = append(d, val)
 	}
 	return d
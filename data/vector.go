@@ -0,0 +1,63 @@
+package data
+
+import "fmt"
+
+//go:generate go run gen_vector.go
+
+//// VECTORIZED ARITHMETIC
+///
+// evaluates arithmetic and comparison operators element-wise on two numeric
+// unboxed vectors of the same type, or a vector and a scalar of its element
+// type. vectors of length one and scalars are applied to every element of
+// the other operand. arithmetic yields a vector of the same type, integers
+// wrap around like their scalar methods do, comparisons yield a BoolVec.
+// integer division by zero, vectors of differing length and operands that
+// don't match yield an error.
+func EvalVector(op OpStr, a, b Native) (Native, error) {
+	var vec = a
+	if a.Type() != Unboxed {
+		vec = b
+	}
+	if res, ok, err := evalVector(op, vec, a, b); ok {
+		return res, err
+	}
+	return nil, fmt.Errorf(
+		"element-wise %s is not defined for %s and %s",
+		op, vectorTypeName(a), vectorTypeName(b))
+}
+
+func vectorTypeName(v Native) string {
+	if v.Type() == Unboxed {
+		return typeElem(v).TypeName() + " vector"
+	}
+	return v.Type().TypeName()
+}
+
+// yields the length of the result and the index step of both operands. a
+// step of zero repeats the single element of an operand.
+func broadcast(v, w int) (n, sv, sw int, err error) {
+	switch {
+	case v == w:
+		return v, 1, 1, nil
+	case v == 1:
+		return w, 0, 1, nil
+	case w == 1:
+		return v, 1, 0, nil
+	}
+	return 0, 0, 0, fmt.Errorf(
+		"vectors of length %d and %d can not be combined", v, w)
+}
+
+func errVecZero(i int) error {
+	return fmt.Errorf("division by zero at index %d", i)
+}
+
+//// REDUCTIONS
+///
+// reductions loop over the elements of the underlying slice. sums and
+// products of integers wrap around on overflow. min and max return false
+// for empty vectors, not a number propagates like it does in math.Min and
+// math.Max. the dot product of imaginary vectors is not conjugated.
+func errDotLen(v, w int) error {
+	return fmt.Errorf("dot product of vectors with length %d and %d", v, w)
+}
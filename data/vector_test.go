@@ -0,0 +1,96 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestEvalVector(t *testing.T) {
+	var cases = []struct {
+		op   OpStr
+		a, b Native
+		res  Native
+	}{
+		{Add, IntVec{1, 2, 3}, IntVec{10, 20, 30}, IntVec{11, 22, 33}},
+		{Substract, IntVec{1, 2, 3}, IntVal(1), IntVec{0, 1, 2}},
+		{Substract, IntVal(10), IntVec{1, 2, 3}, IntVec{9, 8, 7}},
+		{Multiply, Uint8Vec{100, 2}, Uint8Val(3), Uint8Vec{44, 6}},
+		{Quotient, FltVec{1, 3}, FltVec{2}, FltVec{0.5, 1.5}},
+		{Quotient, Int32Vec{7, -9}, Int32Val(2), Int32Vec{3, -4}},
+		{Add, Flt32Vec{0.5}, Flt32Vec{1, 2}, Flt32Vec{1.5, 2.5}},
+		{Multiply, ImagVec{complex(0, 1)}, ImagVal(complex(0, 1)), ImagVec{-1}},
+		{Lesser, IntVec{1, 5, 3}, IntVal(3), BoolVec{true, false, false}},
+		{Geq, FltVec{1, 5}, FltVec{1, 6}, BoolVec{true, false}},
+		{Equal, ImagVec{1, 2}, ImagVal(2), BoolVec{false, true}},
+	}
+	for _, c := range cases {
+		var res, err = EvalVector(c.op, c.a, c.b)
+		fmt.Println(c.a, c.op, c.b, "=", res)
+		if err != nil || !DeepEqual(res, c.res) {
+			t.Log("expected", c.res, "got", res, err)
+			t.Fail()
+		}
+	}
+	var errs = []struct {
+		op   OpStr
+		a, b Native
+	}{
+		{Add, IntVec{1, 2}, IntVec{1, 2, 3}},
+		{Add, IntVec{1, 2}, FltVec{1, 2}},
+		{Add, IntVec{1, 2}, Int8Val(1)},
+		{Quotient, IntVec{1, 2}, IntVec{1, 0}},
+		{Lesser, ImagVec{1}, ImagVec{2}},
+		{Power, IntVec{1}, IntVec{2}},
+		{Add, IntVal(1), IntVal(2)},
+	}
+	for _, c := range errs {
+		if res, err := EvalVector(c.op, c.a, c.b); err == nil {
+			t.Log("expected error evaluating", c.a, c.op, c.b, "got", res)
+			t.Fail()
+		} else {
+			fmt.Println(err)
+		}
+	}
+}
+
+func TestReductions(t *testing.T) {
+	var v = IntVec{3, -1, 4, 1, 5}
+	if v.Sum() != 12 || v.Product() != -60 {
+		t.Log("unexpected sum or product", v.Sum(), v.Product())
+		t.Fail()
+	}
+	if m, ok := v.Min(); !ok || m != -1 {
+		t.Log("unexpected min", m)
+		t.Fail()
+	}
+	if m, ok := v.Max(); !ok || m != 5 {
+		t.Log("unexpected max", m)
+		t.Fail()
+	}
+	if _, ok := (UintVec{}).Min(); ok {
+		t.Log("min of empty vector should not be ok")
+		t.Fail()
+	}
+	if d, err := v.Dot(IntVec{1, 1, 1, 1, 2}); err != nil || d != 17 {
+		t.Log("unexpected dot product", d, err)
+		t.Fail()
+	}
+	if _, err := v.Dot(IntVec{1}); err == nil {
+		t.Log("dot product of vectors with different length should fail")
+		t.Fail()
+	}
+	var f = FltVec{1.5, math.NaN(), 0.5}
+	if m, _ := f.Max(); !math.IsNaN(float64(m)) {
+		t.Log("not a number should propagate", m)
+		t.Fail()
+	}
+	if s := (Flt32Vec{0.25, 0.5}).Sum(); s != 0.75 {
+		t.Log("unexpected sum", s)
+		t.Fail()
+	}
+	if d, _ := (ImagVec{complex(0, 1), 2}).Dot(ImagVec{complex(0, 1), 3}); d != 5 {
+		t.Log("unexpected imaginary dot product", d)
+		t.Fail()
+	}
+}
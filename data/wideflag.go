@@ -0,0 +1,217 @@
+package data
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+//// WIDE FLAGS
+///
+// bit flag of arbitrary width, words are stored least significant first.
+// flag sets that fit into a single word should stay bit flags, since their
+// operations compile down to single instructions. wide flags provide the
+// same operations for sets that need more than 64 flags. operations never
+// modify their operands, results are trimmed of empty high words, so that
+// equal flags have equal length.
+type WideFlag []uint64
+
+// implemented by flags of families, that exceed the 64 bits of a bit flag.
+// their flag method yields the least significant word.
+type WideFlagged interface {
+	Typed
+	Wide() WideFlag
+}
+
+// returns wide flag of the words passed, least significant word first
+func NewWideFlag(words ...uint64) WideFlag {
+	return WideFlag(append([]uint64{}, words...)).trim()
+}
+
+// returns a wide flag with the bit at index i set
+func WideBit(i int) WideFlag {
+	var w = make(WideFlag, i/64+1)
+	w[i/64] = 1 << uint(i%64)
+	return w
+}
+
+// returns a wide flag with all bits set, the flags passed have set
+func WideFlagOf(flags ...Typed) WideFlag {
+	var w = WideFlag{}
+	for _, f := range flags {
+		w = w.Concat(WideOf(f))
+	}
+	return w
+}
+
+// returns the wide flag of wide flagged types, or the bit flag as wide flag
+func WideOf(t Typed) WideFlag {
+	if w, ok := t.(WideFlagged); ok {
+		return w.Wide()
+	}
+	return t.Flag().Wide()
+}
+
+func (v WideFlag) trim() WideFlag {
+	var n = len(v)
+	for n > 0 && v[n-1] == 0 {
+		n--
+	}
+	return v[:n]
+}
+
+// returns the word at index i, words beyond the length are empty
+func (v WideFlag) word(i int) uint64 {
+	if i < len(v) {
+		return v[i]
+	}
+	return 0
+}
+
+// applies the function to all words of both flags
+func (v WideFlag) zip(w WideFlag, fn func(a, b uint64) uint64) WideFlag {
+	var n = len(v)
+	if len(w) > n {
+		n = len(w)
+	}
+	var r = make(WideFlag, n)
+	for i := range r {
+		r[i] = fn(v.word(i), w.word(i))
+	}
+	return r.trim()
+}
+
+// the least significant word, as bit flag
+func (v WideFlag) Low() BitFlag       { return BitFlag(v.word(0)) }
+func (v WideFlag) Words() []uint64    { return append([]uint64{}, v...) }
+func (v WideFlag) Empty() bool        { return len(v.trim()) == 0 }
+func (v WideFlag) Has(i int) bool     { return v.word(i/64)&(1<<uint(i%64)) != 0 }
+func (v WideFlag) Set(i int) WideFlag { return v.Concat(WideBit(i)) }
+
+// number of bits needed to represent the flag
+func (v WideFlag) Len() int {
+	var w = v.trim()
+	if len(w) == 0 {
+		return 0
+	}
+	return (len(w)-1)*64 + bits.Len64(w[len(w)-1])
+}
+
+// number of bits set
+func (v WideFlag) Count() int {
+	var n int
+	for _, u := range v {
+		n = n + bits.OnesCount64(u)
+	}
+	return n
+}
+
+// index of the least significant bit set, -1 if the flag is empty
+func (v WideFlag) Least() int {
+	for i, u := range v {
+		if u != 0 {
+			return i*64 + bits.TrailingZeros64(u)
+		}
+	}
+	return -1
+}
+
+// index of the most significant bit set, -1 if the flag is empty
+func (v WideFlag) Most() int { return v.Len() - 1 }
+
+func (v WideFlag) Concat(w WideFlag) WideFlag {
+	return v.zip(w, func(a, b uint64) uint64 { return a | b })
+}
+func (v WideFlag) Mask(w WideFlag) WideFlag {
+	return v.zip(w, func(a, b uint64) uint64 { return a &^ b })
+}
+func (v WideFlag) Toggle(w WideFlag) WideFlag {
+	return v.zip(w, func(a, b uint64) uint64 { return a ^ b })
+}
+func (v WideFlag) Intersect(w WideFlag) WideFlag {
+	return v.zip(w, func(a, b uint64) uint64 { return a & b })
+}
+
+func (v WideFlag) Equal(w WideFlag) bool {
+	var x, y = v.trim(), w.trim()
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// like flag match, a flag matches, if all its bits are set in the flag
+// passed. a flag with a single bit set matches a concatenated flag, if the
+// concatenation contains the bit.
+func (v WideFlag) Match(w WideFlag) bool {
+	if v.Count() > 1 && w.Count() == 1 {
+		return w.Match(v)
+	}
+	for i, u := range v {
+		if u&^w.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the indices of all bits set in ascending order
+func (v WideFlag) Indices() []int {
+	var idx = make([]int, 0, v.Count())
+	for i, u := range v {
+		for u != 0 {
+			var b = bits.TrailingZeros64(u)
+			idx = append(idx, i*64+b)
+			u = u &^ (1 << uint(b))
+		}
+	}
+	return idx
+}
+
+// decomposes the flag to a slice of flags with a single bit set each
+func (v WideFlag) Decompose() []WideFlag {
+	var flags = make([]WideFlag, 0, v.Count())
+	for _, i := range v.Indices() {
+		flags = append(flags, WideBit(i))
+	}
+	return flags
+}
+
+// string usable as map key, wide flags are not comparable
+func (v WideFlag) Key() string {
+	var str = make([]string, 0, len(v))
+	for _, u := range v.trim() {
+		str = append(str, strconv.FormatUint(u, 16))
+	}
+	return strings.Join(str, ".")
+}
+
+// bits are named by their index. flag families name their bits by passing
+// a name function to string wide flag.
+func (v WideFlag) String() string { return StringWideFlag(v, BitName) }
+
+func BitName(i int) string { return "Bit(" + strconv.Itoa(i) + ")" }
+
+// names bits within the range of native type flags by the type they
+// represent, other bits by their index.
+func NatBitName(i int) string {
+	if i < 64 {
+		return TyNat(1 << uint(i)).TypeName()
+	}
+	return BitName(i)
+}
+
+// serializes the flag as names of its bits, delimited like bit flags are
+func StringWideFlag(v WideFlag, name func(i int) string) string {
+	var idx = v.Indices()
+	var str = make([]string, 0, len(idx))
+	for _, i := range idx {
+		str = append(str, name(i))
+	}
+	return strings.Join(str, "∙")
+}
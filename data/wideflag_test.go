@@ -0,0 +1,57 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWideFlag(t *testing.T) {
+	var a = WideBit(3).Set(70).Set(130)
+	var b = WideFlagOf(Int, String).Set(70)
+	fmt.Println(a, b, a.Key())
+	if a.Count() != 3 || a.Len() != 131 || a.Least() != 3 || a.Most() != 130 {
+		t.Log("unexpected count, length, least or most", a.Count(), a.Len(), a.Least(), a.Most())
+		t.Fail()
+	}
+	if !a.Has(70) || a.Has(71) || a.Has(1000) {
+		t.Log("unexpected bits set", a.Indices())
+		t.Fail()
+	}
+	if fmt.Sprint(a.Indices()) != "[3 70 130]" || len(a.Decompose()) != 3 {
+		t.Log("unexpected decomposition", a.Indices(), a.Decompose())
+		t.Fail()
+	}
+	if c := a.Concat(b); c.Count() != 5 || !c.Match(a.Concat(b)) {
+		t.Log("unexpected concatenation", c)
+		t.Fail()
+	}
+	if m := a.Mask(WideBit(130)); len(m) != 2 || !m.Equal(WideBit(3).Set(70)) {
+		t.Log("masking should trim empty words", m, m.Words())
+		t.Fail()
+	}
+	if !a.Toggle(a).Empty() || !a.Intersect(b).Equal(WideBit(70)) {
+		t.Log("unexpected toggle, or intersection")
+		t.Fail()
+	}
+	if !WideBit(70).Match(a) || !a.Match(WideBit(70)) || a.Match(b) ||
+		!WideBit(3).Set(70).Match(a) {
+		t.Log("unexpected match results")
+		t.Fail()
+	}
+	if !NewWideFlag(5, 0, 0).Equal(NewWideFlag(5)) || NewWideFlag(5, 0, 0).Key() != "5" {
+		t.Log("flags differing by empty words should be equal")
+		t.Fail()
+	}
+	if b.Low() != (Int|String).Flag() || !Decimal.Flag().Wide().Equal(WideFlagOf(Decimal)) {
+		t.Log("unexpected conversion between bit flags and wide flags", b.Low())
+		t.Fail()
+	}
+	if s := StringWideFlag(b, NatBitName); s != "Int∙String∙Bit(70)" {
+		t.Log("unexpected string of native flags", s)
+		t.Fail()
+	}
+	if s := b.String(); s != "Bit(5)∙Bit(22)∙Bit(70)" {
+		t.Log("bits should be named by index, unless a name function is passed", s)
+		t.Fail()
+	}
+}
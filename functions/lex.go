@@ -8,20 +8,33 @@ import (
 )
 
 ///// SYNTAX DEFINITION /////
+// syntax items are indices of the bits of a wide flag, which keeps the
+// number of items from being limited to the 64 bits of a bit flag. the null
+// item has no bit set, the flag of an item is the least significant word of
+// its wide flag.
 type TyLex d.BitFlag
 
 func (t TyLex) TypeFnc() TyFnc                { return Lexical }
 func (t TyLex) TypeNat() d.TyNat              { return d.Type }
 func (t TyLex) Type() TyComp                  { return Def(t) }
 func (t TyLex) Kind() d.Uint8Val              { return Kind_Lex.U() }
-func (t TyLex) Flag() d.BitFlag               { return d.BitFlag(t) }
+func (t TyLex) Flag() d.BitFlag               { return t.Wide().Low() }
 func (t TyLex) Utf8() string                  { return mapUtf8[t] }
 func (t TyLex) Ascii() string                 { return mapAscii[t] }
 func (t TyLex) MatchUtf8(arg string) bool     { return t.Utf8() == arg }
 func (t TyLex) MatchAscii(arg string) bool    { return t.Ascii() == arg }
 func (t TyLex) TypeName() string              { return mapUtf8[t] }
 func (t TyLex) Call(...Expression) Expression { return t }
-func (t TyLex) Match(arg d.Typed) bool        { return t.Flag().Match(arg) }
+func (t TyLex) Match(arg d.Typed) bool        { return t.Wide().Match(d.WideOf(arg)) }
+func (t TyLex) Wide() d.WideFlag {
+	if t == Lex_Null {
+		return d.WideFlag{}
+	}
+	return d.WideBit(int(t))
+}
+
+// names the bit at index i by the syntax item it represents
+func LexBitName(i int) string { return TyLex(i).String() }
 func FindUtf8(arg string) (TyLex, bool) {
 	var lex, ok = mapUtf8Text[arg]
 	return lex, ok
@@ -33,24 +46,18 @@ func FindAscii(arg string) (TyLex, bool) {
 
 // slice of all syntax items in there int constant form
 var AllItems = func() []TyLex {
-	var (
-		tt = []TyLex{}
-		i  uint
-		t  TyLex = 0
-	)
-	for i < 63 {
-		t = 1 << i
-		i = i + 1
-		tt = append(tt, TyLex(t))
+	var tt = []TyLex{}
+	for t := Lex_Blank; t <= lastLex; t++ {
+		tt = append(tt, t)
 	}
 	return tt
 }()
 
 //go:generate stringer -type TyLex
 const (
-	Lex_Null  TyLex = 0
-	Lex_Blank TyLex = 1
-	Lex_Tab   TyLex = 1 << iota
+	Lex_Null TyLex = 0 + iota
+	Lex_Blank
+	Lex_Tab
 	Lex_NewLine
 	Lex_Underscore
 	Lex_Asterisk
@@ -110,6 +117,8 @@ const (
 	Lex_SubSet
 	Lex_EmptySet
 	Lex_Pi
+
+	lastLex = Lex_Pi
 )
 
 var mapUtf8 = map[TyLex]string{
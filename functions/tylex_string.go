@@ -10,138 +10,75 @@ func _() {
 	var x [1]struct{}
 	_ = x[Lex_Null-0]
 	_ = x[Lex_Blank-1]
-	_ = x[Lex_Tab-4]
-	_ = x[Lex_NewLine-8]
-	_ = x[Lex_Underscore-16]
-	_ = x[Lex_Asterisk-32]
-	_ = x[Lex_Fullstop-64]
-	_ = x[Lex_Ellipsis-128]
-	_ = x[Lex_Negative-256]
-	_ = x[Lex_Positive-512]
-	_ = x[Lex_SquareRoot-1024]
-	_ = x[Lex_Dot-2048]
-	_ = x[Lex_Times-4096]
-	_ = x[Lex_DotProduct-8192]
-	_ = x[Lex_CrossProduct-16384]
-	_ = x[Lex_Division-32768]
-	_ = x[Lex_Infinite-65536]
-	_ = x[Lex_And-131072]
-	_ = x[Lex_Or-262144]
-	_ = x[Lex_Xor-524288]
-	_ = x[Lex_Equal-1048576]
-	_ = x[Lex_Unequal-2097152]
-	_ = x[Lex_Lesser-4194304]
-	_ = x[Lex_Greater-8388608]
-	_ = x[Lex_LesserEq-16777216]
-	_ = x[Lex_GreaterEq-33554432]
-	_ = x[Lex_LeftPar-67108864]
-	_ = x[Lex_RightPar-134217728]
-	_ = x[Lex_LeftBra-268435456]
-	_ = x[Lex_RightBra-536870912]
-	_ = x[Lex_LeftCur-1073741824]
-	_ = x[Lex_RightCur-2147483648]
-	_ = x[Lex_LeftLace-4294967296]
-	_ = x[Lex_RightLace-8589934592]
-	_ = x[Lex_SingQuote-17179869184]
-	_ = x[Lex_DoubQuote-34359738368]
-	_ = x[Lex_BackTick-68719476736]
-	_ = x[Lex_BackSlash-137438953472]
-	_ = x[Lex_Slash-274877906944]
-	_ = x[Lex_Pipe-549755813888]
-	_ = x[Lex_Not-1099511627776]
-	_ = x[Lex_Decrement-2199023255552]
-	_ = x[Lex_Increment-4398046511104]
-	_ = x[Lex_TripEqual-8796093022208]
-	_ = x[Lex_RightArrow-17592186044416]
-	_ = x[Lex_LeftArrow-35184372088832]
-	_ = x[Lex_LeftFatArrow-70368744177664]
-	_ = x[Lex_RightFatArrow-140737488355328]
-	_ = x[Lex_DoubleFatArrow-281474976710656]
-	_ = x[Lex_Sequence-562949953421312]
-	_ = x[Lex_SequenceRev-1125899906842624]
-	_ = x[Lex_DoubCol-2251799813685248]
-	_ = x[Lex_Application-4503599627370496]
-	_ = x[Lex_Lambda-9007199254740992]
-	_ = x[Lex_Function-18014398509481984]
-	_ = x[Lex_Polymorph-36028797018963968]
-	_ = x[Lex_Monad-72057594037927936]
-	_ = x[Lex_Parameter-144115188075855872]
-	_ = x[Lex_Integral-288230376151711744]
-	_ = x[Lex_SubSet-576460752303423488]
-	_ = x[Lex_EmptySet-1152921504606846976]
-	_ = x[Lex_Pi-2305843009213693952]
+	_ = x[Lex_Tab-2]
+	_ = x[Lex_NewLine-3]
+	_ = x[Lex_Underscore-4]
+	_ = x[Lex_Asterisk-5]
+	_ = x[Lex_Fullstop-6]
+	_ = x[Lex_Ellipsis-7]
+	_ = x[Lex_Negative-8]
+	_ = x[Lex_Positive-9]
+	_ = x[Lex_SquareRoot-10]
+	_ = x[Lex_Dot-11]
+	_ = x[Lex_Times-12]
+	_ = x[Lex_DotProduct-13]
+	_ = x[Lex_CrossProduct-14]
+	_ = x[Lex_Division-15]
+	_ = x[Lex_Infinite-16]
+	_ = x[Lex_And-17]
+	_ = x[Lex_Or-18]
+	_ = x[Lex_Xor-19]
+	_ = x[Lex_Equal-20]
+	_ = x[Lex_Unequal-21]
+	_ = x[Lex_Lesser-22]
+	_ = x[Lex_Greater-23]
+	_ = x[Lex_LesserEq-24]
+	_ = x[Lex_GreaterEq-25]
+	_ = x[Lex_LeftPar-26]
+	_ = x[Lex_RightPar-27]
+	_ = x[Lex_LeftBra-28]
+	_ = x[Lex_RightBra-29]
+	_ = x[Lex_LeftCur-30]
+	_ = x[Lex_RightCur-31]
+	_ = x[Lex_LeftLace-32]
+	_ = x[Lex_RightLace-33]
+	_ = x[Lex_SingQuote-34]
+	_ = x[Lex_DoubQuote-35]
+	_ = x[Lex_BackTick-36]
+	_ = x[Lex_BackSlash-37]
+	_ = x[Lex_Slash-38]
+	_ = x[Lex_Pipe-39]
+	_ = x[Lex_Not-40]
+	_ = x[Lex_Decrement-41]
+	_ = x[Lex_Increment-42]
+	_ = x[Lex_TripEqual-43]
+	_ = x[Lex_RightArrow-44]
+	_ = x[Lex_LeftArrow-45]
+	_ = x[Lex_LeftFatArrow-46]
+	_ = x[Lex_RightFatArrow-47]
+	_ = x[Lex_DoubleFatArrow-48]
+	_ = x[Lex_Sequence-49]
+	_ = x[Lex_SequenceRev-50]
+	_ = x[Lex_DoubCol-51]
+	_ = x[Lex_Application-52]
+	_ = x[Lex_Lambda-53]
+	_ = x[Lex_Function-54]
+	_ = x[Lex_Polymorph-55]
+	_ = x[Lex_Monad-56]
+	_ = x[Lex_Parameter-57]
+	_ = x[Lex_Integral-58]
+	_ = x[Lex_SubSet-59]
+	_ = x[Lex_EmptySet-60]
+	_ = x[Lex_Pi-61]
 }
 
 const _TyLex_name = "Lex_NullLex_BlankLex_TabLex_NewLineLex_UnderscoreLex_AsteriskLex_FullstopLex_EllipsisLex_NegativeLex_PositiveLex_SquareRootLex_DotLex_TimesLex_DotProductLex_CrossProductLex_DivisionLex_InfiniteLex_AndLex_OrLex_XorLex_EqualLex_UnequalLex_LesserLex_GreaterLex_LesserEqLex_GreaterEqLex_LeftParLex_RightParLex_LeftBraLex_RightBraLex_LeftCurLex_RightCurLex_LeftLaceLex_RightLaceLex_SingQuoteLex_DoubQuoteLex_BackTickLex_BackSlashLex_SlashLex_PipeLex_NotLex_DecrementLex_IncrementLex_TripEqualLex_RightArrowLex_LeftArrowLex_LeftFatArrowLex_RightFatArrowLex_DoubleFatArrowLex_SequenceLex_SequenceRevLex_DoubColLex_ApplicationLex_LambdaLex_FunctionLex_PolymorphLex_MonadLex_ParameterLex_IntegralLex_SubSetLex_EmptySetLex_Pi"
 
-var _TyLex_map = map[TyLex]string{
-	0:                   _TyLex_name[0:8],
-	1:                   _TyLex_name[8:17],
-	4:                   _TyLex_name[17:24],
-	8:                   _TyLex_name[24:35],
-	16:                  _TyLex_name[35:49],
-	32:                  _TyLex_name[49:61],
-	64:                  _TyLex_name[61:73],
-	128:                 _TyLex_name[73:85],
-	256:                 _TyLex_name[85:97],
-	512:                 _TyLex_name[97:109],
-	1024:                _TyLex_name[109:123],
-	2048:                _TyLex_name[123:130],
-	4096:                _TyLex_name[130:139],
-	8192:                _TyLex_name[139:153],
-	16384:               _TyLex_name[153:169],
-	32768:               _TyLex_name[169:181],
-	65536:               _TyLex_name[181:193],
-	131072:              _TyLex_name[193:200],
-	262144:              _TyLex_name[200:206],
-	524288:              _TyLex_name[206:213],
-	1048576:             _TyLex_name[213:222],
-	2097152:             _TyLex_name[222:233],
-	4194304:             _TyLex_name[233:243],
-	8388608:             _TyLex_name[243:254],
-	16777216:            _TyLex_name[254:266],
-	33554432:            _TyLex_name[266:279],
-	67108864:            _TyLex_name[279:290],
-	134217728:           _TyLex_name[290:302],
-	268435456:           _TyLex_name[302:313],
-	536870912:           _TyLex_name[313:325],
-	1073741824:          _TyLex_name[325:336],
-	2147483648:          _TyLex_name[336:348],
-	4294967296:          _TyLex_name[348:360],
-	8589934592:          _TyLex_name[360:373],
-	17179869184:         _TyLex_name[373:386],
-	34359738368:         _TyLex_name[386:399],
-	68719476736:         _TyLex_name[399:411],
-	137438953472:        _TyLex_name[411:424],
-	274877906944:        _TyLex_name[424:433],
-	549755813888:        _TyLex_name[433:441],
-	1099511627776:       _TyLex_name[441:448],
-	2199023255552:       _TyLex_name[448:461],
-	4398046511104:       _TyLex_name[461:474],
-	8796093022208:       _TyLex_name[474:487],
-	17592186044416:      _TyLex_name[487:501],
-	35184372088832:      _TyLex_name[501:514],
-	70368744177664:      _TyLex_name[514:530],
-	140737488355328:     _TyLex_name[530:547],
-	281474976710656:     _TyLex_name[547:565],
-	562949953421312:     _TyLex_name[565:577],
-	1125899906842624:    _TyLex_name[577:592],
-	2251799813685248:    _TyLex_name[592:603],
-	4503599627370496:    _TyLex_name[603:618],
-	9007199254740992:    _TyLex_name[618:628],
-	18014398509481984:   _TyLex_name[628:640],
-	36028797018963968:   _TyLex_name[640:653],
-	72057594037927936:   _TyLex_name[653:662],
-	144115188075855872:  _TyLex_name[662:675],
-	288230376151711744:  _TyLex_name[675:687],
-	576460752303423488:  _TyLex_name[687:697],
-	1152921504606846976: _TyLex_name[697:709],
-	2305843009213693952: _TyLex_name[709:715],
-}
+var _TyLex_index = [...]uint16{0, 8, 17, 24, 35, 49, 61, 73, 85, 97, 109, 123, 130, 139, 153, 169, 181, 193, 200, 206, 213, 222, 233, 243, 254, 266, 279, 290, 302, 313, 325, 336, 348, 360, 373, 386, 399, 411, 424, 433, 441, 448, 461, 474, 487, 501, 514, 530, 547, 565, 577, 592, 603, 618, 628, 640, 653, 662, 675, 687, 697, 709, 715}
 
 func (i TyLex) String() string {
-	if str, ok := _TyLex_map[i]; ok {
-		return str
+	if i >= TyLex(len(_TyLex_index)-1) {
+		return "TyLex(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return "TyLex(" + strconv.FormatInt(int64(i), 10) + ")"
+	return _TyLex_name[_TyLex_index[i]:_TyLex_index[i+1]]
 }
@@ -169,11 +169,29 @@ func compToExpr(comps []TyComp) []Expression {
 func (t TyFnc) TypeFnc() TyFnc                     { return Type }
 func (t TyFnc) TypeNat() d.TyNat                   { return d.Type }
 func (t TyFnc) Flag() d.BitFlag                    { return d.BitFlag(t) }
+func (t TyFnc) Wide() d.WideFlag                   { return t.Flag().Wide() }
 func (t TyFnc) Uint() d.UintVal                    { return d.BitFlag(t).Uint() }
 func (t TyFnc) Kind() d.Uint8Val                   { return Kind_Fnc.U() }
 func (t TyFnc) Call(args ...Expression) Expression { return t.TypeFnc() }
 func (t TyFnc) Type() TyComp                       { return Def(t) }
-func (t TyFnc) Match(arg d.Typed) bool             { return t.Flag().Match(arg) }
+
+// functional type flags are matched as bit flags, flags of wide flagged
+// families, that exceed a bit flag, as wide flags.
+func (t TyFnc) Match(arg d.Typed) bool {
+	if w, ok := arg.(d.WideFlagged); ok && !Kind_Fnc.Match(arg.Kind()) {
+		return t.Wide().Match(w.Wide())
+	}
+	return t.Flag().Match(arg)
+}
+
+// names the bit at index i by the functional type it represents
+func FncBitName(i int) string {
+	if i < 64 {
+		return TyFnc(1 << uint(i)).String()
+	}
+	return d.BitName(i)
+}
+
 func (t TyFnc) TypeName() string {
 	var count = t.Flag().Count()
 	// loop to print concatenated type classes correcty
@@ -198,15 +216,11 @@ func (t TyFnc) TypeName() string {
 		case Continues:
 			return "Funtors"
 		}
-		var delim = "|"
-		var str string
-		for i, flag := range t.Flag().Decompose() {
-			str = str + TyFnc(flag.Flag()).String()
-			if i < count-1 {
-				str = str + delim
-			}
+		var str = make([]string, 0, count)
+		for _, i := range t.Wide().Indices() {
+			str = append(str, FncBitName(i))
 		}
-		return str
+		return strings.Join(str, "|")
 	}
 	return t.String()
 }
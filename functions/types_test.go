@@ -33,3 +33,29 @@ func TestPattern(t *testing.T) {
 
 func TestNestedPattern(t *testing.T) {
 }
+
+func TestWideFlags(t *testing.T) {
+	var wide = TyLex(100)
+	fmt.Println(AllItems, d.StringWideFlag(Lex_Tab.Wide().Concat(Lex_Pi.Wide()), LexBitName))
+	if len(AllItems) != int(Lex_Pi) || !wide.Wide().Has(100) || wide.Flag() != 0 {
+		t.Log("syntax items should be bit indices of wide flags", len(AllItems), wide.Wide())
+		t.Fail()
+	}
+	if !Lex_Pi.Match(Lex_Pi) || Lex_Pi.Match(Lex_Tab) || wide.Match(Lex_Pi) ||
+		!wide.Match(wide) {
+		t.Log("unexpected match of syntax items")
+		t.Fail()
+	}
+	if s := d.StringWideFlag(Lex_Tab.Wide().Concat(Lex_Pi.Wide()), LexBitName); s != "Lex_Tab∙Lex_Pi" {
+		t.Log("unexpected names of syntax items", s)
+		t.Fail()
+	}
+	if s := (Key | Pair).TypeName(); s != "Key|Pair" || FncBitName(64) != "Bit(64)" {
+		t.Log("unexpected name of functional type", s)
+		t.Fail()
+	}
+	if !Pair.Match(Key|Pair) || Pair.Match(wide) {
+		t.Log("unexpected match of functional types")
+		t.Fail()
+	}
+}